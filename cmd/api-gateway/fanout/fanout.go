@@ -0,0 +1,66 @@
+// Package fanout calls several backends concurrently for composite responses such as the dashboard.
+//
+// It works like errgroup, with two differences suited to responses assembled from independent sections:
+// a failing section does not cancel the others, and every section runs with its own deadline,
+// so one slow or unavailable backend only costs its own part of the response.
+package fanout
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Group runs named sections concurrently and collects their errors
+type Group struct {
+	ctx context.Context
+	wg  sync.WaitGroup
+
+	mu   sync.Mutex
+	errs map[string]error
+}
+
+// New returns a group whose sections run under ctx (e.g. the request context, so that
+// sections stop when the client goes away)
+func New(ctx context.Context) *Group {
+	return &Group{ctx: ctx, errs: make(map[string]error)}
+}
+
+// Go runs fn for the named section in a new goroutine. fn gets a context that expires after
+// timeout; it should store its result itself (each section writing its own variable) and return
+// an error if the section could not be loaded. A panic in fn is reported as the section's error.
+func (g *Group) Go(name string, timeout time.Duration, fn func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		ctx, cancel := context.WithTimeout(g.ctx, timeout)
+		defer cancel()
+
+		if err := run(ctx, fn); err != nil {
+			g.mu.Lock()
+			g.errs[name] = err
+			g.mu.Unlock()
+		}
+	}()
+}
+
+// Wait waits for all sections and returns the errors of the failed ones by section name.
+// The map is empty if every section succeeded.
+func (g *Group) Wait() map[string]error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.errs
+}
+
+// run calls fn, turning a panic into an error
+func run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -12,11 +12,22 @@ import (
 	"strings"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
 
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
 	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
 )
 
+const (
+	// accessTokenCookie holds the JWT access token
+	accessTokenCookie = "auth_token"
+	// refreshTokenCookie holds the refresh token used by POST /token/refresh
+	refreshTokenCookie = "refresh_token"
+)
+
 // AuthHandler handles authentication-related endpoints
 type AuthHandler struct {
 	authClient pbAuth.AuthServiceClient
@@ -62,33 +73,32 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("[BFF] Calling Login")
 	res, err := h.authClient.Login(ctx, &pbAuth.LoginRequest{
-		Email:    reqBody.Email,
-		Password: reqBody.Password,
+		Email:     reqBody.Email,
+		Password:  reqBody.Password,
+		UserAgent: r.UserAgent(),
+		IpAddress: utils.ClientIP(r),
 	})
 	if err != nil {
 		log.Printf("❌ Login failed: %v", err)
-		utils.ErrorResponse(w, http.StatusInternalServerError, "Login failed")
+		utils.GRPCErrorResponse(w, err, "Login failed")
 		return
 	}
 
-	// Set httpOnly Cookie for secure token storage
-	// For cross-origin requests (production), use SameSite=None with Secure=true
-	// For same-origin requests (development), use SameSite=Lax
-	isSecure := os.Getenv("ENVIRONMENT") == "production" || os.Getenv("HTTPS_ENABLED") == "true"
-	sameSite := http.SameSiteLaxMode
-	if isSecure {
-		// Cross-origin requests require SameSite=None with Secure=true
-		sameSite = http.SameSiteNoneMode
+	// The password was right, but a second factor is needed before any cookie is set
+	if res.MfaRequired {
+		utils.SuccessResponse(w, map[string]interface{}{
+			"message":             "MFA required",
+			"mfa_required":        true,
+			"mfa_token":           res.MfaToken,
+			"expires_in":          res.MfaExpiresIn,
+			"enrollment_required": res.MfaEnrollmentRequired,
+		})
+		return
 	}
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    res.AccessToken,
-		HttpOnly: true,
-		Secure:   isSecure,
-		SameSite: sameSite,
-		MaxAge:   int(res.ExpiresIn),
-		Path:     "/",
-	})
+
+	// Set httpOnly Cookies for secure token storage
+	setAuthCookie(w, accessTokenCookie, res.AccessToken, int(res.ExpiresIn))
+	setAuthCookie(w, refreshTokenCookie, res.RefreshToken, int(res.RefreshExpiresIn))
 
 	utils.SuccessResponse(w, map[string]interface{}{
 		"message":    "Login successful",
@@ -96,6 +106,43 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Refresh issues a new access token using the refresh token cookie.
+// The refresh token is rotated; both cookies are replaced.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(refreshTokenCookie)
+	if err != nil || cookie.Value == "" {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "Missing refresh token")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.Refresh(ctx, &pbAuth.RefreshRequest{
+		RefreshToken: cookie.Value,
+	})
+	if err != nil {
+		log.Printf("❌ Token refresh failed: %v", err)
+		if code := status.Code(err); code == codes.InvalidArgument || code == codes.Unauthenticated {
+			// The session is over; clear the cookies so the client signs in again
+			setAuthCookie(w, accessTokenCookie, "", -1)
+			setAuthCookie(w, refreshTokenCookie, "", -1)
+			utils.ErrorCodeResponse(w, http.StatusUnauthorized, "invalid_refresh_token", "Invalid or expired refresh token")
+			return
+		}
+		utils.GRPCErrorResponse(w, err, "Token refresh failed")
+		return
+	}
+
+	setAuthCookie(w, accessTokenCookie, res.AccessToken, int(res.ExpiresIn))
+	setAuthCookie(w, refreshTokenCookie, res.RefreshToken, int(res.RefreshExpiresIn))
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message":    "Token refreshed",
+		"expires_in": res.ExpiresIn,
+	})
+}
+
 // Signup handles user registration
 func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 	var reqBody struct {
@@ -148,12 +195,7 @@ func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		log.Printf("❌ Signup failed: %v", err)
-		// Check for specific error types
-		if strings.Contains(err.Error(), "already registered") || strings.Contains(err.Error(), "duplicate") {
-			utils.ErrorResponse(w, http.StatusConflict, "Email already registered")
-			return
-		}
-		utils.ErrorResponse(w, http.StatusInternalServerError, "Registration failed")
+		utils.GRPCErrorResponse(w, err, "Registration failed")
 		return
 	}
 
@@ -164,9 +206,304 @@ func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// Logout handles user logout by clearing the auth cookie
+// Logout handles user logout by revoking the access token, ending the session and clearing the auth cookies
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	// Clear the auth cookie
+	var refreshToken string
+	if cookie, err := r.Cookie(refreshTokenCookie); err == nil {
+		refreshToken = cookie.Value
+	}
+	accessToken := middleware.BearerToken(r)
+
+	if refreshToken != "" || accessToken != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// The cookies are cleared even if the tokens could not be revoked
+		if _, err := h.authClient.Logout(ctx, &pbAuth.LogoutRequest{
+			RefreshToken: refreshToken,
+			AccessToken:  accessToken,
+		}); err != nil {
+			log.Printf("❌ Logout failed: %v", err)
+		}
+	}
+
+	// Clear the auth cookies
+	setAuthCookie(w, accessTokenCookie, "", -1)
+	setAuthCookie(w, refreshTokenCookie, "", -1)
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message": "Logout successful",
+	})
+}
+
+// LogoutEverywhere signs the current user out on every device
+func (h *AuthHandler) LogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.RevokeAllSessions(ctx, &pbAuth.RevokeAllSessionsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		log.Printf("❌ Logout everywhere failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Logout failed")
+		return
+	}
+
+	setAuthCookie(w, accessTokenCookie, "", -1)
+	setAuthCookie(w, refreshTokenCookie, "", -1)
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message":          "Logged out on all devices",
+		"revoked_sessions": res.RevokedCount,
+	})
+}
+
+// ChangePassword changes the current user's password; other devices are signed out
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	sessionID, _ := middleware.GetSessionID(r)
+
+	var reqBody struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if reqBody.CurrentPassword == "" {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Current password is required")
+		return
+	}
+	if err := validatePassword(reqBody.NewPassword); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.ChangePassword(ctx, &pbAuth.ChangePasswordRequest{
+		UserId:          userID,
+		SessionId:       sessionID,
+		CurrentPassword: reqBody.CurrentPassword,
+		NewPassword:     reqBody.NewPassword,
+	})
+	if err != nil {
+		log.Printf("❌ Password change failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Password change failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message":          "Password changed",
+		"revoked_sessions": res.RevokedCount,
+	})
+}
+
+// ForgotPassword sends a password reset link to the given email address.
+// The response does not reveal whether the address is registered.
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if strings.TrimSpace(reqBody.Email) == "" {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := h.authClient.RequestPasswordReset(ctx, &pbAuth.RequestPasswordResetRequest{
+		Email: reqBody.Email,
+	})
+	if err != nil {
+		log.Printf("❌ Password reset request failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Password reset request failed")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusAccepted, map[string]interface{}{
+		"message": "If the email address is registered, a password reset link has been sent",
+	})
+}
+
+// ResetPassword sets a new password with the token from a reset link
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"new_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if reqBody.Token == "" {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+	if err := validatePassword(reqBody.NewPassword); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.ResetPassword(ctx, &pbAuth.ResetPasswordRequest{
+		Token:       reqBody.Token,
+		NewPassword: reqBody.NewPassword,
+	})
+	if err != nil {
+		log.Printf("❌ Password reset failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Password reset failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message":          "Password reset",
+		"revoked_sessions": res.RevokedCount,
+	})
+}
+
+// VerifyEmail verifies an email address with the token from the link sent at signup
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.VerifyEmail(ctx, &pbAuth.VerifyEmailRequest{
+		Token: token,
+	})
+	if err != nil {
+		log.Printf("❌ Email verification failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Email verification failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message": "Email address verified",
+		"user_id": res.UserId,
+	})
+}
+
+// ResendVerificationEmail sends a new verification link to the current user
+func (h *AuthHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := h.authClient.ResendVerificationEmail(ctx, &pbAuth.ResendVerificationEmailRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		log.Printf("❌ Resending verification email failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Resending verification email failed")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusAccepted, map[string]interface{}{
+		"message": "A new verification link has been sent",
+	})
+}
+
+// UnlockAccount lifts a login lockout with the token from the link emailed at the lockout
+func (h *AuthHandler) UnlockAccount(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if reqBody.Token == "" {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := h.authClient.UnlockAccount(ctx, &pbAuth.UnlockAccountRequest{
+		Token:     reqBody.Token,
+		IpAddress: utils.ClientIP(r),
+	})
+	if err != nil {
+		log.Printf("❌ Account unlock failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Account unlock failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message": "Account unlocked",
+	})
+}
+
+// JWKS serves the public keys access tokens are signed with, for verifiers outside the Auth Service
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.GetJWKS(ctx, &pbAuth.GetJWKSRequest{})
+	if err != nil {
+		log.Printf("❌ Failed to get JWKS: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to get signing keys")
+		return
+	}
+
+	keys := make([]map[string]interface{}, 0, len(res.Keys))
+	for _, key := range res.Keys {
+		jwk := map[string]interface{}{
+			"kty": key.Kty,
+			"use": key.Use,
+			"alg": key.Alg,
+			"kid": key.Kid,
+		}
+		switch key.Kty {
+		case "OKP":
+			jwk["crv"] = key.Crv
+			jwk["x"] = key.X
+		case "RSA":
+			jwk["n"] = key.N
+			jwk["e"] = key.E
+		}
+		keys = append(keys, jwk)
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", res.MaxAge))
+	utils.SuccessResponse(w, map[string]interface{}{
+		"keys": keys,
+	})
+}
+
+// setAuthCookie sets an httpOnly cookie; a negative maxAge deletes it
+func setAuthCookie(w http.ResponseWriter, name, value string, maxAge int) {
 	// For cross-origin requests (production), use SameSite=None with Secure=true
 	// For same-origin requests (development), use SameSite=Lax
 	isSecure := os.Getenv("ENVIRONMENT") == "production" || os.Getenv("HTTPS_ENABLED") == "true"
@@ -176,18 +513,14 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		sameSite = http.SameSiteNoneMode
 	}
 	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    "",
+		Name:     name,
+		Value:    value,
 		HttpOnly: true,
 		Secure:   isSecure,
 		SameSite: sameSite,
-		MaxAge:   -1, // Delete the cookie
+		MaxAge:   maxAge,
 		Path:     "/",
 	})
-
-	utils.SuccessResponse(w, map[string]interface{}{
-		"message": "Logout successful",
-	})
 }
 
 // isValidEmail validates email format using regex
@@ -233,4 +566,3 @@ func validatePassword(password string) error {
 
 	return nil
 }
-
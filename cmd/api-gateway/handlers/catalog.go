@@ -0,0 +1,557 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pbCatalog "github.com/karimiku/smart-stay-platform/pkg/genproto/catalog"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
+)
+
+// CatalogHandler handles property and room management endpoints for owners
+type CatalogHandler struct {
+	catalogClient pbCatalog.CatalogServiceClient
+}
+
+// NewCatalogHandler creates a new catalog handler
+func NewCatalogHandler(catalogClient pbCatalog.CatalogServiceClient) *CatalogHandler {
+	return &CatalogHandler{
+		catalogClient: catalogClient,
+	}
+}
+
+// propertyRequest is the request body for creating or updating a property
+type propertyRequest struct {
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	Timezone     string `json:"timezone"`
+	CheckInTime  string `json:"check_in_time"`  // HH:MM, property-local
+	CheckOutTime string `json:"check_out_time"` // HH:MM, property-local
+	IsActive     *bool  `json:"is_active"`
+}
+
+// roomRequest is the request body for creating or updating a room
+type roomRequest struct {
+	Name          string   `json:"name"`
+	Capacity      int32    `json:"capacity"`
+	Amenities     []string `json:"amenities"`
+	LockDeviceID  string   `json:"lock_device_id"`
+	IsActive      *bool    `json:"is_active"`
+	BaseRate      int64    `json:"base_rate"`
+	MinStayNights int32    `json:"min_stay_nights"`
+}
+
+// seasonalRateRequest is the request body for creating a seasonal rate
+type seasonalRateRequest struct {
+	Name        string `json:"name"`
+	StartDate   string `json:"start_date"` // Format: YYYY-MM-DD
+	EndDate     string `json:"end_date"`   // Format: YYYY-MM-DD (exclusive)
+	NightlyRate int64  `json:"nightly_rate"`
+}
+
+// CreateProperty handles property registration
+func (h *CatalogHandler) CreateProperty(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var reqBody propertyRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.CreateProperty(ctx, &pbCatalog.CreatePropertyRequest{
+		OwnerId:      ownerID,
+		Name:         reqBody.Name,
+		Address:      reqBody.Address,
+		Timezone:     reqBody.Timezone,
+		CheckInTime:  reqBody.CheckInTime,
+		CheckOutTime: reqBody.CheckOutTime,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to create property")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, propertyToJSON(res.Property))
+}
+
+// ListProperties handles listing the caller's properties
+func (h *CatalogHandler) ListProperties(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.ListProperties(ctx, &pbCatalog.ListPropertiesRequest{
+		OwnerId: ownerID,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to list properties")
+		return
+	}
+
+	properties := []map[string]interface{}{}
+	for _, property := range res.Properties {
+		properties = append(properties, propertyToJSON(property))
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"properties": properties,
+	})
+}
+
+// GetProperty handles retrieving one of the caller's properties
+func (h *CatalogHandler) GetProperty(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	propertyID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.GetProperty(ctx, &pbCatalog.GetPropertyRequest{
+		OwnerId:    ownerID,
+		PropertyId: propertyID,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to get property")
+		return
+	}
+
+	utils.SuccessResponse(w, propertyToJSON(res.Property))
+}
+
+// UpdateProperty handles replacing the editable fields of a property
+func (h *CatalogHandler) UpdateProperty(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	propertyID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var reqBody propertyRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.UpdateProperty(ctx, &pbCatalog.UpdatePropertyRequest{
+		OwnerId:      ownerID,
+		PropertyId:   propertyID,
+		Name:         reqBody.Name,
+		Address:      reqBody.Address,
+		Timezone:     reqBody.Timezone,
+		CheckInTime:  reqBody.CheckInTime,
+		CheckOutTime: reqBody.CheckOutTime,
+		IsActive:     reqBody.IsActive == nil || *reqBody.IsActive, // Active unless explicitly disabled
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to update property")
+		return
+	}
+
+	utils.SuccessResponse(w, propertyToJSON(res.Property))
+}
+
+// DeleteProperty handles deleting one of the caller's properties
+func (h *CatalogHandler) DeleteProperty(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	propertyID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := h.catalogClient.DeleteProperty(ctx, &pbCatalog.DeletePropertyRequest{
+		OwnerId:    ownerID,
+		PropertyId: propertyID,
+	}); err != nil {
+		catalogErrorResponse(w, err, "Failed to delete property")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message": "Property deleted",
+	})
+}
+
+// CreateRoom handles adding a room to a property
+func (h *CatalogHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	propertyID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var reqBody roomRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.CreateRoom(ctx, &pbCatalog.CreateRoomRequest{
+		OwnerId:       ownerID,
+		PropertyId:    propertyID,
+		Name:          reqBody.Name,
+		Capacity:      reqBody.Capacity,
+		Amenities:     reqBody.Amenities,
+		LockDeviceId:  reqBody.LockDeviceID,
+		BaseRate:      reqBody.BaseRate,
+		MinStayNights: reqBody.MinStayNights,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to create room")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, roomToJSON(res.Room))
+}
+
+// ListRooms handles listing the rooms of a property
+func (h *CatalogHandler) ListRooms(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	propertyID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.ListRooms(ctx, &pbCatalog.ListRoomsRequest{
+		OwnerId:    ownerID,
+		PropertyId: propertyID,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to list rooms")
+		return
+	}
+
+	rooms := []map[string]interface{}{}
+	for _, room := range res.Rooms {
+		rooms = append(rooms, roomToJSON(room))
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"rooms": rooms,
+	})
+}
+
+// GetRoom handles retrieving a room
+func (h *CatalogHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	roomID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.GetRoom(ctx, &pbCatalog.GetRoomRequest{
+		OwnerId: ownerID,
+		RoomId:  roomID,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to get room")
+		return
+	}
+
+	utils.SuccessResponse(w, roomToJSON(res.Room))
+}
+
+// UpdateRoom handles replacing the editable fields of a room
+func (h *CatalogHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	roomID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var reqBody roomRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.UpdateRoom(ctx, &pbCatalog.UpdateRoomRequest{
+		OwnerId:       ownerID,
+		RoomId:        roomID,
+		Name:          reqBody.Name,
+		Capacity:      reqBody.Capacity,
+		Amenities:     reqBody.Amenities,
+		LockDeviceId:  reqBody.LockDeviceID,
+		IsActive:      reqBody.IsActive == nil || *reqBody.IsActive, // Active unless explicitly disabled
+		BaseRate:      reqBody.BaseRate,
+		MinStayNights: reqBody.MinStayNights,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to update room")
+		return
+	}
+
+	utils.SuccessResponse(w, roomToJSON(res.Room))
+}
+
+// DeleteRoom handles deleting a room
+func (h *CatalogHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	roomID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := h.catalogClient.DeleteRoom(ctx, &pbCatalog.DeleteRoomRequest{
+		OwnerId: ownerID,
+		RoomId:  roomID,
+	}); err != nil {
+		catalogErrorResponse(w, err, "Failed to delete room")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message": "Room deleted",
+	})
+}
+
+// CreateSeasonalRate handles adding a seasonal rate to a room
+func (h *CatalogHandler) CreateSeasonalRate(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	roomID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var reqBody seasonalRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Parse Date Strings to Time
+	layout := "2006-01-02"
+	start, err := time.Parse(layout, reqBody.StartDate)
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid start_date format (use YYYY-MM-DD)")
+		return
+	}
+	end, err := time.Parse(layout, reqBody.EndDate)
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid end_date format (use YYYY-MM-DD)")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.CreateSeasonalRate(ctx, &pbCatalog.CreateSeasonalRateRequest{
+		OwnerId:     ownerID,
+		RoomId:      roomID,
+		Name:        reqBody.Name,
+		StartDate:   timestamppb.New(start),
+		EndDate:     timestamppb.New(end),
+		NightlyRate: reqBody.NightlyRate,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to create seasonal rate")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, seasonalRateToJSON(res.SeasonalRate))
+}
+
+// ListSeasonalRates handles listing the seasonal rates of a room
+func (h *CatalogHandler) ListSeasonalRates(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	roomID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.catalogClient.ListSeasonalRates(ctx, &pbCatalog.ListSeasonalRatesRequest{
+		OwnerId: ownerID,
+		RoomId:  roomID,
+	})
+	if err != nil {
+		catalogErrorResponse(w, err, "Failed to list seasonal rates")
+		return
+	}
+
+	rates := []map[string]interface{}{}
+	for _, rate := range res.SeasonalRates {
+		rates = append(rates, seasonalRateToJSON(rate))
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"seasonal_rates": rates,
+	})
+}
+
+// DeleteSeasonalRate handles deleting a seasonal rate
+func (h *CatalogHandler) DeleteSeasonalRate(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	rateID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := h.catalogClient.DeleteSeasonalRate(ctx, &pbCatalog.DeleteSeasonalRateRequest{
+		OwnerId:        ownerID,
+		SeasonalRateId: rateID,
+	}); err != nil {
+		catalogErrorResponse(w, err, "Failed to delete seasonal rate")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message": "Seasonal rate deleted",
+	})
+}
+
+// pathID parses a numeric path parameter, writing a 400 response if it is invalid
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil || id < 1 {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid "+name)
+		return 0, false
+	}
+	return id, true
+}
+
+// catalogErrorResponse converts a catalog service error into an HTTP error response
+func catalogErrorResponse(w http.ResponseWriter, err error, fallback string) {
+	log.Printf("❌ %s: %v", fallback, err)
+	utils.GRPCErrorResponse(w, err, fallback)
+}
+
+// propertyToJSON converts a property to its JSON representation
+func propertyToJSON(property *pbCatalog.Property) map[string]interface{} {
+	return map[string]interface{}{
+		"id":             property.Id,
+		"owner_id":       property.OwnerId,
+		"name":           property.Name,
+		"address":        property.Address,
+		"timezone":       property.Timezone,
+		"check_in_time":  property.CheckInTime,
+		"check_out_time": property.CheckOutTime,
+		"is_active":      property.IsActive,
+		"created_at":     property.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// roomToJSON converts a room to its JSON representation
+func roomToJSON(room *pbCatalog.Room) map[string]interface{} {
+	amenities := room.Amenities
+	if amenities == nil {
+		amenities = []string{}
+	}
+	return map[string]interface{}{
+		"id":              room.Id,
+		"property_id":     room.PropertyId,
+		"name":            room.Name,
+		"capacity":        room.Capacity,
+		"amenities":       amenities,
+		"lock_device_id":  room.LockDeviceId,
+		"is_active":       room.IsActive,
+		"base_rate":       room.BaseRate,
+		"min_stay_nights": room.MinStayNights,
+		"created_at":      room.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// seasonalRateToJSON converts a seasonal rate to its JSON representation
+func seasonalRateToJSON(rate *pbCatalog.SeasonalRate) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           rate.Id,
+		"room_id":      rate.RoomId,
+		"name":         rate.Name,
+		"start_date":   rate.StartDate.AsTime().Format("2006-01-02"),
+		"end_date":     rate.EndDate.AsTime().Format("2006-01-02"),
+		"nightly_rate": rate.NightlyRate,
+	}
+}
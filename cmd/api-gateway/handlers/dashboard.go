@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+	pbKey "github.com/karimiku/smart-stay-platform/pkg/genproto/key"
+	pbRes "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/fanout"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
+)
+
+const (
+	// dashboardSectionTimeout is the deadline of each backend call of the dashboard.
+	// A section that does not answer in time is reported as failed; the others are still returned.
+	dashboardSectionTimeout = 2 * time.Second
+	// dashboardPastReservations is the number of past reservations shown, most recent first
+	dashboardPastReservations = 5
+	// dashboardNotifications is the number of unread notifications shown, newest first
+	dashboardNotifications = 5
+)
+
+// Dashboard sections, which are also the keys of the "errors" object of the response
+const (
+	sectionProfile       = "profile"
+	sectionReservations  = "reservations"
+	sectionKeys          = "keys"
+	sectionNotifications = "notifications"
+)
+
+// DashboardHandler serves the dashboard, which combines data from the Auth, Reservation and Key services
+type DashboardHandler struct {
+	authClient pbAuth.AuthServiceClient
+	resClient  pbRes.ReservationServiceClient
+	keyClient  pbKey.KeyServiceClient
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(authClient pbAuth.AuthServiceClient, resClient pbRes.ReservationServiceClient, keyClient pbKey.KeyServiceClient) *DashboardHandler {
+	return &DashboardHandler{
+		authClient: authClient,
+		resClient:  resClient,
+		keyClient:  keyClient,
+	}
+}
+
+// GetDashboard returns the profile, upcoming and past reservations, active keys and unread
+// notifications of the current user. The backends are called concurrently; if some of them
+// fail, the other sections are still returned, the failed ones are null and "errors" says why.
+func (h *DashboardHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var (
+		user          *pbAuth.User
+		reservations  []*pbRes.Reservation
+		keys          []*pbKey.Key
+		notifications *pbRes.ListNotificationsResponse
+	)
+
+	group := fanout.New(r.Context())
+	group.Go(sectionProfile, dashboardSectionTimeout, func(ctx context.Context) error {
+		res, err := h.authClient.GetUser(ctx, &pbAuth.GetUserRequest{UserId: userID})
+		if err != nil {
+			return err
+		}
+		user = res.User
+		return nil
+	})
+	group.Go(sectionReservations, dashboardSectionTimeout, func(ctx context.Context) error {
+		res, err := h.resClient.ListReservations(ctx, &pbRes.ListReservationsRequest{UserId: userID})
+		if err != nil {
+			return err
+		}
+		reservations = res.Reservations
+		return nil
+	})
+	group.Go(sectionKeys, dashboardSectionTimeout, func(ctx context.Context) error {
+		res, err := h.keyClient.ListKeys(ctx, &pbKey.ListKeysRequest{UserId: userID})
+		if err != nil {
+			return err
+		}
+		keys = res.Keys
+		return nil
+	})
+	group.Go(sectionNotifications, dashboardSectionTimeout, func(ctx context.Context) error {
+		res, err := h.resClient.ListNotifications(ctx, &pbRes.ListNotificationsRequest{
+			UserId:     userID,
+			UnreadOnly: true,
+			Limit:      dashboardNotifications,
+		})
+		if err != nil {
+			return err
+		}
+		notifications = res
+		return nil
+	})
+	errs := group.Wait()
+
+	body := map[string]interface{}{
+		"profile":               nil,
+		"upcoming_reservations": nil,
+		"past_reservations":     nil,
+		"active_keys":           nil,
+		"notifications":         nil,
+	}
+	now := time.Now()
+	if _, failed := errs[sectionProfile]; !failed {
+		body["profile"] = userToJSON(user)
+	}
+	if _, failed := errs[sectionReservations]; !failed {
+		upcoming, past := splitReservations(reservations, now)
+		body["upcoming_reservations"] = reservationsToJSON(upcoming)
+		body["past_reservations"] = reservationsToJSON(past)
+	}
+	if _, failed := errs[sectionKeys]; !failed {
+		var active []map[string]interface{}
+		for _, key := range keys {
+			if key.Status == pbKey.KeyStatus_ACTIVE && key.ValidUntil.AsTime().After(now) {
+				active = append(active, keyToJSON(key))
+			}
+		}
+		if active == nil {
+			active = []map[string]interface{}{}
+		}
+		body["active_keys"] = active
+	}
+	if _, failed := errs[sectionNotifications]; !failed {
+		body["notifications"] = notificationsToJSON(notifications)
+	}
+
+	sectionErrors := make(map[string]utils.Problem, len(errs))
+	for section, err := range errs {
+		log.Printf("⚠️  Dashboard section %s failed for user %s: %v", section, userID, err)
+		sectionErrors[section] = utils.GRPCProblem(err, "Failed to load "+section)
+	}
+	body["errors"] = sectionErrors
+	body["partial"] = len(errs) > 0
+
+	utils.SuccessResponse(w, body)
+}
+
+// splitReservations separates the reservations whose stay is still ahead or in progress,
+// soonest first, from the finished or cancelled ones, of which the most recent few are kept
+func splitReservations(reservations []*pbRes.Reservation, now time.Time) (upcoming, past []*pbRes.Reservation) {
+	for _, reservation := range reservations {
+		active := reservation.Status == pbRes.ReservationStatus_PENDING || reservation.Status == pbRes.ReservationStatus_CONFIRMED
+		if active && reservation.CheckOutAt.AsTime().After(now) {
+			upcoming = append(upcoming, reservation)
+		} else {
+			past = append(past, reservation)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].CheckInAt.AsTime().Before(upcoming[j].CheckInAt.AsTime())
+	})
+	sort.Slice(past, func(i, j int) bool {
+		return past[i].CheckInAt.AsTime().After(past[j].CheckInAt.AsTime())
+	})
+	if len(past) > dashboardPastReservations {
+		past = past[:dashboardPastReservations]
+	}
+	return upcoming, past
+}
+
+// reservationsToJSON converts reservations to their JSON representation (an empty list, not null, if there are none)
+func reservationsToJSON(reservations []*pbRes.Reservation) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(reservations))
+	for _, reservation := range reservations {
+		result = append(result, reservationToJSON(reservation))
+	}
+	return result
+}
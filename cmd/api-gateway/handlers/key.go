@@ -3,11 +3,10 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"net/http"
 	"time"
 
-	"google.golang.org/protobuf/types/known/timestamppb"
-
 	pbKey "github.com/karimiku/smart-stay-platform/pkg/genproto/key"
 
 	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
@@ -26,83 +25,91 @@ func NewKeyHandler(keyClient pbKey.KeyServiceClient) *KeyHandler {
 	}
 }
 
-// GenerateKey handles manual key generation (for debugging)
-func (h *KeyHandler) GenerateKey(w http.ResponseWriter, r *http.Request) {
-	var reqBody struct {
-		ReservationID string `json:"reservation_id"`
-		ValidFrom     string `json:"valid_from"`
-		ValidUntil    string `json:"valid_until"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
-		return
-	}
-
-	// Parse RFC3339
-	validFrom, err := time.Parse(time.RFC3339, reqBody.ValidFrom)
-	if err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid valid_from format (use RFC3339)")
-		return
-	}
-	validUntil, err := time.Parse(time.RFC3339, reqBody.ValidUntil)
-	if err != nil {
-		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid valid_until format (use RFC3339)")
+// ListKeys handles listing all keys for the current user
+func (h *KeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	// Get user_id from JWT
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	res, err := h.keyClient.GenerateKey(ctx, &pbKey.GenerateKeyRequest{
-		ReservationId: reqBody.ReservationID,
-		ValidFrom:     timestamppb.New(validFrom),
-		ValidUntil:    timestamppb.New(validUntil),
+	res, err := h.keyClient.ListKeys(ctx, &pbKey.ListKeysRequest{
+		UserId: userID,
 	})
 	if err != nil {
-		utils.ErrorResponse(w, http.StatusInternalServerError, "Key generation failed")
+		utils.GRPCErrorResponse(w, err, "Failed to list keys")
 		return
 	}
 
+	// Convert keys to JSON format
+	var keys []map[string]interface{}
+	for _, key := range res.Keys {
+		keys = append(keys, keyToJSON(key))
+	}
+
 	utils.SuccessResponse(w, map[string]interface{}{
-		"key_code":  res.KeyCode,
-		"device_id": res.DeviceId,
+		"keys": keys,
 	})
 }
 
-// ListKeys handles listing all keys for the current user
-func (h *KeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
-	// Get user_id from JWT
+// RevokeKey handles revoking the key of a reservation.
+// Allowed for the guest at check-out and for the owner of the property.
+func (h *KeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
 		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
 		return
 	}
+	role, _ := middleware.GetRole(r)
+
+	// The body is optional
+	var reqBody struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	res, err := h.keyClient.ListKeys(ctx, &pbKey.ListKeysRequest{
-		UserId: userID,
+	res, err := h.keyClient.RevokeKey(ctx, &pbKey.RevokeKeyRequest{
+		ReservationId: r.PathValue("reservation_id"),
+		Reason:        reqBody.Reason,
+		RequesterId:   userID,
+		RequesterRole: role,
 	})
 	if err != nil {
-		utils.ErrorResponse(w, http.StatusInternalServerError, "Failed to list keys")
+		log.Printf("❌ Key revocation failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Key revocation failed")
 		return
 	}
 
-	// Convert keys to JSON format
-	var keys []map[string]interface{}
-	for _, key := range res.Keys {
-		keys = append(keys, map[string]interface{}{
-			"key_code":       key.KeyCode,
-			"device_id":      key.DeviceId,
-			"reservation_id": key.ReservationId,
-			"valid_from":     key.ValidFrom.AsTime().Format(time.RFC3339),
-			"valid_until":    key.ValidUntil.AsTime().Format(time.RFC3339),
-		})
-	}
-
+	key := res.Key
 	utils.SuccessResponse(w, map[string]interface{}{
-		"keys": keys,
+		"reservation_id": key.ReservationId,
+		"device_id":      key.DeviceId,
+		"status":         key.Status.String(),
+		"revoked_at":     key.RevokedAt.AsTime().Format(time.RFC3339),
+		"revoke_reason":  key.RevokeReason,
 	})
 }
 
+// keyToJSON converts a key to its JSON representation
+func keyToJSON(key *pbKey.Key) map[string]interface{} {
+	return map[string]interface{}{
+		"key_code":       key.KeyCode,
+		"device_id":      key.DeviceId,
+		"reservation_id": key.ReservationId,
+		"valid_from":     key.ValidFrom.AsTime().Format(time.RFC3339),
+		"valid_until":    key.ValidUntil.AsTime().Format(time.RFC3339),
+		"status":         key.Status.String(),
+	}
+}
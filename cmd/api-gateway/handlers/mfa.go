@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
+)
+
+// VerifyMFA completes a login with the MFA token from POST /login and a TOTP or recovery code
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if reqBody.MFAToken == "" || reqBody.Code == "" {
+		utils.ErrorResponse(w, http.StatusBadRequest, "mfa_token and code are required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.VerifyMFA(ctx, &pbAuth.VerifyMFARequest{
+		MfaToken:  reqBody.MFAToken,
+		Code:      reqBody.Code,
+		UserAgent: r.UserAgent(),
+		IpAddress: utils.ClientIP(r),
+	})
+	if err != nil {
+		log.Printf("❌ MFA verification failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "MFA verification failed")
+		return
+	}
+
+	setAuthCookie(w, accessTokenCookie, res.AccessToken, int(res.ExpiresIn))
+	setAuthCookie(w, refreshTokenCookie, res.RefreshToken, int(res.RefreshExpiresIn))
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message":                  "Login successful",
+		"expires_in":               res.ExpiresIn,
+		"recovery_codes_remaining": res.RecoveryCodesRemaining,
+	})
+}
+
+// EnrollMFA starts TOTP enrollment for the current user, or for a user whose login requires
+// enrollment (identified by the mfa_token from POST /login)
+func (h *AuthHandler) EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		MFAToken string `json:"mfa_token"`
+	}
+	// The body is optional for signed-in users
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	req := &pbAuth.EnrollMFARequest{MfaToken: reqBody.MFAToken}
+	if req.MfaToken == "" {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			utils.ErrorResponse(w, http.StatusUnauthorized, "Authentication or mfa_token is required")
+			return
+		}
+		req.UserId = userID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.EnrollMFA(ctx, req)
+	if err != nil {
+		log.Printf("❌ MFA enrollment failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "MFA enrollment failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"secret":      res.Secret,
+		"otpauth_uri": res.OtpauthUri,
+	})
+}
+
+// ConfirmMFA enables MFA with a first code from the authenticator app.
+// With an mfa_token the login is completed and the auth cookies are set.
+func (h *AuthHandler) ConfirmMFA(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if reqBody.Code == "" {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Code is required")
+		return
+	}
+	req := &pbAuth.ConfirmMFARequest{
+		MfaToken:  reqBody.MFAToken,
+		Code:      reqBody.Code,
+		UserAgent: r.UserAgent(),
+		IpAddress: utils.ClientIP(r),
+	}
+	if req.MfaToken == "" {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			utils.ErrorResponse(w, http.StatusUnauthorized, "Authentication or mfa_token is required")
+			return
+		}
+		req.UserId = userID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.ConfirmMFA(ctx, req)
+	if err != nil {
+		log.Printf("❌ MFA confirmation failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "MFA confirmation failed")
+		return
+	}
+
+	body := map[string]interface{}{
+		"message":        "MFA enabled",
+		"recovery_codes": res.RecoveryCodes,
+	}
+	if res.AccessToken != "" {
+		setAuthCookie(w, accessTokenCookie, res.AccessToken, int(res.ExpiresIn))
+		setAuthCookie(w, refreshTokenCookie, res.RefreshToken, int(res.RefreshExpiresIn))
+		body["expires_in"] = res.ExpiresIn
+	}
+	utils.SuccessResponse(w, body)
+}
+
+// DisableMFA turns MFA off for the current user after checking a code
+func (h *AuthHandler) DisableMFA(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	var reqBody struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := h.authClient.DisableMFA(ctx, &pbAuth.DisableMFARequest{
+		UserId: userID,
+		Code:   reqBody.Code,
+	}); err != nil {
+		log.Printf("❌ Disabling MFA failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Disabling MFA failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"message": "MFA disabled",
+	})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user after checking a code
+func (h *AuthHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	var reqBody struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.RegenerateRecoveryCodes(ctx, &pbAuth.RegenerateRecoveryCodesRequest{
+		UserId: userID,
+		Code:   reqBody.Code,
+	})
+	if err != nil {
+		log.Printf("❌ Regenerating recovery codes failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Regenerating recovery codes failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"recovery_codes": res.RecoveryCodes,
+	})
+}
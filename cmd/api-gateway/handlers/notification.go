@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	pbRes "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
+)
+
+// NotificationHandler handles the notifications of the current user
+type NotificationHandler struct {
+	resClient pbRes.ReservationServiceClient
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(resClient pbRes.ReservationServiceClient) *NotificationHandler {
+	return &NotificationHandler{
+		resClient: resClient,
+	}
+}
+
+// ListNotifications lists the notifications of the current user, newest first.
+// Query parameters: unread=true to list only unread ones, limit (default 20, at most 100).
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	query := r.URL.Query()
+	var limit int
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			utils.ErrorResponse(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.resClient.ListNotifications(ctx, &pbRes.ListNotificationsRequest{
+		UserId:     userID,
+		UnreadOnly: query.Get("unread") == "true",
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		log.Printf("❌ Failed to list notifications: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to list notifications")
+		return
+	}
+
+	utils.SuccessResponse(w, notificationsToJSON(res))
+}
+
+// MarkNotificationRead marks one of the current user's notifications as read
+func (h *NotificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.resClient.MarkNotificationRead(ctx, &pbRes.MarkNotificationReadRequest{
+		NotificationId: r.PathValue("id"),
+		UserId:         userID,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to mark notification as read: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to mark notification as read")
+		return
+	}
+
+	utils.SuccessResponse(w, notificationToJSON(res.Notification))
+}
+
+// notificationsToJSON converts a notification list to its JSON representation
+func notificationsToJSON(res *pbRes.ListNotificationsResponse) map[string]interface{} {
+	notifications := make([]map[string]interface{}, 0, len(res.Notifications))
+	for _, notification := range res.Notifications {
+		notifications = append(notifications, notificationToJSON(notification))
+	}
+	return map[string]interface{}{
+		"notifications": notifications,
+		"unread_count":  res.UnreadCount,
+	}
+}
+
+// notificationToJSON converts a notification to its JSON representation
+func notificationToJSON(notification *pbRes.Notification) map[string]interface{} {
+	var readAt interface{}
+	if notification.ReadAt != nil {
+		readAt = notification.ReadAt.AsTime().Format(time.RFC3339)
+	}
+	return map[string]interface{}{
+		"id":             notification.Id,
+		"reservation_id": notification.ReservationId,
+		"type":           notification.Type,
+		"message":        notification.Message,
+		"created_at":     notification.CreatedAt.AsTime().Format(time.RFC3339),
+		"read_at":        readAt,
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -73,7 +75,7 @@ func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, r *http.Re
 	})
 	if err != nil {
 		log.Printf("❌ Reservation failed: %v", err)
-		utils.ErrorResponse(w, http.StatusInternalServerError, "Reservation failed")
+		utils.GRPCErrorResponse(w, err, "Reservation failed")
 		return
 	}
 
@@ -104,22 +106,14 @@ func (h *ReservationHandler) ListReservations(w http.ResponseWriter, r *http.Req
 	})
 	if err != nil {
 		log.Printf("❌ List reservations failed: %v", err)
-		utils.ErrorResponse(w, http.StatusInternalServerError, "Failed to list reservations")
+		utils.GRPCErrorResponse(w, err, "Failed to list reservations")
 		return
 	}
 
 	// Convert reservations to JSON format
 	var reservations []map[string]interface{}
 	for _, reservation := range res.Reservations {
-		reservations = append(reservations, map[string]interface{}{
-			"id":          reservation.Id,
-			"user_id":     reservation.UserId,
-			"room_id":     reservation.RoomId,
-			"start_date":  reservation.StartDate.AsTime().Format("2006-01-02"),
-			"end_date":    reservation.EndDate.AsTime().Format("2006-01-02"),
-			"total_price": reservation.TotalPrice,
-			"status":      reservation.Status.String(),
-		})
+		reservations = append(reservations, reservationToJSON(reservation))
 	}
 
 	utils.SuccessResponse(w, map[string]interface{}{
@@ -127,3 +121,209 @@ func (h *ReservationHandler) ListReservations(w http.ResponseWriter, r *http.Req
 	})
 }
 
+// CancelReservation handles cancelling a reservation owned by the current user
+func (h *ReservationHandler) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	reservationID := r.PathValue("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	log.Printf("[BFF] Cancelling Reservation %s for User %s", reservationID, userID)
+
+	res, err := h.resClient.CancelReservation(ctx, &pbRes.CancelReservationRequest{
+		ReservationId: reservationID,
+		UserId:        userID,
+	})
+	if err != nil {
+		log.Printf("❌ Cancellation failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Cancellation failed")
+		return
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"reservation_id":   res.ReservationId,
+		"status":           res.Status.String(),
+		"cancellation_fee": res.CancellationFee,
+		"refund_amount":    res.RefundAmount,
+	})
+}
+
+// SearchAvailability handles searching for rooms that are free between two dates
+func (h *ReservationHandler) SearchAvailability(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Parse Date Strings to Time
+	layout := "2006-01-02"
+	start, err := time.Parse(layout, query.Get("start_date"))
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid start_date format (use YYYY-MM-DD)")
+		return
+	}
+	end, err := time.Parse(layout, query.Get("end_date"))
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid end_date format (use YYYY-MM-DD)")
+		return
+	}
+	if !end.After(start) {
+		utils.ErrorResponse(w, http.StatusBadRequest, "end_date must be after start_date")
+		return
+	}
+
+	// Guests defaults to 1
+	guests := 1
+	if v := query.Get("guests"); v != "" {
+		guests, err = strconv.Atoi(v)
+		if err != nil || guests < 1 {
+			utils.ErrorResponse(w, http.StatusBadRequest, "guests must be a positive integer")
+			return
+		}
+	}
+
+	// Optional comma-separated list of rooms to check
+	var roomIDs []int64
+	if v := query.Get("room_ids"); v != "" {
+		for _, part := range strings.Split(v, ",") {
+			roomID, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil {
+				utils.ErrorResponse(w, http.StatusBadRequest, "Invalid room_ids (use comma-separated integers)")
+				return
+			}
+			roomIDs = append(roomIDs, roomID)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.resClient.SearchAvailability(ctx, &pbRes.SearchAvailabilityRequest{
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(end),
+		Guests:    int32(guests),
+		RoomIds:   roomIDs,
+	})
+	if err != nil {
+		log.Printf("❌ Availability search failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to search availability")
+		return
+	}
+
+	// Convert rooms to JSON format
+	rooms := []map[string]interface{}{}
+	for _, room := range res.Rooms {
+		var nights []map[string]interface{}
+		for _, night := range room.Nights {
+			nights = append(nights, map[string]interface{}{
+				"date":  night.Date.AsTime().Format("2006-01-02"),
+				"price": night.Price,
+			})
+		}
+		rooms = append(rooms, map[string]interface{}{
+			"room_id":     room.RoomId,
+			"nights":      nights,
+			"total_price": room.TotalPrice,
+		})
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"start_date": start.Format("2006-01-02"),
+		"end_date":   end.Format("2006-01-02"),
+		"guests":     guests,
+		"rooms":      rooms,
+	})
+}
+
+// QuotePrice handles pricing a stay in a room without booking it
+func (h *ReservationHandler) QuotePrice(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	roomID, err := strconv.ParseInt(query.Get("room_id"), 10, 64)
+	if err != nil || roomID < 1 {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid room_id")
+		return
+	}
+
+	// Parse Date Strings to Time
+	layout := "2006-01-02"
+	start, err := time.Parse(layout, query.Get("start_date"))
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid start_date format (use YYYY-MM-DD)")
+		return
+	}
+	end, err := time.Parse(layout, query.Get("end_date"))
+	if err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid end_date format (use YYYY-MM-DD)")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.resClient.QuotePrice(ctx, &pbRes.QuotePriceRequest{
+		RoomId:    roomID,
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(end),
+	})
+	if err != nil {
+		log.Printf("❌ Price quote failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to quote price")
+		return
+	}
+
+	var nights []map[string]interface{}
+	for _, night := range res.Nights {
+		nights = append(nights, map[string]interface{}{
+			"date":  night.Date.AsTime().Format("2006-01-02"),
+			"price": night.Price,
+		})
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"room_id":     res.RoomId,
+		"start_date":  start.Format("2006-01-02"),
+		"end_date":    end.Format("2006-01-02"),
+		"nights":      nights,
+		"line_items":  lineItemsToJSON(res.LineItems),
+		"subtotal":    res.Subtotal,
+		"discount":    res.Discount,
+		"total_price": res.TotalPrice,
+	})
+}
+
+// lineItemsToJSON converts a price breakdown to its JSON representation
+func lineItemsToJSON(items []*pbRes.PriceLineItem) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, item := range items {
+		lineItem := map[string]interface{}{
+			"type":        item.Type,
+			"description": item.Description,
+			"amount":      item.Amount,
+		}
+		if item.Date != nil {
+			lineItem["date"] = item.Date.AsTime().Format("2006-01-02")
+		}
+		result = append(result, lineItem)
+	}
+	return result
+}
+
+// reservationToJSON converts a reservation to its JSON representation
+func reservationToJSON(reservation *pbRes.Reservation) map[string]interface{} {
+	return map[string]interface{}{
+		"id":               reservation.Id,
+		"user_id":          reservation.UserId,
+		"room_id":          reservation.RoomId,
+		"start_date":       reservation.StartDate.AsTime().Format("2006-01-02"),
+		"end_date":         reservation.EndDate.AsTime().Format("2006-01-02"),
+		"check_in_at":      reservation.CheckInAt.AsTime().Format(time.RFC3339),
+		"check_out_at":     reservation.CheckOutAt.AsTime().Format(time.RFC3339),
+		"total_price":      reservation.TotalPrice,
+		"price_breakdown":  lineItemsToJSON(reservation.PriceBreakdown),
+		"cancellation_fee": reservation.CancellationFee,
+		"status":           reservation.Status.String(),
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
+)
+
+// SessionHandler handles the signed-in devices of the current user
+type SessionHandler struct {
+	authClient pbAuth.AuthServiceClient
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(authClient pbAuth.AuthServiceClient) *SessionHandler {
+	return &SessionHandler{
+		authClient: authClient,
+	}
+}
+
+// ListSessions lists the active sessions of the current user
+func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	currentSessionID, _ := middleware.GetSessionID(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.ListSessions(ctx, &pbAuth.ListSessionsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to list sessions: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to list sessions")
+		return
+	}
+
+	sessions := make([]map[string]interface{}, 0, len(res.Sessions))
+	for _, session := range res.Sessions {
+		sessions = append(sessions, map[string]interface{}{
+			"session_id":   session.SessionId,
+			"user_agent":   session.UserAgent,
+			"ip_address":   session.IpAddress,
+			"created_at":   session.CreatedAt.AsTime().Format(time.RFC3339),
+			"last_used_at": session.LastUsedAt.AsTime().Format(time.RFC3339),
+			"expires_at":   session.ExpiresAt.AsTime().Format(time.RFC3339),
+			"current":      session.SessionId == currentSessionID,
+		})
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession signs one of the current user's devices out
+func (h *SessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	sessionID := r.PathValue("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := h.authClient.RevokeSession(ctx, &pbAuth.RevokeSessionRequest{
+		UserId:    userID,
+		SessionId: sessionID,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to revoke session: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to revoke session")
+		return
+	}
+
+	// Revoking the current session is the same as logging out
+	if currentSessionID, _ := middleware.GetSessionID(r); sessionID == currentSessionID {
+		setAuthCookie(w, accessTokenCookie, "", -1)
+		setAuthCookie(w, refreshTokenCookie, "", -1)
+	}
+
+	utils.SuccessResponse(w, map[string]interface{}{
+		"session_id": sessionID,
+		"message":    "Session revoked",
+	})
+}
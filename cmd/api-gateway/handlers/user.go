@@ -1,21 +1,32 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"log"
 	"net/http"
+	"strings"
+	"time"
+
+	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
 
 	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
 	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
 )
 
 // UserHandler handles user-related endpoints
-type UserHandler struct{}
+type UserHandler struct {
+	authClient pbAuth.AuthServiceClient
+}
 
 // NewUserHandler creates a new user handler
-func NewUserHandler() *UserHandler {
-	return &UserHandler{}
+func NewUserHandler(authClient pbAuth.AuthServiceClient) *UserHandler {
+	return &UserHandler{
+		authClient: authClient,
+	}
 }
 
-// GetMe returns the current user's information
+// GetMe returns the current user's profile
 func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
@@ -23,14 +34,91 @@ func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	role, ok := middleware.GetRole(r)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.GetUser(ctx, &pbAuth.GetUserRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to get user: %v", err)
+		utils.GRPCErrorResponse(w, err, "Failed to get profile")
+		return
+	}
+
+	utils.SuccessResponse(w, userToJSON(res.User))
+}
+
+// UpdateMe updates the current user's profile. Only the fields present in the body are changed.
+// A new email address must be confirmed through the link sent to it before it replaces the current one.
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
 	if !ok {
-		role = "guest" // Default role
+		utils.ErrorResponse(w, http.StatusUnauthorized, "User ID not found")
+		return
 	}
 
-	utils.SuccessResponse(w, map[string]interface{}{
-		"user_id": userID,
-		"role":    role,
+	var reqBody struct {
+		Name            *string `json:"name"`
+		Phone           *string `json:"phone"`
+		Locale          *string `json:"locale"`
+		Email           *string `json:"email"`
+		CurrentPassword string  `json:"current_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if reqBody.Name == nil && reqBody.Phone == nil && reqBody.Locale == nil && reqBody.Email == nil {
+		utils.ErrorResponse(w, http.StatusBadRequest, "No profile field to update")
+		return
+	}
+	if reqBody.Email != nil {
+		email := strings.TrimSpace(*reqBody.Email)
+		if !isValidEmail(email) {
+			utils.ErrorResponse(w, http.StatusBadRequest, "Invalid email format")
+			return
+		}
+		reqBody.Email = &email
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.authClient.UpdateProfile(ctx, &pbAuth.UpdateProfileRequest{
+		UserId:          userID,
+		Name:            reqBody.Name,
+		Phone:           reqBody.Phone,
+		Locale:          reqBody.Locale,
+		Email:           reqBody.Email,
+		CurrentPassword: reqBody.CurrentPassword,
 	})
+	if err != nil {
+		log.Printf("❌ Profile update failed: %v", err)
+		utils.GRPCErrorResponse(w, err, "Profile update failed")
+		return
+	}
+
+	body := userToJSON(res.User)
+	body["verification_email_sent"] = res.VerificationEmailSent
+	utils.SuccessResponse(w, body)
 }
 
+// userToJSON converts a user profile to its JSON representation
+func userToJSON(user *pbAuth.User) map[string]interface{} {
+	var pendingEmail interface{}
+	if user.PendingEmail != "" {
+		pendingEmail = user.PendingEmail
+	}
+	return map[string]interface{}{
+		"user_id":        user.Id,
+		"email":          user.Email,
+		"name":           user.Name,
+		"role":           user.Role,
+		"phone":          user.Phone,
+		"locale":         user.Locale,
+		"email_verified": user.EmailVerified,
+		"pending_email":  pendingEmail,
+		"created_at":     user.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
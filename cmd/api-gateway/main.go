@@ -1,22 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+	pbCatalog "github.com/karimiku/smart-stay-platform/pkg/genproto/catalog"
 	pbKey "github.com/karimiku/smart-stay-platform/pkg/genproto/key"
 	pbRes "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
 
 	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/handlers"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/metrics"
 	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/middleware"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
+	"github.com/karimiku/smart-stay-platform/internal/database"
 )
 
 func main() {
@@ -37,6 +43,8 @@ func main() {
 	resConn := mustConnectGrpc("Reservation Service", resAddr)
 	defer resConn.Close()
 	resClient := pbRes.NewReservationServiceClient(resConn)
+	// The room catalog is served by the Reservation Service
+	catalogClient := pbCatalog.NewCatalogServiceClient(resConn)
 
 	// Key Service
 	keyConn := mustConnectGrpc("Key Service", keyAddr)
@@ -44,13 +52,49 @@ func main() {
 	keyClient := pbKey.NewKeyServiceClient(keyConn)
 
 	// 3. Initialize Middleware
-	authMiddleware := middleware.NewAuthMiddleware(authClient)
+	authConfig, err := middleware.LoadAuthConfig()
+	if err != nil {
+		log.Fatalf("❌ Invalid auth configuration: %v", err)
+	}
+	authMiddleware := middleware.NewAuthMiddleware(authClient, authConfig)
+	authMiddleware.Start(context.Background())
+
+	// X-Forwarded-For is only believed from these proxies (client IPs for rate limits, login lockouts and sessions)
+	if err := utils.LoadTrustedProxies(); err != nil {
+		log.Fatalf("❌ Invalid TRUSTED_PROXIES: %v", err)
+	}
+
+	rateLimitConfig, err := middleware.LoadRateLimitConfig()
+	if err != nil {
+		log.Fatalf("❌ Invalid rate limit configuration: %v", err)
+	}
+	var rateLimitStore middleware.RateLimitStore = middleware.NewMemoryRateLimitStore()
+	if rateLimitConfig.Store == "postgres" {
+		// Buckets in Postgres are shared, so the limits hold across all gateway instances
+		databaseURL := os.Getenv("DATABASE_URL")
+		if databaseURL == "" {
+			log.Fatalf("❌ DATABASE_URL environment variable is required with RATE_LIMIT_STORE=postgres.")
+		}
+		dbPool, err := pgxpool.New(context.Background(), databaseURL)
+		if err != nil {
+			log.Fatalf("❌ Failed to connect to database: %v", err)
+		}
+		defer dbPool.Close()
+		rateLimitStore = middleware.NewPostgresRateLimitStore(database.New(dbPool))
+	}
+	rateLimiter := middleware.NewRateLimiter(rateLimitStore, rateLimitConfig, authMiddleware.Identify)
+	rateLimiter.Start(context.Background())
+	log.Printf("✅ Rate limits (%s store): %v", rateLimitConfig.Store, rateLimitConfig.Limits)
 
 	// 4. Initialize Handlers
 	authHandler := handlers.NewAuthHandler(authClient)
-	userHandler := handlers.NewUserHandler()
+	userHandler := handlers.NewUserHandler(authClient)
+	sessionHandler := handlers.NewSessionHandler(authClient)
 	reservationHandler := handlers.NewReservationHandler(resClient)
 	keyHandler := handlers.NewKeyHandler(keyClient)
+	catalogHandler := handlers.NewCatalogHandler(catalogClient)
+	notificationHandler := handlers.NewNotificationHandler(resClient)
+	dashboardHandler := handlers.NewDashboardHandler(authClient, resClient, keyClient)
 
 	// 5. Setup Router
 	mux := http.NewServeMux()
@@ -60,27 +104,95 @@ func main() {
 	// =========================================================================
 	mux.HandleFunc("POST /signup", authHandler.Signup)
 	mux.HandleFunc("POST /login", authHandler.Login)
+	mux.HandleFunc("POST /login/mfa", authHandler.VerifyMFA)
 	mux.HandleFunc("POST /logout", authHandler.Logout)
+	mux.HandleFunc("POST /token/refresh", authHandler.Refresh)
+	mux.HandleFunc("POST /password/forgot", authHandler.ForgotPassword)
+	mux.HandleFunc("POST /password/reset", authHandler.ResetPassword)
+	mux.HandleFunc("GET /verify-email", authHandler.VerifyEmail)
+	mux.HandleFunc("POST /account/unlock", authHandler.UnlockAccount)
+	mux.HandleFunc("GET /.well-known/jwks.json", authHandler.JWKS)
+	// Enrollment works for signed-in users and for logins that require enrollment (mfa_token)
+	mux.HandleFunc("POST /mfa/enroll", authMiddleware.OptionalAuth(authHandler.EnrollMFA))
+	mux.HandleFunc("POST /mfa/confirm", authMiddleware.OptionalAuth(authHandler.ConfirmMFA))
 
 	// =========================================================================
 	// 👤 User Routes (Protected - Authentication required)
 	// =========================================================================
 	mux.HandleFunc("GET /me", authMiddleware.RequireAuth(userHandler.GetMe))
+	mux.HandleFunc("PATCH /me", authMiddleware.RequireAuth(userHandler.UpdateMe))
+	mux.HandleFunc("PUT /me/password", authMiddleware.RequireAuth(authHandler.ChangePassword))
+	mux.HandleFunc("POST /verify-email/resend", authMiddleware.RequireAuth(authHandler.ResendVerificationEmail))
+	mux.HandleFunc("DELETE /me/mfa", authMiddleware.RequireAuth(authHandler.DisableMFA))
+	mux.HandleFunc("POST /me/mfa/recovery-codes", authMiddleware.RequireAuth(authHandler.RegenerateRecoveryCodes))
+	mux.HandleFunc("POST /logout/all", authMiddleware.RequireAuth(authHandler.LogoutEverywhere))
+	mux.HandleFunc("GET /sessions", authMiddleware.RequireAuth(sessionHandler.ListSessions))
+	mux.HandleFunc("DELETE /sessions/{id}", authMiddleware.RequireAuth(sessionHandler.RevokeSession))
 
 	// =========================================================================
 	// 📝 Reservation Routes (Protected - Authentication required)
 	// =========================================================================
 	mux.HandleFunc("POST /reservations", authMiddleware.RequireAuth(reservationHandler.CreateReservation))
 	mux.HandleFunc("GET /reservations", authMiddleware.RequireAuth(reservationHandler.ListReservations))
+	mux.HandleFunc("DELETE /reservations/{id}", authMiddleware.RequireAuth(reservationHandler.CancelReservation))
+
+	// =========================================================================
+	// 🏠 Availability Routes (Public - No authentication required)
+	// =========================================================================
+	mux.HandleFunc("GET /availability", reservationHandler.SearchAvailability)
+	mux.HandleFunc("GET /quote", reservationHandler.QuotePrice)
+
+	// =========================================================================
+	// 🏠 Catalog Routes (Protected - Owner role required)
+	// =========================================================================
+	requireOwner := authMiddleware.RequireRole("owner")
+	mux.HandleFunc("POST /properties", requireOwner(catalogHandler.CreateProperty))
+	mux.HandleFunc("GET /properties", requireOwner(catalogHandler.ListProperties))
+	mux.HandleFunc("GET /properties/{id}", requireOwner(catalogHandler.GetProperty))
+	mux.HandleFunc("PUT /properties/{id}", requireOwner(catalogHandler.UpdateProperty))
+	mux.HandleFunc("DELETE /properties/{id}", requireOwner(catalogHandler.DeleteProperty))
+	mux.HandleFunc("POST /properties/{id}/rooms", requireOwner(catalogHandler.CreateRoom))
+	mux.HandleFunc("GET /properties/{id}/rooms", requireOwner(catalogHandler.ListRooms))
+	mux.HandleFunc("GET /rooms/{id}", requireOwner(catalogHandler.GetRoom))
+	mux.HandleFunc("PUT /rooms/{id}", requireOwner(catalogHandler.UpdateRoom))
+	mux.HandleFunc("DELETE /rooms/{id}", requireOwner(catalogHandler.DeleteRoom))
+	mux.HandleFunc("POST /rooms/{id}/seasonal-rates", requireOwner(catalogHandler.CreateSeasonalRate))
+	mux.HandleFunc("GET /rooms/{id}/seasonal-rates", requireOwner(catalogHandler.ListSeasonalRates))
+	mux.HandleFunc("DELETE /seasonal-rates/{id}", requireOwner(catalogHandler.DeleteSeasonalRate))
 
 	// =========================================================================
 	// 🔑 Key Routes (Protected - Authentication required)
 	// =========================================================================
-	mux.HandleFunc("POST /keys/generate", authMiddleware.RequireAuth(keyHandler.GenerateKey))
 	mux.HandleFunc("GET /keys", authMiddleware.RequireAuth(keyHandler.ListKeys))
+	mux.HandleFunc("POST /keys/{reservation_id}/revoke", authMiddleware.RequireAuth(keyHandler.RevokeKey))
+
+	// =========================================================================
+	// 🔔 Notification Routes (Protected - Authentication required)
+	// =========================================================================
+	mux.HandleFunc("GET /notifications", authMiddleware.RequireAuth(notificationHandler.ListNotifications))
+	mux.HandleFunc("POST /notifications/{id}/read", authMiddleware.RequireAuth(notificationHandler.MarkNotificationRead))
+
+	// =========================================================================
+	// 📋 Dashboard Route (Protected - Authentication required)
+	// Calls the Auth, Reservation and Key services concurrently
+	// =========================================================================
+	mux.HandleFunc("GET /dashboard", authMiddleware.RequireAuth(dashboardHandler.GetDashboard))
+
+	// =========================================================================
+	// 📊 Metrics (Prometheus text format)
+	// =========================================================================
+	mux.HandleFunc("GET /metrics", metrics.Handler)
 
-	// 6. Apply CORS middleware
-	handler := middleware.CORS(mux)
+	// 6. Apply rate limiting and CORS middleware
+	// CORS is outermost, so that browsers can read 429 responses as well
+	rateLimiter.Route(middleware.RateLimitGroupAuth,
+		"POST /signup", "POST /login", "POST /login/mfa", "POST /token/refresh",
+		"POST /password/forgot", "POST /password/reset", "POST /account/unlock",
+		"POST /verify-email/resend", "POST /mfa/enroll", "POST /mfa/confirm", "PUT /me/password", "PATCH /me",
+		"DELETE /me/mfa", "POST /me/mfa/recovery-codes")
+	rateLimiter.Route(middleware.RateLimitGroupReservations,
+		"POST /reservations", "GET /reservations", "DELETE /reservations/{id}")
+	handler := middleware.CORS(rateLimiter.Handler(mux))
 
 	// 7. Start Server
 	port := getEnv("PORT", "8080")
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// registry holds every metric created by this package, by name
+var registry = struct {
+	mu      sync.Mutex
+	metrics map[string]metric
+}{metrics: make(map[string]metric)}
+
+// metric is a value exposed at /metrics
+type metric interface {
+	write(sb *strings.Builder)
+}
+
+// Counter is a monotonically increasing count
+type Counter struct {
+	name  string
+	help  string
+	value atomic.Uint64
+}
+
+// NewCounter creates and registers a counter. Names follow the Prometheus conventions (…_total).
+func NewCounter(name, help string) *Counter {
+	c := &Counter{name: name, help: help}
+	register(name, c)
+	return c
+}
+
+// Inc increments the counter by one
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Value returns the current count
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+func (c *Counter) write(sb *strings.Builder) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", c.name, c.help, c.name, c.name, c.Value())
+}
+
+// GaugeFunc is a value read when metrics are scraped, such as the size of a cache
+type GaugeFunc struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+// NewGaugeFunc creates and registers a gauge whose value is returned by fn
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{name: name, help: help, fn: fn}
+	register(name, g)
+	return g
+}
+
+func (g *GaugeFunc) write(sb *strings.Builder) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", g.name, g.help, g.name, g.name, g.fn())
+}
+
+// register adds a metric to the registry; names must be unique
+func register(name string, m metric) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, exists := registry.metrics[name]; exists {
+		panic("metrics: duplicate metric " + name)
+	}
+	registry.metrics[name] = m
+}
+
+// Handler serves every registered metric in the Prometheus text exposition format
+func Handler(w http.ResponseWriter, r *http.Request) {
+	registry.mu.Lock()
+	names := make([]string, 0, len(registry.metrics))
+	for name := range registry.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		registry.metrics[name].write(&sb)
+	}
+	registry.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(sb.String()))
+}
@@ -2,33 +2,79 @@ package middleware
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/metrics"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
 )
 
 // contextKey is a type-safe context key
 type contextKey string
 
 const (
-	UserIDKey contextKey = "user_id"
-	RoleKey   contextKey = "role"
+	UserIDKey    contextKey = "user_id"
+	RoleKey      contextKey = "role"
+	SessionIDKey contextKey = "session_id"
 )
 
-// AuthMiddleware validates JWT tokens and sets user information in the context
+// AuthMiddleware validates JWT tokens and sets user information in the context.
+//
+// Tokens are verified locally with the public keys of the Auth Service (its JWKS) and checked
+// against the revocations it publishes, so most requests need no call to the Auth Service.
+// Validated tokens are cached for a short time. The Validate RPC is used only when the
+// middleware cannot decide by itself: the keys have not been loaded yet or the revocation
+// feed is out of date.
 type AuthMiddleware struct {
-	authClient pbAuth.AuthServiceClient
+	authClient  pbAuth.AuthServiceClient
+	keys        *keySet
+	revocations *revocationFeed
+	cache       *tokenCache
 }
 
-// NewAuthMiddleware creates a new authentication middleware
-func NewAuthMiddleware(authClient pbAuth.AuthServiceClient) *AuthMiddleware {
-	return &AuthMiddleware{
-		authClient: authClient,
+// NewAuthMiddleware creates a new authentication middleware. Call Start to load the keys and revocations.
+func NewAuthMiddleware(authClient pbAuth.AuthServiceClient, config AuthConfig) *AuthMiddleware {
+	m := &AuthMiddleware{
+		authClient:  authClient,
+		keys:        newKeySet(authClient),
+		revocations: newRevocationFeed(authClient, config.RevocationPollInterval),
+		cache:       newTokenCache(config.CacheTTL),
 	}
+
+	metrics.NewGaugeFunc("gateway_auth_cache_entries", "Validated tokens currently cached.", func() float64 {
+		return float64(m.cache.Len())
+	})
+	metrics.NewGaugeFunc("gateway_auth_revocations", "Revoked tokens and sessions known to the gateway.", func() float64 {
+		return float64(m.revocations.Size())
+	})
+	metrics.NewGaugeFunc("gateway_auth_revocation_feed_age_seconds", "Seconds since revocations were last synced (-1 if never).", func() float64 {
+		if age := m.revocations.Age(); age >= 0 {
+			return age.Seconds()
+		}
+		return -1
+	})
+	return m
+}
+
+// Start loads the signing keys and revocations and keeps them up to date until ctx is done.
+// Failures are not fatal: until the Auth Service is reachable, tokens are validated by RPC.
+func (m *AuthMiddleware) Start(ctx context.Context) {
+	loadCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := m.keys.refresh(loadCtx); err != nil {
+		log.Printf("⚠️  Failed to load JWKS, validating tokens by RPC for now: %v", err)
+	}
+	if err := m.revocations.poll(loadCtx); err != nil {
+		log.Printf("⚠️  Failed to load token revocations, validating tokens by RPC for now: %v", err)
+	}
+
+	go m.keys.run(ctx)
+	go m.revocations.run(ctx)
 }
 
 // RequireAuth is a middleware that requires authentication
@@ -42,27 +88,17 @@ func (m *AuthMiddleware) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		// 2. Validate token with Auth Service
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-
-		validateRes, err := m.authClient.Validate(ctx, &pbAuth.ValidateRequest{
-			AccessToken: token,
-		})
-		if err != nil {
-			log.Printf("[Auth] Token validation error: %v", err)
-			respondUnauthorized(w, "Invalid token")
-			return
-		}
-
-		if !validateRes.Valid {
+		// 2. Validate token (locally, or with Auth Service when that is not possible)
+		identity, ok := m.authenticate(token)
+		if !ok {
 			respondUnauthorized(w, "Invalid or expired token")
 			return
 		}
 
 		// 3. Set user information in context
-		ctx = context.WithValue(r.Context(), UserIDKey, validateRes.UserId)
-		ctx = context.WithValue(ctx, RoleKey, validateRes.Role)
+		ctx := context.WithValue(r.Context(), UserIDKey, identity.UserID)
+		ctx = context.WithValue(ctx, RoleKey, identity.Role)
+		ctx = context.WithValue(ctx, SessionIDKey, identity.SessionID)
 
 		// 4. Execute next handler
 		next(w, r.WithContext(ctx))
@@ -75,15 +111,10 @@ func (m *AuthMiddleware) OptionalAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token := extractBearerToken(r)
 		if token != "" {
-			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-			defer cancel()
-
-			validateRes, err := m.authClient.Validate(ctx, &pbAuth.ValidateRequest{
-				AccessToken: token,
-			})
-			if err == nil && validateRes.Valid {
-				ctx = context.WithValue(r.Context(), UserIDKey, validateRes.UserId)
-				ctx = context.WithValue(ctx, RoleKey, validateRes.Role)
+			if identity, ok := m.authenticate(token); ok {
+				ctx := context.WithValue(r.Context(), UserIDKey, identity.UserID)
+				ctx = context.WithValue(ctx, RoleKey, identity.Role)
+				ctx = context.WithValue(ctx, SessionIDKey, identity.SessionID)
 				r = r.WithContext(ctx)
 			}
 		}
@@ -92,6 +123,21 @@ func (m *AuthMiddleware) OptionalAuth(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// Identify returns the user ID of the request's access token if there is a valid one.
+// Unlike RequireAuth and OptionalAuth it does not change the request; the rate limiter uses it
+// to tell users apart before the route's own middleware runs.
+func (m *AuthMiddleware) Identify(r *http.Request) (string, bool) {
+	token := extractBearerToken(r)
+	if token == "" {
+		return "", false
+	}
+	identity, ok := m.authenticate(token)
+	if !ok {
+		return "", false
+	}
+	return identity.UserID, true
+}
+
 // RequireRole is a middleware that requires specific roles
 func (m *AuthMiddleware) RequireRole(allowedRoles ...string) func(http.HandlerFunc) http.HandlerFunc {
 	return func(next http.HandlerFunc) http.HandlerFunc {
@@ -139,23 +185,20 @@ func extractBearerToken(r *http.Request) string {
 		return ""
 }
 
+// BearerToken returns the access token of a request (Authorization header or Cookie), or ""
+func BearerToken(r *http.Request) string {
+	return extractBearerToken(r)
+}
+
 // respondUnauthorized returns 401 Unauthorized response
 // Security: Does not return detailed error information (prevents information leakage)
 func respondUnauthorized(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": message,
-	})
+	utils.ErrorResponse(w, http.StatusUnauthorized, message)
 }
 
 // respondForbidden returns 403 Forbidden response
 func respondForbidden(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusForbidden)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": message,
-	})
+	utils.ErrorResponse(w, http.StatusForbidden, message)
 }
 
 // GetUserID retrieves user_id from context
@@ -170,3 +213,8 @@ func GetRole(r *http.Request) (string, bool) {
 	return role, ok
 }
 
+// GetSessionID retrieves the session ID of the access token from context
+func GetSessionID(r *http.Request) (string, bool) {
+	sessionID, ok := r.Context().Value(SessionIDKey).(string)
+	return sessionID, ok
+}
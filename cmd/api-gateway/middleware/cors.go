@@ -35,7 +35,7 @@ func CORS(next http.Handler) http.Handler {
 
 		// Set CORS headers (only if origin was allowed)
 		if w.Header().Get("Access-Control-Allow-Origin") != "" {
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 			w.Header().Set("Access-Control-Allow-Credentials", "true") // Required for cookies
 			w.Header().Set("Access-Control-Max-Age", "3600")
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+
+	"github.com/karimiku/smart-stay-platform/internal/jwt"
+)
+
+const (
+	// defaultJWKSRefreshInterval is used until the Auth Service has told us its max age
+	defaultJWKSRefreshInterval = 5 * time.Minute
+	// minJWKSRefetchInterval limits refetches triggered by tokens naming an unknown key
+	minJWKSRefetchInterval = 30 * time.Second
+)
+
+// keySet keeps the public keys of the Auth Service (its JWKS) so that tokens can be verified locally
+type keySet struct {
+	authClient pbAuth.AuthServiceClient
+	keyring    *jwt.Keyring
+
+	mu     sync.Mutex
+	loaded bool
+	maxAge time.Duration
+	// refetchedAt is when an unknown key last triggered a refetch
+	refetchedAt time.Time
+}
+
+// newKeySet creates an empty key set; keys are loaded by refresh
+func newKeySet(authClient pbAuth.AuthServiceClient) *keySet {
+	return &keySet{
+		authClient: authClient,
+		keyring:    jwt.NewKeyring(),
+		maxAge:     defaultJWKSRefreshInterval,
+	}
+}
+
+// Loaded reports whether the keys have been fetched at least once
+func (k *keySet) Loaded() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.loaded
+}
+
+// Validate verifies the signature and claims of a token.
+// A token naming an unknown key triggers a (rate-limited) refetch of the keys, since the
+// Auth Service may have published a new key since the last fetch.
+func (k *keySet) Validate(ctx context.Context, token string) (*jwt.Claims, error) {
+	claims, err := k.keyring.ValidateToken(token)
+	if !errors.Is(err, jwt.ErrUnknownKey) || !k.claimRefetch() {
+		return claims, err
+	}
+	if err := k.refresh(ctx); err != nil {
+		log.Printf("❌ Failed to refetch JWKS: %v", err)
+	}
+	return k.keyring.ValidateToken(token)
+}
+
+// refresh fetches the keys from the Auth Service
+func (k *keySet) refresh(ctx context.Context) error {
+	res, err := k.authClient.GetJWKS(ctx, &pbAuth.GetJWKSRequest{})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]jwt.Key, 0, len(res.Keys))
+	for _, pbKey := range res.Keys {
+		key, err := jwt.ParseJWK(jwt.JWK{
+			Kty: pbKey.Kty,
+			Use: pbKey.Use,
+			Alg: pbKey.Alg,
+			Kid: pbKey.Kid,
+			Crv: pbKey.Crv,
+			X:   pbKey.X,
+			N:   pbKey.N,
+			E:   pbKey.E,
+		})
+		if err != nil {
+			// Skip keys we cannot use rather than losing the others
+			log.Printf("⚠️  Ignoring JWK %s: %v", pbKey.Kid, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	k.keyring.Replace(keys)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.loaded = true
+	if res.MaxAge > 0 {
+		k.maxAge = time.Duration(res.MaxAge) * time.Second
+	}
+	return nil
+}
+
+// run refreshes the keys whenever the cached copy reaches its max age
+func (k *keySet) run(ctx context.Context) {
+	for {
+		k.mu.Lock()
+		wait := k.maxAge
+		if !k.loaded {
+			// Retry soon while the Auth Service is unreachable
+			wait = minJWKSRefetchInterval
+		}
+		k.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		if err := k.refresh(fetchCtx); err != nil && ctx.Err() == nil {
+			log.Printf("❌ Failed to refresh JWKS: %v", err)
+		}
+		cancel()
+	}
+}
+
+// claimRefetch reports whether an unknown key may trigger a refetch now.
+// Only one caller per interval gets true, so a burst of such tokens causes a single fetch.
+func (k *keySet) claimRefetch() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if time.Since(k.refetchedAt) < minJWKSRefetchInterval {
+		return false
+	}
+	k.refetchedAt = time.Now()
+	return true
+}
@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/metrics"
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/utils"
+)
+
+// Route groups with their own rate limits. Routes not assigned to a group are in RateLimitGroupDefault.
+const (
+	// RateLimitGroupAuth is signup, login and the other credential endpoints, which are the usual
+	// targets of credential stuffing and signup spam
+	RateLimitGroupAuth = "auth"
+	// RateLimitGroupReservations is the reservation endpoints, which hold locks in the database
+	RateLimitGroupReservations = "reservations"
+	// RateLimitGroupDefault is every other route
+	RateLimitGroupDefault = "default"
+)
+
+const (
+	// rateLimitPurgeInterval is how often idle buckets are deleted from the store
+	rateLimitPurgeInterval = 10 * time.Minute
+	// rateLimitIdleTime is how long a bucket is kept without requests, unless a bucket takes
+	// longer to refill: deleting a bucket that is not full yet would hand out extra tokens
+	rateLimitIdleTime = time.Hour
+)
+
+var (
+	rateLimitRejections = metrics.NewCounter("gateway_rate_limit_rejections_total",
+		"Requests rejected with 429 by the rate limiter.")
+	rateLimitStoreErrors = metrics.NewCounter("gateway_rate_limit_store_errors_total",
+		"Requests let through unlimited because the rate limit store failed.")
+)
+
+// RateLimit is a token bucket: it holds up to Burst tokens and is refilled with PerMinute tokens
+// a minute. Every request takes a token; a request finding the bucket empty is rejected.
+type RateLimit struct {
+	PerMinute int
+	Burst     int
+}
+
+// rate returns the refill rate in tokens per second
+func (l RateLimit) rate() float64 {
+	return float64(l.PerMinute) / 60
+}
+
+// RateLimitConfig controls the rate limiter
+type RateLimitConfig struct {
+	// Store is where the buckets are kept: "memory" (this instance only) or "postgres" (shared by all instances)
+	Store string
+	// Limits are the limits per route group; a group with PerMinute 0 is not limited
+	Limits map[string]RateLimit
+}
+
+// LoadRateLimitConfig reads the rate limiter configuration from the environment
+func LoadRateLimitConfig() (RateLimitConfig, error) {
+	config := RateLimitConfig{
+		Store: "memory",
+		Limits: map[string]RateLimit{
+			RateLimitGroupAuth:         {PerMinute: 10, Burst: 5},
+			RateLimitGroupReservations: {PerMinute: 60, Burst: 20},
+			RateLimitGroupDefault:      {PerMinute: 300, Burst: 100},
+		},
+	}
+
+	if v := os.Getenv("RATE_LIMIT_STORE"); v != "" {
+		if v != "memory" && v != "postgres" {
+			return config, fmt.Errorf("invalid RATE_LIMIT_STORE %q (want memory or postgres)", v)
+		}
+		config.Store = v
+	}
+	for group, limit := range config.Limits {
+		prefix := "RATE_LIMIT_" + strings.ToUpper(group)
+		if v := os.Getenv(prefix + "_PER_MINUTE"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return config, fmt.Errorf("invalid %s_PER_MINUTE %q", prefix, v)
+			}
+			limit.PerMinute = n
+		}
+		if v := os.Getenv(prefix + "_BURST"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				return config, fmt.Errorf("invalid %s_BURST %q", prefix, v)
+			}
+			limit.Burst = n
+		}
+		config.Limits[group] = limit
+	}
+
+	return config, nil
+}
+
+// RateLimiter limits requests per route group and per principal: the user ID for requests with
+// a valid access token, the client IP otherwise. The buckets are kept in a RateLimitStore.
+type RateLimiter struct {
+	store    RateLimitStore
+	limits   map[string]RateLimit
+	groups   map[string]string
+	identify func(r *http.Request) (string, bool)
+}
+
+// NewRateLimiter creates a rate limiter. identify returns the user ID of an authenticated request
+// (see AuthMiddleware.Identify). Call Start to purge idle buckets.
+func NewRateLimiter(store RateLimitStore, config RateLimitConfig, identify func(r *http.Request) (string, bool)) *RateLimiter {
+	return &RateLimiter{
+		store:    store,
+		limits:   config.Limits,
+		groups:   make(map[string]string),
+		identify: identify,
+	}
+}
+
+// Route assigns routes to a group. Patterns are written as registered on the mux, e.g. "POST /login".
+func (l *RateLimiter) Route(group string, patterns ...string) {
+	for _, pattern := range patterns {
+		l.groups[pattern] = group
+	}
+}
+
+// Start deletes idle buckets from the store periodically until ctx is done
+func (l *RateLimiter) Start(ctx context.Context) {
+	idleTime := rateLimitIdleTime
+	for _, limit := range l.limits {
+		if limit.PerMinute > 0 {
+			idleTime = max(idleTime, time.Duration(float64(limit.Burst)/limit.rate()*float64(time.Second)))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(rateLimitPurgeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := l.store.Purge(ctx, time.Now().Add(-idleTime))
+				if err != nil {
+					log.Printf("⚠️  Failed to purge rate limit buckets: %v", err)
+				} else if deleted > 0 {
+					log.Printf("🧹 Purged %d idle rate limit bucket(s)", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// Handler limits the requests to the routes of mux. Every limited response carries the
+// RateLimit-* headers; rejected requests get 429 Too Many Requests with Retry-After.
+func (l *RateLimiter) Handler(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The pattern the request matches decides its group; unknown routes count as default
+		_, pattern := mux.Handler(r)
+		group, ok := l.groups[pattern]
+		if !ok {
+			group = RateLimitGroupDefault
+		}
+		limit := l.limits[group]
+		if limit.PerMinute <= 0 {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		principal := "ip:" + utils.ClientIP(r)
+		if userID, ok := l.identify(r); ok {
+			principal = "user:" + userID
+		}
+
+		result, err := l.store.Take(r.Context(), group+":"+principal, limit, time.Now())
+		if err != nil {
+			// Failing open: an outage of the store must not take the API down with it
+			log.Printf("⚠️  Rate limit store failed, not limiting request: %v", err)
+			rateLimitStoreErrors.Inc()
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		rate := limit.rate()
+		w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Burst, int(math.Ceil(float64(limit.Burst)/rate))))
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(int(math.Floor(result.Tokens))))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil((float64(limit.Burst)-result.Tokens)/rate))))
+
+		if !result.Allowed {
+			rateLimitRejections.Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil((1-result.Tokens)/rate))))
+			utils.ErrorCodeResponse(w, http.StatusTooManyRequests, "rate_limited", "Too many requests, try again later")
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
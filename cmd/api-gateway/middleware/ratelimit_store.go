@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+)
+
+// RateLimitResult is the outcome of taking a token from a bucket
+type RateLimitResult struct {
+	// Allowed is whether there was a token to take
+	Allowed bool
+	// Tokens is what is left in the bucket afterwards (fractional, as buckets refill continuously)
+	Tokens float64
+}
+
+// RateLimitStore keeps the token buckets of the rate limiter. A store shared by several gateway
+// instances must take tokens atomically, so that concurrent requests cannot spend the same token.
+type RateLimitStore interface {
+	// Take refills the bucket for key for the time since it was last used and takes a token if there is one.
+	// A new bucket starts full.
+	Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error)
+	// Purge deletes buckets that have not been used since idleSince
+	Purge(ctx context.Context, idleSince time.Time) (int64, error)
+}
+
+// MemoryRateLimitStore keeps the buckets in memory. Every gateway instance has its own buckets,
+// so with several instances the effective limit is multiplied by their number.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket is the level of a bucket at the time it was last used
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*tokenBucket)}
+}
+
+// Take takes a token from the bucket for key
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = bucket
+	}
+	if elapsed := now.Sub(bucket.updatedAt); elapsed > 0 {
+		bucket.tokens = min(float64(limit.Burst), bucket.tokens+elapsed.Seconds()*limit.rate())
+		bucket.updatedAt = now
+	}
+	if bucket.tokens < 1 {
+		return RateLimitResult{Allowed: false, Tokens: bucket.tokens}, nil
+	}
+	bucket.tokens--
+	return RateLimitResult{Allowed: true, Tokens: bucket.tokens}, nil
+}
+
+// Purge deletes idle buckets
+func (s *MemoryRateLimitStore) Purge(_ context.Context, idleSince time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, bucket := range s.buckets {
+		if bucket.updatedAt.Before(idleSince) {
+			delete(s.buckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// PostgresRateLimitStore keeps the buckets in the rate_limit_buckets table, shared by all gateway instances
+type PostgresRateLimitStore struct {
+	queries *database.Queries
+}
+
+// NewPostgresRateLimitStore creates a store on the rate_limit_buckets table
+func NewPostgresRateLimitStore(queries *database.Queries) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{queries: queries}
+}
+
+// Take takes a token from the bucket for key. Refilling and taking is one upsert,
+// so concurrent requests from any instance are serialized on the row.
+func (s *PostgresRateLimitStore) Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	row, err := s.queries.TakeRateLimitToken(ctx, database.TakeRateLimitTokenParams{
+		Key:           key,
+		InitialTokens: float64(limit.Burst) - 1,
+		Now:           pgtype.Timestamptz{Time: now, Valid: true},
+		Burst:         float64(limit.Burst),
+		Rate:          limit.rate(),
+	})
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	return RateLimitResult{Allowed: row.Allowed, Tokens: row.Tokens}, nil
+}
+
+// Purge deletes idle buckets
+func (s *PostgresRateLimitStore) Purge(ctx context.Context, idleSince time.Time) (int64, error) {
+	return s.queries.DeleteIdleRateLimitBuckets(ctx, pgtype.Timestamptz{Time: idleSince, Valid: true})
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+)
+
+const (
+	// revocationLookback is re-requested on every poll, so that revocations committed late
+	// (or stamped by a clock slightly behind the Auth Service's) are not missed
+	revocationLookback = time.Minute
+	// revocationStaleAfterPolls is how many polls may fail before the feed is no longer trusted
+	revocationStaleAfterPolls = 3
+)
+
+// revocationFeed mirrors the revoked access tokens and sessions of the Auth Service.
+// It polls ListRevocations and keeps each revocation only for as long as it can matter.
+type revocationFeed struct {
+	authClient pbAuth.AuthServiceClient
+	interval   time.Duration
+
+	mu sync.RWMutex
+	// tokens maps revoked jtis to when the token expires
+	tokens map[string]time.Time
+	// sessions maps revoked session IDs to when their last access token has expired
+	sessions map[string]time.Time
+	// since is sent with the next poll; nil until the first successful poll
+	since *timestamppb.Timestamp
+	// syncedAt is when the last poll succeeded
+	syncedAt time.Time
+}
+
+// newRevocationFeed creates an empty feed; it is filled by poll
+func newRevocationFeed(authClient pbAuth.AuthServiceClient, interval time.Duration) *revocationFeed {
+	return &revocationFeed{
+		authClient: authClient,
+		interval:   interval,
+		tokens:     make(map[string]time.Time),
+		sessions:   make(map[string]time.Time),
+	}
+}
+
+// IsRevoked reports whether a token (by jti and session) is revoked.
+// known is false when the feed is not in sync, in which case the answer cannot be trusted.
+func (f *revocationFeed) IsRevoked(jti, sessionID string) (revoked, known bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.fresh() {
+		return false, false
+	}
+	if _, ok := f.tokens[jti]; ok && jti != "" {
+		return true, true
+	}
+	if _, ok := f.sessions[sessionID]; ok && sessionID != "" {
+		return true, true
+	}
+	return false, true
+}
+
+// Age returns how long ago the feed was last synced, or -1 if it never was
+func (f *revocationFeed) Age() time.Duration {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.syncedAt.IsZero() {
+		return -1
+	}
+	return time.Since(f.syncedAt)
+}
+
+// Size returns the number of revocations held
+func (f *revocationFeed) Size() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.tokens) + len(f.sessions)
+}
+
+// fresh reports whether the feed has been synced recently enough to be trusted; the caller holds the lock
+func (f *revocationFeed) fresh() bool {
+	return !f.syncedAt.IsZero() && time.Since(f.syncedAt) < revocationStaleAfterPolls*f.interval
+}
+
+// poll fetches the revocations made since the last poll and drops those that no longer matter
+func (f *revocationFeed) poll(ctx context.Context) error {
+	f.mu.RLock()
+	since := f.since
+	f.mu.RUnlock()
+
+	res, err := f.authClient.ListRevocations(ctx, &pbAuth.ListRevocationsRequest{Since: since})
+	if err != nil {
+		return err
+	}
+	retention := time.Duration(res.RetentionSeconds) * time.Second
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, token := range res.Tokens {
+		f.tokens[token.Jti] = token.ExpiresAt.AsTime()
+	}
+	for _, session := range res.Sessions {
+		f.sessions[session.SessionId] = session.RevokedAt.AsTime().Add(retention)
+	}
+
+	now := time.Now()
+	for jti, until := range f.tokens {
+		if now.After(until) {
+			delete(f.tokens, jti)
+		}
+	}
+	for sessionID, until := range f.sessions {
+		if now.After(until) {
+			delete(f.sessions, sessionID)
+		}
+	}
+
+	f.since = timestamppb.New(res.AsOf.AsTime().Add(-revocationLookback))
+	f.syncedAt = now
+	return nil
+}
+
+// run polls the Auth Service at the configured interval
+func (f *revocationFeed) run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pollCtx, cancel := context.WithTimeout(ctx, f.interval)
+			if err := f.poll(pollCtx); err != nil && ctx.Err() == nil {
+				log.Printf("❌ Failed to poll token revocations: %v", err)
+			}
+			cancel()
+		}
+	}
+}
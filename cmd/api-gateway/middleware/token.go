@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	pbAuth "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+
+	"github.com/karimiku/smart-stay-platform/cmd/api-gateway/metrics"
+)
+
+// maxTokenCacheEntries bounds the token cache; expired entries are dropped when it is full
+const maxTokenCacheEntries = 10000
+
+var (
+	authCacheHits = metrics.NewCounter("gateway_auth_cache_hits_total",
+		"Requests whose token was found in the validation cache.")
+	authCacheMisses = metrics.NewCounter("gateway_auth_cache_misses_total",
+		"Requests whose token was not in the validation cache.")
+	authLocalVerifications = metrics.NewCounter("gateway_auth_local_verifications_total",
+		"Tokens verified locally with the JWKS and the revocation feed.")
+	authRPCFallbacks = metrics.NewCounter("gateway_auth_rpc_fallbacks_total",
+		"Tokens validated by the Auth Service because the gateway could not decide.")
+	authRejections = metrics.NewCounter("gateway_auth_rejections_total",
+		"Tokens rejected as invalid, expired or revoked.")
+)
+
+// AuthConfig controls how the auth middleware validates tokens
+type AuthConfig struct {
+	// CacheTTL is how long a validated token is trusted without verifying it again (0 disables the cache).
+	// Revocations are still checked on every request while the revocation feed is in sync.
+	CacheTTL time.Duration
+	// RevocationPollInterval is how often revocations are fetched from the Auth Service
+	RevocationPollInterval time.Duration
+}
+
+// LoadAuthConfig reads the auth middleware configuration from the environment
+func LoadAuthConfig() (AuthConfig, error) {
+	config := AuthConfig{
+		CacheTTL:               30 * time.Second,
+		RevocationPollInterval: 5 * time.Second,
+	}
+
+	if v := os.Getenv("GATEWAY_AUTH_CACHE_TTL_SECONDS"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 {
+			return config, fmt.Errorf("invalid GATEWAY_AUTH_CACHE_TTL_SECONDS %q", v)
+		}
+		config.CacheTTL = time.Duration(seconds) * time.Second
+	}
+	if v := os.Getenv("GATEWAY_REVOCATION_POLL_SECONDS"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds <= 0 {
+			return config, fmt.Errorf("invalid GATEWAY_REVOCATION_POLL_SECONDS %q", v)
+		}
+		config.RevocationPollInterval = time.Duration(seconds) * time.Second
+	}
+
+	return config, nil
+}
+
+// tokenIdentity is what the middleware learns from a valid token
+type tokenIdentity struct {
+	UserID    string
+	Role      string
+	SessionID string
+}
+
+// authenticate validates a token: from the cache, locally, or with the Auth Service
+func (m *AuthMiddleware) authenticate(token string) (tokenIdentity, bool) {
+	cacheKey := sha256.Sum256([]byte(token))
+
+	if cached, ok := m.cache.Get(cacheKey); ok {
+		authCacheHits.Inc()
+		// The signature was checked when the token was cached, but it may have been revoked since
+		if revoked, _ := m.revocations.IsRevoked(cached.jti, cached.SessionID); revoked {
+			m.cache.Delete(cacheKey)
+			authRejections.Inc()
+			return tokenIdentity{}, false
+		}
+		return cached.tokenIdentity, true
+	}
+	authCacheMisses.Inc()
+
+	entry, valid, decided := m.verifyLocally(token)
+	if !decided {
+		authRPCFallbacks.Inc()
+		identity, valid := m.validateRemotely(token)
+		if !valid {
+			authRejections.Inc()
+		}
+		return identity, valid
+	}
+
+	authLocalVerifications.Inc()
+	if !valid {
+		authRejections.Inc()
+		return tokenIdentity{}, false
+	}
+	m.cache.Put(cacheKey, entry)
+	return entry.tokenIdentity, true
+}
+
+// verifyLocally checks the signature, claims and revocation of a token without calling the Auth Service.
+// decided is false when the keys or revocations needed to decide are not available.
+func (m *AuthMiddleware) verifyLocally(token string) (entry cachedToken, valid, decided bool) {
+	if !m.keys.Loaded() {
+		return cachedToken{}, false, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	claims, err := m.keys.Validate(ctx, token)
+	if err != nil {
+		log.Printf("[Auth] Token validation failed: %v", err)
+		return cachedToken{}, false, true
+	}
+
+	revoked, known := m.revocations.IsRevoked(claims.ID, claims.SessionID)
+	if !known {
+		return cachedToken{}, false, false
+	}
+	if revoked {
+		log.Printf("[Auth] Token of user %s has been revoked", claims.UserID)
+		return cachedToken{}, false, true
+	}
+
+	entry = cachedToken{
+		tokenIdentity: tokenIdentity{
+			UserID:    claims.UserID,
+			Role:      claims.Role,
+			SessionID: claims.SessionID,
+		},
+		jti: claims.ID,
+	}
+	if claims.ExpiresAt != nil {
+		entry.expiresAt = claims.ExpiresAt.Time
+	}
+	return entry, true, true
+}
+
+// validateRemotely asks the Auth Service to validate a token
+func (m *AuthMiddleware) validateRemotely(token string) (tokenIdentity, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	validateRes, err := m.authClient.Validate(ctx, &pbAuth.ValidateRequest{
+		AccessToken: token,
+	})
+	if err != nil {
+		log.Printf("[Auth] Token validation error: %v", err)
+		return tokenIdentity{}, false
+	}
+	if !validateRes.Valid {
+		return tokenIdentity{}, false
+	}
+
+	return tokenIdentity{
+		UserID:    validateRes.UserId,
+		Role:      validateRes.Role,
+		SessionID: validateRes.SessionId,
+	}, true
+}
+
+// cachedToken is a locally verified token
+type cachedToken struct {
+	tokenIdentity
+	jti       string
+	expiresAt time.Time
+	until     time.Time
+}
+
+// tokenCache holds validated tokens by their SHA-256 hash, for at most the TTL and never past expiry
+type tokenCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cachedToken
+}
+
+// newTokenCache creates a token cache; a zero TTL disables it
+func newTokenCache(ttl time.Duration) *tokenCache {
+	return &tokenCache{
+		ttl:     ttl,
+		entries: make(map[[sha256.Size]byte]cachedToken),
+	}
+}
+
+// Get returns a cached token that has not timed out
+func (c *tokenCache) Get(key [sha256.Size]byte) (cachedToken, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return cachedToken{}, false
+	}
+	if !time.Now().Before(entry.until) {
+		delete(c.entries, key)
+		return cachedToken{}, false
+	}
+	return entry, true
+}
+
+// Put caches a token until the TTL passes or the token expires, whichever is first
+func (c *tokenCache) Put(key [sha256.Size]byte, entry cachedToken) {
+	if c.ttl <= 0 {
+		return
+	}
+	now := time.Now()
+	entry.until = now.Add(c.ttl)
+	if !entry.expiresAt.IsZero() && entry.expiresAt.Before(entry.until) {
+		entry.until = entry.expiresAt
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxTokenCacheEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.until) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxTokenCacheEntries {
+			// Everything is still fresh; start over rather than grow without bound
+			c.entries = make(map[[sha256.Size]byte]cachedToken)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// Delete removes a token from the cache
+func (c *tokenCache) Delete(key [sha256.Size]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Len returns the number of cached tokens, including ones that have timed out but not been dropped yet
+func (c *tokenCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Problem is an error response body in the RFC 7807 "problem details" format
+// (Content-Type: application/problem+json)
+type Problem struct {
+	// Type identifies the kind of problem; "about:blank" means the HTTP status says it all
+	Type string `json:"type"`
+	// Title is the HTTP status text
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Detail explains this occurrence for humans
+	Detail string `json:"detail,omitempty"`
+	// Code is the machine-readable error code (an extension member), e.g. "email_already_registered"
+	Code string `json:"code"`
+	// Errors lists the invalid request fields (an extension member)
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is an invalid request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ProblemResponse writes a problem response
+func ProblemResponse(w http.ResponseWriter, problem Problem) {
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.Code == "" {
+		problem.Code = statusCode(problem.Status)
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// GRPCErrorResponse writes the problem response for an error returned by a backend service.
+// A RetryInfo delay becomes the Retry-After header.
+func GRPCErrorResponse(w http.ResponseWriter, err error, fallback string) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(info.RetryDelay.AsDuration())))
+		}
+	}
+	ProblemResponse(w, GRPCProblem(err, fallback))
+}
+
+// GRPCProblem converts an error returned by a backend service to a problem.
+//
+// The gRPC code decides the HTTP status, the ErrorInfo reason (if any) the error code,
+// and BadRequest field violations are listed in "errors". Messages of server-side errors
+// are not shown to clients; fallback (e.g. "Reservation failed") is used instead.
+func GRPCProblem(err error, fallback string) Problem {
+	st := status.Convert(err)
+	httpStatus := HTTPStatusFromCode(st.Code())
+
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(httpStatus),
+		Status: httpStatus,
+		Detail: st.Message(),
+		Code:   snakeCase(st.Code().String()),
+	}
+	if httpStatus >= http.StatusInternalServerError {
+		problem.Detail = fallback
+		problem.Code = statusCode(httpStatus)
+	}
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.Reason != "" {
+				problem.Code = strings.ToLower(detail.Reason)
+			}
+		case *errdetails.BadRequest:
+			for _, violation := range detail.FieldViolations {
+				problem.Errors = append(problem.Errors, FieldError{
+					Field:   violation.Field,
+					Message: violation.Description,
+				})
+			}
+		}
+	}
+	return problem
+}
+
+// HTTPStatusFromCode returns the HTTP status for a gRPC code
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		// FailedPrecondition means the request conflicts with the current state of the resource
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded, codes.Canceled:
+		return http.StatusGatewayTimeout
+	default:
+		// Internal, Unknown, DataLoss
+		return http.StatusInternalServerError
+	}
+}
+
+// statusCode returns the default error code for an HTTP status, e.g. "not_found" for 404
+func statusCode(httpStatus int) string {
+	return snakeCase(strings.ReplaceAll(http.StatusText(httpStatus), " ", ""))
+}
+
+// snakeCase converts a CamelCase name such as "InvalidArgument" to "invalid_argument"
+func snakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		if r == '-' || r == '\'' {
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// retryAfterSeconds rounds a delay up to whole seconds for the Retry-After header
+func retryAfterSeconds(delay time.Duration) int {
+	seconds := int(delay / time.Second)
+	if delay%time.Second != 0 {
+		seconds++
+	}
+	return max(seconds, 1)
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// defaultTrustedProxies are the networks a load balancer or reverse proxy in front of the
+// gateway is expected to connect from: loopback, private and link-local addresses
+var defaultTrustedProxies = []string{
+	"127.0.0.0/8", "::1/128",
+	"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7",
+	"169.254.0.0/16", "fe80::/10",
+}
+
+// trustedProxies are the networks whose X-Forwarded-For header is believed
+var trustedProxies = mustParseCIDRs(defaultTrustedProxies)
+
+// LoadTrustedProxies reads the networks of trusted proxies from TRUSTED_PROXIES
+// (comma-separated CIDRs or IP addresses). An empty value trusts no proxy.
+func LoadTrustedProxies() error {
+	v, ok := os.LookupEnv("TRUSTED_PROXIES")
+	if !ok {
+		return nil
+	}
+	var entries []string
+	for _, entry := range strings.Split(v, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	networks, err := parseCIDRs(entries)
+	if err != nil {
+		return err
+	}
+	trustedProxies = networks
+	return nil
+}
+
+// ClientIP returns the IP address of the client.
+// X-Forwarded-For is only believed when the request comes from a trusted proxy, as anyone else
+// can send any value. Proxies append the address they received the request from, so the client
+// is the last address in the chain that is not a trusted proxy itself.
+func ClientIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrustedProxy(remote) {
+		return remote
+	}
+
+	var chain []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, ip := range strings.Split(header, ",") {
+			chain = append(chain, strings.TrimSpace(ip))
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if net.ParseIP(chain[i]) == nil {
+			// Whatever is left of a malformed entry cannot be trusted
+			break
+		}
+		if !isTrustedProxy(chain[i]) || i == 0 {
+			return chain[i]
+		}
+	}
+	return remote
+}
+
+// isTrustedProxy reports whether ip belongs to a trusted proxy network
+func isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCIDRs parses networks given as CIDRs or single IP addresses
+func parseCIDRs(entries []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func mustParseCIDRs(entries []string) []*net.IPNet {
+	networks, err := parseCIDRs(entries)
+	if err != nil {
+		panic(err)
+	}
+	return networks
+}
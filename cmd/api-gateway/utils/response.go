@@ -12,11 +12,15 @@ func JSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-// ErrorResponse returns an error response
+// ErrorResponse returns an error response (a problem response whose code is derived from the status)
 func ErrorResponse(w http.ResponseWriter, statusCode int, message string) {
-	JSONResponse(w, statusCode, map[string]interface{}{
-		"error": message,
-	})
+	ProblemResponse(w, Problem{Status: statusCode, Detail: message})
+}
+
+// ErrorCodeResponse returns an error response with a machine-readable code,
+// for errors the client is expected to handle (e.g., by asking the user to verify their email)
+func ErrorCodeResponse(w http.ResponseWriter, statusCode int, code, message string) {
+	ProblemResponse(w, Problem{Status: statusCode, Detail: message, Code: code})
 }
 
 // SuccessResponse returns a success response
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+)
+
+// Audit event types
+const (
+	// auditLoginLockout is recorded when failed logins lock out an email address or a client IP
+	auditLoginLockout = "login.lockout"
+	// auditAccountUnlocked is recorded when a locked-out account is unlocked
+	auditAccountUnlocked = "account.unlocked"
+	// auditMFALockout is recorded when wrong MFA codes lock out a signed-in user's MFA changes
+	auditMFALockout = "mfa.lockout"
+)
+
+// recordAuditEvent writes an event to the audit log. userID may be the zero UUID for events
+// that are not tied to an account. Failures are logged rather than returned, so that auditing
+// never breaks the operation being audited.
+func (s *server) recordAuditEvent(ctx context.Context, eventType string, userID pgtype.UUID, subject, ipAddress string, details map[string]any) {
+	log.Printf("🚨 Audit: %s (subject: %s, ip: %s, details: %v)", eventType, subject, ipAddress, details)
+
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		log.Printf("❌ Failed to encode audit event details: %v", err)
+		detailsJSON = []byte("{}")
+	}
+	if err := s.queries.CreateAuditEvent(ctx, database.CreateAuditEventParams{
+		EventType: eventType,
+		UserID:    userID,
+		Subject:   subject,
+		IpAddress: ipAddress,
+		Details:   detailsJSON,
+	}); err != nil {
+		log.Printf("❌ Failed to record audit event %s: %v", eventType, err)
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+)
+
+// verificationTokenPurgeInterval is how often expired rows are deleted from email_verification_tokens
+const verificationTokenPurgeInterval = time.Hour
+
+// errInvalidVerificationToken is returned for unknown, used and expired verification tokens alike
+var errInvalidVerificationToken = rpcerror.New(codes.InvalidArgument, "INVALID_TOKEN", "invalid or expired verification token")
+
+// emailVerificationConfig controls the verification links sent at signup
+type emailVerificationConfig struct {
+	// TokenTTL is how long a verification link can be used
+	TokenTTL time.Duration
+	// URL is the page that reads the token from the "token" query parameter (GET /verify-email)
+	URL string
+}
+
+// loadEmailVerificationConfig reads the email verification configuration from the environment
+func loadEmailVerificationConfig() (emailVerificationConfig, error) {
+	config := emailVerificationConfig{
+		TokenTTL: 24 * time.Hour,
+		URL:      "http://localhost:8080/verify-email",
+	}
+
+	if v := os.Getenv("EMAIL_VERIFICATION_TOKEN_TTL_HOURS"); v != "" {
+		hours, err := strconv.Atoi(v)
+		if err != nil || hours <= 0 {
+			return config, fmt.Errorf("invalid EMAIL_VERIFICATION_TOKEN_TTL_HOURS %q", v)
+		}
+		config.TokenTTL = time.Duration(hours) * time.Hour
+	}
+	if v := os.Getenv("EMAIL_VERIFICATION_URL"); v != "" {
+		if _, err := url.Parse(v); err != nil {
+			return config, fmt.Errorf("invalid EMAIL_VERIFICATION_URL %q", v)
+		}
+		config.URL = v
+	}
+
+	return config, nil
+}
+
+// VerifyEmail marks the email address of a user as verified with a token from a verification link.
+// A token sent for an email change replaces the user's address with the new one.
+func (s *server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	if req.Token == "" {
+		return nil, rpcerror.InvalidField("token", "token is required")
+	}
+
+	var used database.UseEmailVerificationTokenRow
+	var previous database.User
+	err := s.inTx(ctx, func(q *database.Queries) error {
+		var err error
+		// Consuming the token and checking it is one statement, so a token cannot be used twice
+		used, err = q.UseEmailVerificationToken(ctx, hashOneTimeToken(req.Token))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errInvalidVerificationToken
+			}
+			return fmt.Errorf("failed to use verification token: %w", err)
+		}
+		if !used.Email.Valid {
+			if err := q.InvalidateEmailVerificationTokens(ctx, used.UserID); err != nil {
+				return fmt.Errorf("failed to invalidate verification tokens: %w", err)
+			}
+			if err := q.MarkUserEmailVerified(ctx, used.UserID); err != nil {
+				return fmt.Errorf("failed to mark email verified: %w", err)
+			}
+			return nil
+		}
+
+		// An email change: the new address replaces the current one, verified by this very link
+		previous, err = q.GetUserByID(ctx, used.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if err := q.InvalidateEmailVerificationTokens(ctx, used.UserID); err != nil {
+			return fmt.Errorf("failed to invalidate verification tokens: %w", err)
+		}
+		if err := q.InvalidateEmailChangeTokens(ctx, used.UserID); err != nil {
+			return fmt.Errorf("failed to invalidate email change tokens: %w", err)
+		}
+		if err := q.ChangeUserEmail(ctx, database.ChangeUserEmailParams{
+			ID:    used.UserID,
+			Email: used.Email.String,
+		}); err != nil {
+			// Someone signed up with the address after the change was requested
+			if isUniqueViolation(err) {
+				return errEmailAlreadyRegistered
+			}
+			return fmt.Errorf("failed to change email: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errInvalidVerificationToken):
+			log.Printf("❌ Invalid email verification token")
+			return nil, errInvalidVerificationToken
+		case errors.Is(err, errEmailAlreadyRegistered):
+			log.Printf("❌ Email change failed, address already registered: %s", used.Email.String)
+			return nil, errEmailAlreadyRegistered
+		}
+		log.Printf("❌ Failed to verify email: %v", err)
+		return nil, rpcerror.Internal("failed to verify email")
+	}
+
+	userID := uuidToString(used.UserID)
+	if used.Email.Valid {
+		log.Printf("✅ Email changed for user: %s", userID)
+		go s.sendEmailChangedNotice(previous.Email, previous.Name, used.Email.String)
+	} else {
+		log.Printf("✅ Email verified for user: %s", userID)
+	}
+	return &pb.VerifyEmailResponse{UserId: userID}, nil
+}
+
+// ResendVerificationEmail sends a new verification link; earlier links stop working
+func (s *server) ResendVerificationEmail(ctx context.Context, req *pb.ResendVerificationEmailRequest) (*pb.ResendVerificationEmailResponse, error) {
+	log.Printf("📨 ResendVerificationEmail request received for user: %s", req.UserId)
+
+	userID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		log.Printf("❌ Failed to get user: %v", err)
+		return nil, rpcerror.Internal("failed to resend verification email")
+	}
+	if user.EmailVerifiedAt.Valid {
+		return nil, rpcerror.New(codes.FailedPrecondition, "EMAIL_ALREADY_VERIFIED", "email address is already verified")
+	}
+
+	var token string
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		if err := q.InvalidateEmailVerificationTokens(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to invalidate verification tokens: %w", err)
+		}
+		token, err = issueVerificationToken(ctx, q, user.ID, "", s.emailVerification.TokenTTL)
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Failed to create email verification token: %v", err)
+		return nil, rpcerror.Internal("failed to resend verification email")
+	}
+
+	go s.sendVerificationEmail(user.Email, user.Name, token)
+
+	return &pb.ResendVerificationEmailResponse{}, nil
+}
+
+// sendVerificationEmail emails a verification link; failures are logged, as the RPC has already returned
+func (s *server) sendVerificationEmail(email, name, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	link, err := tokenLink(s.emailVerification.URL, token)
+	if err != nil {
+		log.Printf("❌ Invalid email verification URL: %v", err)
+		return
+	}
+
+	body := fmt.Sprintf(`Hello %s,
+
+Welcome to Smart Stay! Please confirm your email address by opening the link below.
+You can make reservations once your address is confirmed. The link expires in %d hours.
+
+%s
+
+If you did not create a Smart Stay account, you can ignore this email.
+`, name, int(s.emailVerification.TokenTTL.Hours()), link)
+
+	if err := s.mailer.Send(ctx, Message{
+		To:      email,
+		Subject: "Confirm your Smart Stay email address",
+		Body:    body,
+	}); err != nil {
+		log.Printf("❌ Failed to send verification email to %s: %v", email, err)
+	}
+}
+
+// purgeExpiredVerificationTokens deletes verification tokens that can no longer be used
+func (s *server) purgeExpiredVerificationTokens(ctx context.Context) error {
+	deleted, err := s.queries.DeleteExpiredEmailVerificationTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge email verification tokens: %w", err)
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Purged %d expired email verification token(s)", deleted)
+	}
+	return nil
+}
+
+// issueVerificationToken creates an email verification token for a user and returns it.
+// email is the new address of an email change, or empty to verify the current address.
+func issueVerificationToken(ctx context.Context, q *database.Queries, userID pgtype.UUID, email string, ttl time.Duration) (string, error) {
+	token, hash, err := newOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+	if _, err := q.CreateEmailVerificationToken(ctx, database.CreateEmailVerificationTokenParams{
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(ttl), Valid: true},
+		Email:     pgtype.Text{String: email, Valid: email != ""},
+	}); err != nil {
+		return "", fmt.Errorf("failed to store verification token: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+	"google.golang.org/grpc/codes"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/loginguard"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+)
+
+const (
+	// loginFailureWindow is how long failed logins are remembered when there are no new ones
+	loginFailureWindow = time.Hour
+	// loginBackoffBase is the wait after the first failed login for an email address; it doubles
+	// with every further failure up to loginBackoffMax
+	loginBackoffBase = time.Second
+	loginBackoffMax  = 30 * time.Second
+	// loginAttemptPurgeInterval is how often stale rows are deleted from login_attempts
+	loginAttemptPurgeInterval = time.Hour
+	// unlockTokenPurgeInterval is how often expired rows are deleted from account_unlock_tokens
+	unlockTokenPurgeInterval = time.Hour
+)
+
+// errInvalidCredentials is returned for unknown email addresses and wrong passwords alike
+var errInvalidCredentials = rpcerror.New(codes.Unauthenticated, "INVALID_CREDENTIALS", "invalid credentials")
+
+// errInvalidUnlockToken is returned for unknown, used and expired unlock tokens alike
+var errInvalidUnlockToken = rpcerror.New(codes.InvalidArgument, "INVALID_TOKEN", "invalid or expired unlock token")
+
+// loginGuardConfig controls brute-force protection for Login
+type loginGuardConfig struct {
+	// Store is where failed logins are counted: "postgres" (shared by all instances) or "memory"
+	Store string
+	// Email and IP are the limits per email address and per client IP
+	Email loginguard.Policy
+	IP    loginguard.Policy
+	// MFA is the limit on wrong MFA codes per user (second step of a login, disabling MFA, new recovery codes)
+	MFA loginguard.Policy
+	// UnlockTokenTTL is how long the unlock link sent at a lockout can be used
+	UnlockTokenTTL time.Duration
+	// UnlockURL is the frontend page that reads the token from the "token" query parameter
+	UnlockURL string
+}
+
+// loadLoginGuardConfig reads the brute-force protection configuration from the environment
+func loadLoginGuardConfig() (loginGuardConfig, error) {
+	config := loginGuardConfig{
+		Store:          "postgres",
+		UnlockTokenTTL: 24 * time.Hour,
+		UnlockURL:      "http://localhost:3000/unlock-account",
+	}
+	maxPerEmail, maxPerIP, lockout := 5, 20, 15*time.Minute
+
+	if v := os.Getenv("LOGIN_ATTEMPT_STORE"); v != "" {
+		if v != "postgres" && v != "memory" {
+			return config, fmt.Errorf("invalid LOGIN_ATTEMPT_STORE %q (want postgres or memory)", v)
+		}
+		config.Store = v
+	}
+	if v := os.Getenv("LOGIN_MAX_FAILURES_PER_EMAIL"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return config, fmt.Errorf("invalid LOGIN_MAX_FAILURES_PER_EMAIL %q", v)
+		}
+		maxPerEmail = n
+	}
+	if v := os.Getenv("LOGIN_MAX_FAILURES_PER_IP"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return config, fmt.Errorf("invalid LOGIN_MAX_FAILURES_PER_IP %q", v)
+		}
+		maxPerIP = n
+	}
+	if v := os.Getenv("LOGIN_LOCKOUT_MINUTES"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil || minutes <= 0 {
+			return config, fmt.Errorf("invalid LOGIN_LOCKOUT_MINUTES %q", v)
+		}
+		lockout = time.Duration(minutes) * time.Minute
+	}
+	if v := os.Getenv("ACCOUNT_UNLOCK_TOKEN_TTL_HOURS"); v != "" {
+		hours, err := strconv.Atoi(v)
+		if err != nil || hours <= 0 {
+			return config, fmt.Errorf("invalid ACCOUNT_UNLOCK_TOKEN_TTL_HOURS %q", v)
+		}
+		config.UnlockTokenTTL = time.Duration(hours) * time.Hour
+	}
+	if v := os.Getenv("ACCOUNT_UNLOCK_URL"); v != "" {
+		if _, err := url.Parse(v); err != nil {
+			return config, fmt.Errorf("invalid ACCOUNT_UNLOCK_URL %q", v)
+		}
+		config.UnlockURL = v
+	}
+
+	window := max(loginFailureWindow, lockout)
+	config.Email = loginguard.Policy{
+		MaxFailures:     maxPerEmail,
+		BaseDelay:       loginBackoffBase,
+		MaxDelay:        loginBackoffMax,
+		LockoutDuration: lockout,
+		Window:          window,
+	}
+	// Many users can share an IP (NAT, office networks), so IPs get no backoff, only a lockout
+	config.IP = loginguard.Policy{
+		MaxFailures:     maxPerIP,
+		LockoutDuration: lockout,
+		Window:          window,
+	}
+	// Neither a stolen password nor a stolen access token may be enough to guess the 6-digit code
+	config.MFA = loginguard.Policy{
+		MaxFailures:     maxMFAAttempts,
+		BaseDelay:       loginBackoffBase,
+		MaxDelay:        loginBackoffMax,
+		LockoutDuration: lockout,
+		Window:          window,
+	}
+
+	return config, nil
+}
+
+// newLoginGuard creates the guard for Login on the configured store
+func newLoginGuard(config loginGuardConfig, queries *database.Queries) *loginguard.Guard {
+	var store loginguard.Store = loginguard.NewPostgresStore(queries)
+	if config.Store == "memory" {
+		store = loginguard.NewMemoryStore()
+	}
+	return loginguard.New(store, map[loginguard.Kind]loginguard.Policy{
+		loginguard.KindEmail: config.Email,
+		loginguard.KindIP:    config.IP,
+		loginguard.KindMFA:   config.MFA,
+	})
+}
+
+// loginKeys returns the keys a login attempt is counted under
+func loginKeys(email, ipAddress string) []loginguard.Key {
+	keys := []loginguard.Key{loginguard.EmailKey(email)}
+	if ipAddress != "" {
+		keys = append(keys, loginguard.IPKey(ipAddress))
+	}
+	return keys
+}
+
+// tooManyLoginAttemptsError is returned while a login has to wait; the RetryInfo detail
+// tells the client how long
+func tooManyLoginAttemptsError(wait time.Duration) error {
+	// Round up, so that a client waiting exactly the advertised time is let through
+	wait = wait.Truncate(time.Second) + time.Second
+	return rpcerror.Retryable(codes.ResourceExhausted, "TOO_MANY_LOGIN_ATTEMPTS",
+		"too many failed login attempts, try again later", wait)
+}
+
+// recordLoginFailure ends a login attempt as a failure. Lockouts are audited, and the owner of a
+// locked-out account is emailed an unlock link. Errors are logged, as the login fails either way.
+func (s *server) recordLoginFailure(ctx context.Context, attempt *loginguard.Attempt, email, ipAddress string) {
+	lockouts, err := attempt.Fail(ctx)
+	if err != nil {
+		log.Printf("❌ Failed to record failed login: %v", err)
+	}
+
+	for _, lockout := range lockouts {
+		details := map[string]any{
+			"failures":     lockout.Failures,
+			"locked_until": lockout.Until.UTC().Format(time.RFC3339),
+		}
+		if lockout.Key.Kind != loginguard.KindEmail {
+			s.recordAuditEvent(ctx, auditLoginLockout, pgtype.UUID{}, lockout.Key.String(), ipAddress, details)
+			continue
+		}
+
+		user, err := s.queries.GetUserByEmail(ctx, email)
+		if err != nil {
+			// Unknown addresses are locked out too, so that lockouts do not reveal which accounts exist
+			s.recordAuditEvent(ctx, auditLoginLockout, pgtype.UUID{}, lockout.Key.String(), ipAddress, details)
+			continue
+		}
+		s.recordAuditEvent(ctx, auditLoginLockout, user.ID, lockout.Key.String(), ipAddress, details)
+
+		var token string
+		err = s.inTx(ctx, func(q *database.Queries) error {
+			if err := q.InvalidateAccountUnlockTokens(ctx, user.ID); err != nil {
+				return fmt.Errorf("failed to invalidate unlock tokens: %w", err)
+			}
+			token, err = issueUnlockToken(ctx, q, user.ID, s.loginGuardConfig.UnlockTokenTTL)
+			return err
+		})
+		if err != nil {
+			log.Printf("❌ Failed to create account unlock token: %v", err)
+			continue
+		}
+		go s.sendUnlockEmail(user.Email, user.Name, token, lockout.Until)
+	}
+}
+
+// UnlockAccount lifts the login lockout of an account with a token from an unlock link
+func (s *server) UnlockAccount(ctx context.Context, req *pb.UnlockAccountRequest) (*pb.UnlockAccountResponse, error) {
+	if req.Token == "" {
+		return nil, rpcerror.InvalidField("token", "token is required")
+	}
+
+	var userID pgtype.UUID
+	err := s.inTx(ctx, func(q *database.Queries) error {
+		var err error
+		// Consuming the token and checking it is one statement, so a token cannot be used twice
+		userID, err = q.UseAccountUnlockToken(ctx, hashOneTimeToken(req.Token))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errInvalidUnlockToken
+			}
+			return fmt.Errorf("failed to use unlock token: %w", err)
+		}
+		if err := q.InvalidateAccountUnlockTokens(ctx, userID); err != nil {
+			return fmt.Errorf("failed to invalidate unlock tokens: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errInvalidUnlockToken) {
+			log.Printf("❌ Invalid account unlock token")
+			return nil, errInvalidUnlockToken
+		}
+		log.Printf("❌ Failed to unlock account: %v", err)
+		return nil, rpcerror.Internal("failed to unlock account")
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Printf("❌ Failed to get user: %v", err)
+		return nil, rpcerror.Internal("failed to unlock account")
+	}
+	if err := s.unlockLogin(ctx, user, req.IpAddress, "unlock_link"); err != nil {
+		log.Printf("❌ Failed to unlock account: %v", err)
+		return nil, rpcerror.Internal("failed to unlock account")
+	}
+
+	log.Printf("✅ Account unlocked for user: %s", uuidToString(userID))
+	return &pb.UnlockAccountResponse{UserId: uuidToString(userID)}, nil
+}
+
+// unlockLogin forgets the failed logins for the email address of a user.
+// Lifting an active lockout is audited; method says how the user proved who they are.
+func (s *server) unlockLogin(ctx context.Context, user database.User, ipAddress, method string) error {
+	key := loginguard.EmailKey(user.Email)
+	wait, err := s.loginGuard.Check(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to check login lockout: %w", err)
+	}
+	if err := s.loginGuard.Reset(ctx, key); err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+	if wait > 0 {
+		s.recordAuditEvent(ctx, auditAccountUnlocked, user.ID, key.String(), ipAddress, map[string]any{
+			"method": method,
+		})
+	}
+	return nil
+}
+
+// sendUnlockEmail emails an unlock link; failures are logged, as the RPC has already returned
+func (s *server) sendUnlockEmail(email, name, token string, lockedUntil time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	link, err := tokenLink(s.loginGuardConfig.UnlockURL, token)
+	if err != nil {
+		log.Printf("❌ Invalid account unlock URL: %v", err)
+		return
+	}
+
+	body := fmt.Sprintf(`Hello %s,
+
+There were too many failed sign-in attempts for your Smart Stay account, so signing in
+is blocked until %s.
+
+If this was you, you can unlock your account right away with the link below.
+The link expires in %d hours.
+
+%s
+
+If this was not you, someone may be trying to guess your password. Your account is safe,
+but consider resetting your password with the "Forgot password" link.
+`, name, lockedUntil.UTC().Format("2006-01-02 15:04 MST"), int(s.loginGuardConfig.UnlockTokenTTL.Hours()), link)
+
+	if err := s.mailer.Send(ctx, Message{
+		To:      email,
+		Subject: "Your Smart Stay account has been locked",
+		Body:    body,
+	}); err != nil {
+		log.Printf("❌ Failed to send unlock email to %s: %v", email, err)
+	}
+}
+
+// purgeStaleLoginAttempts deletes failed login counters that no longer affect any login
+func (s *server) purgeStaleLoginAttempts(ctx context.Context) error {
+	deleted, err := s.loginGuard.Purge(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge login attempts: %w", err)
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Purged %d stale login attempt counter(s)", deleted)
+	}
+	return nil
+}
+
+// purgeExpiredUnlockTokens deletes unlock tokens that can no longer be used
+func (s *server) purgeExpiredUnlockTokens(ctx context.Context) error {
+	deleted, err := s.queries.DeleteExpiredAccountUnlockTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge account unlock tokens: %w", err)
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Purged %d expired account unlock token(s)", deleted)
+	}
+	return nil
+}
+
+// issueUnlockToken creates an account unlock token for a user and returns it
+func issueUnlockToken(ctx context.Context, q *database.Queries, userID pgtype.UUID, ttl time.Duration) (string, error) {
+	token, hash, err := newOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+	if _, err := q.CreateAccountUnlockToken(ctx, database.CreateAccountUnlockTokenParams{
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(ttl), Valid: true},
+	}); err != nil {
+		return "", fmt.Errorf("failed to store unlock token: %w", err)
+	}
+	return token, nil
+}
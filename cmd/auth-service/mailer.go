@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"os"
+	"strings"
+	"time"
+)
+
+// Mailer sends email to users.
+// Implementations wrap a delivery service (e.g., SendGrid, SES) or a local sink for development.
+type Mailer interface {
+	// Send delivers a message. An error means the message may not have been delivered.
+	Send(ctx context.Context, msg Message) error
+}
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+const (
+	// mailSendTimeout bounds sending one message; mail is sent after the RPC has returned
+	mailSendTimeout = 30 * time.Second
+
+	defaultMailFrom    = "Smart Stay <no-reply@smart-stay.local>"
+	defaultSMTPAddr    = "localhost:1025"
+	defaultMailFileDir = "mail"
+)
+
+// newMailer creates the mailer selected by the MAILER environment variable
+func newMailer() (Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = defaultMailFrom
+	}
+
+	switch mailer := os.Getenv("MAILER"); mailer {
+	case "", "file":
+		dir := os.Getenv("MAIL_FILE_DIR")
+		if dir == "" {
+			dir = defaultMailFileDir
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create MAIL_FILE_DIR: %w", err)
+		}
+		log.Printf("✅ Writing email to %s", dir)
+		return &fileMailer{from: from, dir: dir}, nil
+	case "smtp":
+		addr := os.Getenv("SMTP_ADDR")
+		if addr == "" {
+			addr = defaultSMTPAddr
+		}
+		log.Printf("✅ Sending email through SMTP server at %s", addr)
+		return newSMTPMailer(addr, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from), nil
+	default:
+		return nil, fmt.Errorf("unknown MAILER %q", mailer)
+	}
+}
+
+// formatMessage renders a message in RFC 5322 format
+func formatMessage(from string, msg Message) ([]byte, error) {
+	// Line breaks in a header would let its value add headers of its own
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, errors.New("line break in email header")
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes(), nil
+}
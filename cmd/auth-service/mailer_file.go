@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileMailer writes each message to an .eml file, for local development without a mail server
+type fileMailer struct {
+	from string
+	dir  string
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000Z"), randomHex(4))
+	path := filepath.Join(m.dir, name)
+	data, err := formatMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	log.Printf("📧 Wrote email %q for %s to %s", msg.Subject, msg.To, path)
+	return nil
+}
+
+// randomHex returns n random bytes as hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
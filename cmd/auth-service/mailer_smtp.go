@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/mail"
+	"net/smtp"
+)
+
+// smtpMailer sends messages through an SMTP server (e.g., MailHog for local development)
+type smtpMailer struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+// newSMTPMailer creates an SMTP mailer; without a username no authentication is used
+func newSMTPMailer(addr, username, password, from string) *smtpMailer {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	m := &smtpMailer{addr: addr, host: host, from: from}
+	if username != "" {
+		// PlainAuth refuses to send credentials without TLS, except to localhost
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+	data, err := formatMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.auth != nil {
+		if err := client.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(sender.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := client.Quit(); err != nil {
+		return err
+	}
+
+	log.Printf("📧 Sent email %q to %s", msg.Subject, msg.To)
+	return nil
+}
@@ -15,12 +15,15 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/jwt"
+	"github.com/karimiku/smart-stay-platform/internal/scheduler"
 	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
 )
 
 func main() {
-	// 1. Validate JWT secret is set (will panic if not set)
+	// 1. Validate JWT secret is set
 	// JWT_SECRET MUST be set via environment variable
+	// It encrypts the JWT signing keys stored in the database
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		log.Fatalf("❌ JWT_SECRET environment variable is required. Please set it in .env file or environment.")
@@ -63,17 +66,103 @@ func main() {
 	}
 	log.Println("✅ Database connection established")
 
+	// Load access and refresh token lifetimes
+	tokens, err := loadTokenConfig()
+	if err != nil {
+		log.Fatalf("❌ Invalid token configuration: %v", err)
+	}
+	log.Printf("✅ Tokens: access %s, refresh %s", tokens.AccessTokenTTL, tokens.RefreshTokenTTL)
+
+	revocationCacheTTL, err := loadRevocationCacheTTL()
+	if err != nil {
+		log.Fatalf("❌ Invalid token revocation configuration: %v", err)
+	}
+
+	passwordReset, err := loadPasswordResetConfig()
+	if err != nil {
+		log.Fatalf("❌ Invalid password reset configuration: %v", err)
+	}
+	emailVerification, err := loadEmailVerificationConfig()
+	if err != nil {
+		log.Fatalf("❌ Invalid email verification configuration: %v", err)
+	}
+	mailer, err := newMailer()
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize mailer: %v", err)
+	}
+
+	mfa := loadMFAConfig()
+	mfaSecrets, err := newMFASecretBox(jwtSecret)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize MFA: %v", err)
+	}
+	log.Printf("✅ MFA required for roles: %v", mfa.RequiredRoles)
+
+	loginGuardConfig, err := loadLoginGuardConfig()
+	if err != nil {
+		log.Fatalf("❌ Invalid login attempt configuration: %v", err)
+	}
+	log.Printf("✅ Login lockout after %d failure(s) per email, %d per IP, for %s (%s store)",
+		loginGuardConfig.Email.MaxFailures, loginGuardConfig.IP.MaxFailures,
+		loginGuardConfig.Email.LockoutDuration, loginGuardConfig.Store)
+
+	// Load the signing keys, creating the first one (or rotating a due one) if needed
+	signingConfig, err := loadSigningKeyConfig(tokens.AccessTokenTTL)
+	if err != nil {
+		log.Fatalf("❌ Invalid signing key configuration: %v", err)
+	}
+	queries := database.New(dbPool)
+	keyring := jwt.NewKeyring()
+	signingKeys, err := newSigningKeyManager(dbPool, queries, keyring, signingConfig, jwtSecret)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize signing keys: %v", err)
+	}
+	if err := signingKeys.rotate(ctx); err != nil {
+		log.Fatalf("❌ Failed to load signing keys: %v", err)
+	}
+	log.Printf("✅ Signing keys: %s, rotated every %s with %s overlap",
+		signingConfig.Algorithm, signingConfig.RotationInterval, signingConfig.Overlap)
+
 	// 5. Create a new gRPC server instance
 	grpcServer := grpc.NewServer()
 
 	// 6. Register the AuthService implementation
 	// We pass the database connection to the server
-	queries := database.New(dbPool)
+	revocations := newRevocationStore(queries, revocationCacheTTL)
 	authService := &server{
-		queries: queries,
+		db:          dbPool,
+		queries:     queries,
+		tokens:      tokens,
+		keys:        keyring,
+		revocations: revocations,
+
+		passwordReset:     passwordReset,
+		mailer:            mailer,
+		emailVerification: emailVerification,
+
+		mfa:        mfa,
+		mfaSecrets: mfaSecrets,
+
+		loginGuard:       newLoginGuard(loginGuardConfig, queries),
+		loginGuardConfig: loginGuardConfig,
 	}
 	pb.RegisterAuthServiceServer(grpcServer, authService)
 
+	// Rotate signing keys and purge expired token revocations and emailed tokens in the background;
+	// only one instance runs the jobs at a time, but every instance reloads the keys
+	schedCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	sched := scheduler.New(dbPool, "auth-service")
+	sched.Every("rotate-signing-keys", keyRotationCheckInterval, signingKeys.rotate)
+	sched.Every("purge-revoked-tokens", revokedTokenPurgeInterval, revocations.purgeExpired)
+	sched.Every("purge-password-reset-tokens", resetTokenPurgeInterval, authService.purgeExpiredResetTokens)
+	sched.Every("purge-email-verification-tokens", verificationTokenPurgeInterval, authService.purgeExpiredVerificationTokens)
+	sched.Every("purge-mfa-challenges", mfaChallengePurgeInterval, authService.purgeExpiredMFAChallenges)
+	sched.Every("purge-login-attempts", loginAttemptPurgeInterval, authService.purgeStaleLoginAttempts)
+	sched.Every("purge-account-unlock-tokens", unlockTokenPurgeInterval, authService.purgeExpiredUnlockTokens)
+	go sched.Run(schedCtx)
+	go signingKeys.watch(schedCtx)
+
 	// 7. Enable Server Reflection (Useful for debugging with tools like Evans or Postman)
 	reflection.Register(grpcServer)
 
@@ -92,4 +181,4 @@ func main() {
 	log.Println("🛑 Shutting down server...")
 	grpcServer.GracefulStop()
 	log.Println("✅ Server stopped")
-}
\ No newline at end of file
+}
@@ -0,0 +1,672 @@
+package main
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/loginguard"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+	"github.com/karimiku/smart-stay-platform/internal/totp"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+)
+
+const (
+	// mfaChallengeTTL is how long the second step of a login can take
+	mfaChallengeTTL = 5 * time.Minute
+	// maxMFAAttempts is the number of wrong codes after which a login has to start over,
+	// and after which a user is locked out of MFA codes for the login lockout duration
+	maxMFAAttempts = 5
+	// recoveryCodeCount is the number of recovery codes issued at a time
+	recoveryCodeCount = 10
+	// recoveryCodeBytes is the amount of randomness in a recovery code
+	recoveryCodeBytes = 5
+	// mfaChallengePurgeInterval is how often expired rows are deleted from mfa_challenges
+	mfaChallengePurgeInterval = time.Hour
+)
+
+var (
+	// errInvalidMFAToken is returned for unknown, used and expired MFA challenge tokens alike
+	errInvalidMFAToken = rpcerror.New(codes.Unauthenticated, "INVALID_MFA_TOKEN", "invalid or expired MFA token")
+	// errInvalidMFACode is returned for wrong, replayed and used codes
+	errInvalidMFACode = rpcerror.New(codes.Unauthenticated, "INVALID_MFA_CODE", "invalid MFA code")
+	// errMFANotEnabled is returned when a code is checked for a user without MFA
+	errMFANotEnabled = status.Error(codes.FailedPrecondition, "MFA is not enabled")
+)
+
+// recoveryCodeEncoding renders recovery codes in lowercase base32, which leaves out 0, 1, 8 and 9
+// so that they are not confused with letters
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// mfaConfig controls multi-factor authentication
+type mfaConfig struct {
+	// RequiredRoles are the roles that must use MFA; users with these roles enroll at their next login
+	RequiredRoles map[string]bool
+	// Issuer is the name authenticator apps show for the account
+	Issuer string
+}
+
+// loadMFAConfig reads the MFA configuration from the environment
+func loadMFAConfig() mfaConfig {
+	config := mfaConfig{
+		RequiredRoles: map[string]bool{"owner": true, "staff": true},
+		Issuer:        "Smart Stay",
+	}
+
+	// MFA_REQUIRED_ROLES="" turns enforcement off; MFA stays available to everyone
+	if v, ok := os.LookupEnv("MFA_REQUIRED_ROLES"); ok {
+		config.RequiredRoles = map[string]bool{}
+		for _, role := range strings.Split(v, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				config.RequiredRoles[role] = true
+			}
+		}
+	}
+	if v := os.Getenv("MFA_ISSUER"); v != "" {
+		config.Issuer = v
+	}
+
+	return config
+}
+
+// mfaSecretBox encrypts TOTP secrets at rest
+type mfaSecretBox struct {
+	aead cipher.AEAD
+}
+
+// newMFASecretBox creates a secret box with a key derived from secret.
+// The key differs from the one that encrypts signing keys.
+func newMFASecretBox(secret string) (*mfaSecretBox, error) {
+	encryptionKey := sha256.Sum256([]byte("mfa:" + secret))
+	block, err := aes.NewCipher(encryptionKey[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &mfaSecretBox{aead: aead}, nil
+}
+
+// seal encrypts the TOTP secret of a user; the user ID is authenticated, so a secret cannot be moved to another user
+func (b *mfaSecretBox) seal(userID pgtype.UUID, secret []byte) ([]byte, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return b.aead.Seal(nonce, nonce, secret, userID.Bytes[:]), nil
+}
+
+// open decrypts a secret sealed by seal
+func (b *mfaSecretBox) open(userID pgtype.UUID, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < b.aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:b.aead.NonceSize()], ciphertext[b.aead.NonceSize():]
+	return b.aead.Open(nil, nonce, sealed, userID.Bytes[:])
+}
+
+// beginMFALogin starts the second step of a login if the user has MFA enabled or their role requires it.
+// It returns nil if the password is enough, and a gRPC status while the user's MFA codes are backing off.
+func (s *server) beginMFALogin(ctx context.Context, user database.User) (*pb.LoginResponse, error) {
+	enrolled := false
+	mfa, err := s.queries.GetUserMFA(ctx, user.ID)
+	if err == nil {
+		enrolled = mfa.EnabledAt.Valid
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get MFA: %w", err)
+	}
+	if !enrolled && !s.mfa.RequiredRoles[user.Role] {
+		return nil, nil
+	}
+	// A correct password does not clear wrong codes; no new challenge until the user may try again
+	if err := s.checkMFABackoff(ctx, user); err != nil {
+		return nil, err
+	}
+
+	token, hash, err := newOneTimeToken()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.queries.CreateMFAChallenge(ctx, database.CreateMFAChallengeParams{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(mfaChallengeTTL), Valid: true},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create MFA challenge: %w", err)
+	}
+
+	return &pb.LoginResponse{
+		MfaRequired:           true,
+		MfaToken:              token,
+		MfaExpiresIn:          int64(mfaChallengeTTL.Seconds()),
+		MfaEnrollmentRequired: !enrolled,
+	}, nil
+}
+
+// VerifyMFA completes a login with a TOTP or recovery code and starts the session
+func (s *server) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error) {
+	if req.MfaToken == "" {
+		return nil, rpcerror.InvalidField("mfa_token", "mfa_token is required")
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, rpcerror.InvalidField("code", "code is required")
+	}
+
+	challenge, user, err := s.getMFAChallenge(ctx, req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrong codes are counted per user as well as per challenge: a new challenge after
+	// another correct password must not give new guesses
+	attempt, err := s.beginMFACode(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.checkMFACode(ctx, s.queries, user.ID, req.Code)
+	s.finishMFACode(ctx, attempt, user, req.IpAddress, ok, err)
+	if err != nil {
+		if errors.Is(err, errMFANotEnabled) {
+			return nil, rpcerror.New(codes.FailedPrecondition, "MFA_ENROLLMENT_REQUIRED", "MFA enrollment is required")
+		}
+		log.Printf("❌ Failed to check MFA code: %v", err)
+		return nil, rpcerror.Internal("failed to verify MFA code")
+	}
+	if !ok {
+		s.recordMFAFailure(ctx, challenge)
+		return nil, errInvalidMFACode
+	}
+
+	// Only one request can complete a challenge
+	used, err := s.queries.UseMFAChallenge(ctx, challenge.ID)
+	if err != nil {
+		log.Printf("❌ Failed to use MFA challenge: %v", err)
+		return nil, rpcerror.Internal("failed to verify MFA code")
+	}
+	if used == 0 {
+		return nil, errInvalidMFAToken
+	}
+
+	tokens, err := s.startSession(ctx, user, req.UserAgent, req.IpAddress)
+	if err != nil {
+		log.Printf("❌ Failed to start session: %v", err)
+		return nil, rpcerror.Internal("failed to generate token")
+	}
+	remaining, err := s.queries.CountUnusedMFARecoveryCodes(ctx, user.ID)
+	if err != nil {
+		log.Printf("⚠️ Failed to count recovery codes: %v", err)
+	}
+
+	log.Printf("✅ MFA login completed for user: %s", uuidToString(user.ID))
+	return &pb.VerifyMFAResponse{
+		AccessToken:            tokens.AccessToken,
+		ExpiresIn:              int64(s.tokens.AccessTokenTTL.Seconds()),
+		RefreshToken:           tokens.RefreshToken,
+		RefreshExpiresIn:       int64(s.tokens.RefreshTokenTTL.Seconds()),
+		RecoveryCodesRemaining: int32(remaining),
+	}, nil
+}
+
+// EnrollMFA creates a new TOTP secret for the user. Until it is confirmed, enrollment can be restarted.
+func (s *server) EnrollMFA(ctx context.Context, req *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error) {
+	user, challenge, err := s.mfaSubject(ctx, req.UserId, req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🔐 EnrollMFA request received for user: %s", uuidToString(user.ID))
+	if challenge != nil {
+		if err := s.checkMFABackoff(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Printf("❌ Failed to generate TOTP secret: %v", err)
+		return nil, rpcerror.Internal("failed to enroll MFA")
+	}
+	sealed, err := s.mfaSecrets.seal(user.ID, secret)
+	if err != nil {
+		log.Printf("❌ Failed to encrypt TOTP secret: %v", err)
+		return nil, rpcerror.Internal("failed to enroll MFA")
+	}
+	if _, err := s.queries.UpsertPendingMFA(ctx, database.UpsertPendingMFAParams{
+		UserID:          user.ID,
+		EncryptedSecret: sealed,
+	}); err != nil {
+		// The upsert does not touch an enabled enrollment
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "MFA is already enabled")
+		}
+		log.Printf("❌ Failed to store TOTP secret: %v", err)
+		return nil, rpcerror.Internal("failed to enroll MFA")
+	}
+
+	return &pb.EnrollMFAResponse{
+		Secret:     totp.EncodeSecret(secret),
+		OtpauthUri: totp.URI(s.mfa.Issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables MFA with a first code from the authenticator app and issues recovery codes.
+// During a login that requires enrollment, the login is completed as well.
+func (s *server) ConfirmMFA(ctx context.Context, req *pb.ConfirmMFARequest) (*pb.ConfirmMFAResponse, error) {
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, rpcerror.InvalidField("code", "code is required")
+	}
+	user, challenge, err := s.mfaSubject(ctx, req.UserId, req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.queries.GetUserMFA(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "MFA enrollment has not been started")
+		}
+		log.Printf("❌ Failed to get MFA: %v", err)
+		return nil, rpcerror.Internal("failed to confirm MFA")
+	}
+	if mfa.EnabledAt.Valid {
+		return nil, status.Error(codes.FailedPrecondition, "MFA is already enabled")
+	}
+	secret, err := s.mfaSecrets.open(user.ID, mfa.EncryptedSecret)
+	if err != nil {
+		log.Printf("❌ Failed to decrypt TOTP secret: %v", err)
+		return nil, rpcerror.Internal("failed to confirm MFA")
+	}
+
+	// During a login the code is the second step, so it is counted like one in VerifyMFA
+	var attempt *loginguard.Attempt
+	if challenge != nil {
+		if attempt, err = s.beginMFACode(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	step, ok := totp.Validate(secret, req.Code, time.Now())
+	if attempt != nil {
+		s.finishMFACode(ctx, attempt, user, req.IpAddress, ok, nil)
+	}
+	if !ok {
+		if challenge != nil {
+			s.recordMFAFailure(ctx, *challenge)
+		}
+		return nil, errInvalidMFACode
+	}
+
+	var recoveryCodes []string
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		enabled, err := q.EnableMFA(ctx, database.EnableMFAParams{UserID: user.ID, LastUsedStep: step})
+		if err != nil {
+			return fmt.Errorf("failed to enable MFA: %w", err)
+		}
+		if enabled == 0 {
+			return status.Error(codes.FailedPrecondition, "MFA is already enabled")
+		}
+		if challenge != nil {
+			used, err := q.UseMFAChallenge(ctx, challenge.ID)
+			if err != nil {
+				return fmt.Errorf("failed to use MFA challenge: %w", err)
+			}
+			if used == 0 {
+				return errInvalidMFAToken
+			}
+		}
+		recoveryCodes, err = replaceRecoveryCodes(ctx, q, user.ID)
+		return err
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		log.Printf("❌ Failed to confirm MFA: %v", err)
+		return nil, rpcerror.Internal("failed to confirm MFA")
+	}
+	log.Printf("✅ MFA enabled for user: %s", uuidToString(user.ID))
+
+	res := &pb.ConfirmMFAResponse{RecoveryCodes: recoveryCodes}
+	if challenge != nil {
+		tokens, err := s.startSession(ctx, user, req.UserAgent, req.IpAddress)
+		if err != nil {
+			log.Printf("❌ Failed to start session: %v", err)
+			return nil, rpcerror.Internal("failed to generate token")
+		}
+		res.AccessToken = tokens.AccessToken
+		res.ExpiresIn = int64(s.tokens.AccessTokenTTL.Seconds())
+		res.RefreshToken = tokens.RefreshToken
+		res.RefreshExpiresIn = int64(s.tokens.RefreshTokenTTL.Seconds())
+	}
+	return res, nil
+}
+
+// DisableMFA turns MFA off after checking a code, unless the user's role requires it
+func (s *server) DisableMFA(ctx context.Context, req *pb.DisableMFARequest) (*pb.DisableMFAResponse, error) {
+	log.Printf("🔐 DisableMFA request received for user: %s", req.UserId)
+
+	user, err := s.mfaUser(ctx, req.UserId, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if s.mfa.RequiredRoles[user.Role] {
+		return nil, rpcerror.New(codes.FailedPrecondition, "MFA_REQUIRED_FOR_ROLE", fmt.Sprintf("MFA is required for the %s role", user.Role))
+	}
+
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		if err := q.DeleteUserMFA(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to delete MFA: %w", err)
+		}
+		if err := q.DeleteMFARecoveryCodes(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("❌ Failed to disable MFA: %v", err)
+		return nil, rpcerror.Internal("failed to disable MFA")
+	}
+
+	log.Printf("✅ MFA disabled for user: %s", req.UserId)
+	return &pb.DisableMFAResponse{}, nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of a user after checking a code
+func (s *server) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RegenerateRecoveryCodesResponse, error) {
+	log.Printf("🔐 RegenerateRecoveryCodes request received for user: %s", req.UserId)
+
+	user, err := s.mfaUser(ctx, req.UserId, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		var err error
+		recoveryCodes, err = replaceRecoveryCodes(ctx, q, user.ID)
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Failed to regenerate recovery codes: %v", err)
+		return nil, rpcerror.Internal("failed to regenerate recovery codes")
+	}
+
+	return &pb.RegenerateRecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// mfaSubject identifies the user of an enrollment request: a signed-in user by ID,
+// or a user in the middle of a login by MFA challenge token (the challenge is returned too)
+func (s *server) mfaSubject(ctx context.Context, userID, mfaToken string) (database.User, *database.MfaChallenge, error) {
+	switch {
+	case userID != "" && mfaToken != "":
+		return database.User{}, nil, status.Error(codes.InvalidArgument, "either user_id or mfa_token must be set, not both")
+	case mfaToken != "":
+		challenge, user, err := s.getMFAChallenge(ctx, mfaToken)
+		if err != nil {
+			return database.User{}, nil, err
+		}
+		return user, &challenge, nil
+	case userID != "":
+		user, err := s.getUser(ctx, userID)
+		return user, nil, err
+	default:
+		return database.User{}, nil, status.Error(codes.InvalidArgument, "user_id or mfa_token is required")
+	}
+}
+
+// mfaUser loads a signed-in user and checks a TOTP or recovery code for a sensitive MFA change.
+// Wrong codes are counted per user with backoff and lockout, like failed logins.
+func (s *server) mfaUser(ctx context.Context, userID, code string) (database.User, error) {
+	if strings.TrimSpace(code) == "" {
+		return database.User{}, rpcerror.InvalidField("code", "code is required")
+	}
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return database.User{}, err
+	}
+
+	attempt, err := s.beginMFACode(ctx, user)
+	if err != nil {
+		return database.User{}, err
+	}
+	ok, err := s.checkMFACode(ctx, s.queries, user.ID, code)
+	s.finishMFACode(ctx, attempt, user, "", ok, err)
+	if err != nil {
+		if errors.Is(err, errMFANotEnabled) {
+			return database.User{}, errMFANotEnabled
+		}
+		log.Printf("❌ Failed to check MFA code: %v", err)
+		return database.User{}, rpcerror.Internal("failed to check MFA code")
+	}
+	if !ok {
+		return database.User{}, errInvalidMFACode
+	}
+	return user, nil
+}
+
+// beginMFACode counts an MFA code of a user as wrong before it is checked, so that neither
+// concurrent requests nor new login challenges give more guesses than the MFA policy allows.
+// It returns a ResourceExhausted error while the user is backing off or locked out.
+func (s *server) beginMFACode(ctx context.Context, user database.User) (*loginguard.Attempt, error) {
+	attempt, wait, err := s.loginGuard.Begin(ctx, loginguard.MFAKey(uuidToString(user.ID)))
+	if err != nil {
+		log.Printf("❌ Failed to check wrong MFA codes: %v", err)
+		return nil, rpcerror.Internal("failed to check MFA code")
+	}
+	if wait > 0 {
+		log.Printf("⚠️  MFA code refused for user: %s (retry in %s)", uuidToString(user.ID), wait.Round(time.Second))
+		return nil, tooManyMFAAttemptsError(wait)
+	}
+	return attempt, nil
+}
+
+// finishMFACode ends an attempt begun by beginMFACode with the result of the check: a right code
+// clears the wrong codes of the user, a wrong one stays counted (lockouts are audited), and an
+// error gives the attempt back. Errors are logged, as the result of the request does not depend on them.
+func (s *server) finishMFACode(ctx context.Context, attempt *loginguard.Attempt, user database.User, ipAddress string, ok bool, checkErr error) {
+	userID := uuidToString(user.ID)
+	if checkErr == nil && !ok {
+		lockouts, err := attempt.Fail(ctx)
+		if err != nil {
+			log.Printf("❌ Failed to record wrong MFA code: %v", err)
+		}
+		for _, lockout := range lockouts {
+			s.recordAuditEvent(ctx, auditMFALockout, user.ID, lockout.Key.String(), ipAddress, map[string]any{
+				"failures":     lockout.Failures,
+				"locked_until": lockout.Until.UTC().Format(time.RFC3339),
+			})
+		}
+		return
+	}
+
+	if err := attempt.Release(ctx); err != nil {
+		log.Printf("⚠️  Failed to release MFA attempt for user %s: %v", userID, err)
+	}
+	if checkErr == nil {
+		if err := s.loginGuard.Reset(ctx, loginguard.MFAKey(userID)); err != nil {
+			log.Printf("⚠️  Failed to reset wrong MFA codes for user %s: %v", userID, err)
+		}
+	}
+}
+
+// checkMFABackoff returns a ResourceExhausted error while a user is backing off or locked out
+// after wrong MFA codes, without counting an attempt
+func (s *server) checkMFABackoff(ctx context.Context, user database.User) error {
+	wait, err := s.loginGuard.Check(ctx, loginguard.MFAKey(uuidToString(user.ID)))
+	if err != nil {
+		log.Printf("❌ Failed to check wrong MFA codes: %v", err)
+		return rpcerror.Internal("failed to check MFA code")
+	}
+	if wait > 0 {
+		log.Printf("⚠️  MFA refused for user: %s (retry in %s)", uuidToString(user.ID), wait.Round(time.Second))
+		return tooManyMFAAttemptsError(wait)
+	}
+	return nil
+}
+
+// tooManyMFAAttemptsError is returned while a user has to wait before trying another
+// MFA code; the RetryInfo detail tells the client how long
+func tooManyMFAAttemptsError(wait time.Duration) error {
+	// Round up, so that a client waiting exactly the advertised time is let through
+	wait = wait.Truncate(time.Second) + time.Second
+	return rpcerror.Retryable(codes.ResourceExhausted, "TOO_MANY_MFA_ATTEMPTS",
+		"too many wrong MFA codes, try again later", wait)
+}
+
+// getUser loads a user by ID, mapping a bad or unknown ID to a gRPC status
+func (s *server) getUser(ctx context.Context, userID string) (database.User, error) {
+	id, err := stringToUUID(userID)
+	if err != nil {
+		return database.User{}, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+	user, err := s.queries.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.User{}, status.Error(codes.NotFound, "user not found")
+		}
+		log.Printf("❌ Failed to get user: %v", err)
+		return database.User{}, rpcerror.Internal("failed to get user")
+	}
+	return user, nil
+}
+
+// getMFAChallenge loads an open MFA challenge and its user
+func (s *server) getMFAChallenge(ctx context.Context, token string) (database.MfaChallenge, database.User, error) {
+	challenge, err := s.queries.GetMFAChallengeByHash(ctx, hashOneTimeToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.MfaChallenge{}, database.User{}, errInvalidMFAToken
+		}
+		log.Printf("❌ Failed to get MFA challenge: %v", err)
+		return database.MfaChallenge{}, database.User{}, rpcerror.Internal("failed to get MFA challenge")
+	}
+	user, err := s.queries.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		log.Printf("❌ Failed to get user: %v", err)
+		return database.MfaChallenge{}, database.User{}, rpcerror.Internal("failed to get MFA challenge")
+	}
+	return challenge, user, nil
+}
+
+// recordMFAFailure counts a wrong code against a challenge; after maxMFAAttempts the login has to start over
+func (s *server) recordMFAFailure(ctx context.Context, challenge database.MfaChallenge) {
+	attempts, err := s.queries.RecordMFAChallengeFailure(ctx, database.RecordMFAChallengeFailureParams{
+		ID:          challenge.ID,
+		MaxAttempts: maxMFAAttempts,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to record MFA failure: %v", err)
+		return
+	}
+	log.Printf("❌ Wrong MFA code for user %s (%d/%d)", uuidToString(challenge.UserID), attempts, maxMFAAttempts)
+}
+
+// checkMFACode checks a 6-digit TOTP code or a recovery code. Both can be used once:
+// a TOTP code is rejected if its time step is not after the last accepted one.
+func (s *server) checkMFACode(ctx context.Context, q *database.Queries, userID pgtype.UUID, code string) (bool, error) {
+	mfa, err := q.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, errMFANotEnabled
+		}
+		return false, fmt.Errorf("failed to get MFA: %w", err)
+	}
+	if !mfa.EnabledAt.Valid {
+		return false, errMFANotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits && strings.Trim(code, "0123456789") == "" {
+		secret, err := s.mfaSecrets.open(userID, mfa.EncryptedSecret)
+		if err != nil {
+			return false, fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+		}
+		step, ok := totp.Validate(secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		accepted, err := q.UseMFAStep(ctx, database.UseMFAStepParams{UserID: userID, LastUsedStep: step})
+		if err != nil {
+			return false, fmt.Errorf("failed to record TOTP step: %w", err)
+		}
+		return accepted == 1, nil
+	}
+
+	used, err := q.UseMFARecoveryCode(ctx, database.UseMFARecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: hashRecoveryCode(code),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if used == 1 {
+		log.Printf("⚠️ Recovery code used by user: %s", uuidToString(userID))
+	}
+	return used == 1, nil
+}
+
+// purgeExpiredMFAChallenges deletes MFA challenges that can no longer be completed
+func (s *server) purgeExpiredMFAChallenges(ctx context.Context) error {
+	deleted, err := s.queries.DeleteExpiredMFAChallenges(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge MFA challenges: %w", err)
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Purged %d expired MFA challenge(s)", deleted)
+	}
+	return nil
+}
+
+// replaceRecoveryCodes deletes the recovery codes of a user and returns new ones.
+// Only their hashes are stored, so they can be shown to the user only once.
+func replaceRecoveryCodes(ctx context.Context, q *database.Queries, userID pgtype.UUID) ([]string, error) {
+	if err := q.DeleteMFARecoveryCodes(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	for len(recoveryCodes) < recoveryCodeCount {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		code := recoveryCodeEncoding.EncodeToString(b)
+		code = code[:4] + "-" + code[4:]
+		if err := q.CreateMFARecoveryCode(ctx, database.CreateMFARecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to store recovery code: %w", err)
+		}
+		recoveryCodes = append(recoveryCodes, code)
+	}
+	return recoveryCodes, nil
+}
+
+// hashRecoveryCode returns the SHA-256 hash under which a recovery code is stored.
+// Case, spaces and dashes are ignored, so "ABCD EFGH" matches "abcd-efgh".
+func hashRecoveryCode(code string) []byte {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return sum[:]
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+)
+
+// oneTimeTokenBytes is the number of random bytes in a token sent by email
+const oneTimeTokenBytes = 32
+
+// newOneTimeToken generates a token for an emailed link (password reset, email verification,
+// account unlock) and the hash it is stored under. Only the hash is stored, so the token cannot
+// be recovered from the database.
+func newOneTimeToken() (token string, hash []byte, err error) {
+	b := make([]byte, oneTimeTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashOneTimeToken(token), nil
+}
+
+// hashOneTimeToken returns the SHA-256 hash under which a one-time token is stored
+func hashOneTimeToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// tokenLink adds a token to a link as the "token" query parameter
+func tokenLink(base, token string) (string, error) {
+	link, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
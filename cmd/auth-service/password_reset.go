@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+)
+
+// resetTokenPurgeInterval is how often expired rows are deleted from password_reset_tokens
+const resetTokenPurgeInterval = time.Hour
+
+// errInvalidResetToken is returned for unknown, used and expired reset tokens alike
+var errInvalidResetToken = rpcerror.New(codes.InvalidArgument, "INVALID_TOKEN", "invalid or expired reset token")
+
+// passwordResetConfig controls password reset links
+type passwordResetConfig struct {
+	// TokenTTL is how long a reset link can be used
+	TokenTTL time.Duration
+	// URL is the frontend page that reads the token from the "token" query parameter
+	URL string
+}
+
+// loadPasswordResetConfig reads the password reset configuration from the environment
+func loadPasswordResetConfig() (passwordResetConfig, error) {
+	config := passwordResetConfig{
+		TokenTTL: time.Hour,
+		URL:      "http://localhost:3000/reset-password",
+	}
+
+	if v := os.Getenv("PASSWORD_RESET_TOKEN_TTL_MINUTES"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil || minutes <= 0 {
+			return config, fmt.Errorf("invalid PASSWORD_RESET_TOKEN_TTL_MINUTES %q", v)
+		}
+		config.TokenTTL = time.Duration(minutes) * time.Minute
+	}
+	if v := os.Getenv("PASSWORD_RESET_URL"); v != "" {
+		if _, err := url.Parse(v); err != nil {
+			return config, fmt.Errorf("invalid PASSWORD_RESET_URL %q", v)
+		}
+		config.URL = v
+	}
+
+	return config, nil
+}
+
+// RequestPasswordReset emails a one-time reset link to a registered address.
+// Unknown addresses get the same response, so that the RPC cannot be used to find accounts.
+func (s *server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return nil, rpcerror.InvalidField("email", "email is required")
+	}
+	log.Printf("📨 RequestPasswordReset request received for email: %s", email)
+
+	user, err := s.queries.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("⚠️  Password reset requested for unknown email: %s", email)
+			return &pb.RequestPasswordResetResponse{}, nil
+		}
+		log.Printf("❌ Failed to get user: %v", err)
+		return nil, rpcerror.Internal("failed to request password reset")
+	}
+
+	// Only the latest link works
+	var token string
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		if err := q.InvalidatePasswordResetTokens(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+		}
+		token, err = issueResetToken(ctx, q, user.ID, s.passwordReset.TokenTTL)
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Failed to create password reset token: %v", err)
+		return nil, rpcerror.Internal("failed to request password reset")
+	}
+
+	// Send in the background: waiting for the mail server would make known addresses
+	// measurably slower to answer than unknown ones
+	go s.sendPasswordResetEmail(user.Email, user.Name, token)
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+// ResetPassword sets a new password with a reset token and ends all sessions of the user
+func (s *server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	if req.Token == "" {
+		return nil, rpcerror.InvalidField("token", "token is required")
+	}
+	if err := validatePassword(req.NewPassword); err != nil {
+		return nil, rpcerror.InvalidField("new_password", err.Error())
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("❌ Failed to hash password: %v", err)
+		return nil, rpcerror.Internal("failed to process password")
+	}
+
+	var userID pgtype.UUID
+	var revoked int
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		// Consuming the token and checking it is one statement, so a token cannot be used twice
+		userID, err = q.UsePasswordResetToken(ctx, hashOneTimeToken(req.Token))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errInvalidResetToken
+			}
+			return fmt.Errorf("failed to use reset token: %w", err)
+		}
+		if err := q.InvalidatePasswordResetTokens(ctx, userID); err != nil {
+			return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+		}
+		if err := q.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+			ID:             userID,
+			HashedPassword: hashedPassword,
+		}); err != nil {
+			return fmt.Errorf("failed to update password: %w", err)
+		}
+		// Whoever knew the old password must not stay signed in
+		revoked, err = s.revokeUserSessions(ctx, q, userID, pgtype.UUID{}, "password reset")
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errInvalidResetToken) {
+			log.Printf("❌ Invalid password reset token")
+			return nil, errInvalidResetToken
+		}
+		log.Printf("❌ Failed to reset password: %v", err)
+		return nil, rpcerror.Internal("failed to reset password")
+	}
+
+	// Whoever reset the password controls the mailbox, so a lockout no longer protects anything
+	if user, err := s.queries.GetUserByID(ctx, userID); err != nil {
+		log.Printf("⚠️  Failed to get user to lift login lockout: %v", err)
+	} else if err := s.unlockLogin(ctx, user, "", "password_reset"); err != nil {
+		log.Printf("⚠️  Failed to lift login lockout: %v", err)
+	}
+
+	log.Printf("✅ Password reset for user: %s (%d session(s) ended)", uuidToString(userID), revoked)
+	return &pb.ResetPasswordResponse{RevokedCount: int32(revoked)}, nil
+}
+
+// sendPasswordResetEmail emails a reset link; failures are logged, as the RPC has already returned
+func (s *server) sendPasswordResetEmail(email, name, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	link, err := tokenLink(s.passwordReset.URL, token)
+	if err != nil {
+		log.Printf("❌ Invalid password reset URL: %v", err)
+		return
+	}
+
+	body := fmt.Sprintf(`Hello %s,
+
+We received a request to reset the password of your Smart Stay account.
+Open the link below to choose a new password. The link can be used once and expires in %d minutes.
+
+%s
+
+If you did not request this, you can ignore this email; your password will not change.
+`, name, int(s.passwordReset.TokenTTL.Minutes()), link)
+
+	if err := s.mailer.Send(ctx, Message{
+		To:      email,
+		Subject: "Reset your Smart Stay password",
+		Body:    body,
+	}); err != nil {
+		log.Printf("❌ Failed to send password reset email to %s: %v", email, err)
+	}
+}
+
+// purgeExpiredResetTokens deletes reset tokens that can no longer be used
+func (s *server) purgeExpiredResetTokens(ctx context.Context) error {
+	deleted, err := s.queries.DeleteExpiredPasswordResetTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge password reset tokens: %w", err)
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Purged %d expired password reset token(s)", deleted)
+	}
+	return nil
+}
+
+// issueResetToken creates a password reset token for a user and returns it
+func issueResetToken(ctx context.Context, q *database.Queries, userID pgtype.UUID, ttl time.Duration) (string, error) {
+	token, hash, err := newOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+	if _, err := q.CreatePasswordResetToken(ctx, database.CreatePasswordResetTokenParams{
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(ttl), Valid: true},
+	}); err != nil {
+		return "", fmt.Errorf("failed to store reset token: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+)
+
+// maxNameLength is the maximum length of a user's name in characters (users.name is VARCHAR(255))
+const maxNameLength = 255
+
+// supportedLocales are the languages a user can choose; the first one is the default
+var supportedLocales = []string{"ja", "en"}
+
+// phonePattern matches a phone number in E.164 format, after spaces, hyphens and parentheses are removed
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// errEmailAlreadyRegistered is returned when an email address belongs to another account
+var errEmailAlreadyRegistered = rpcerror.New(codes.AlreadyExists, "EMAIL_ALREADY_REGISTERED", "email already registered")
+
+// GetUser returns the profile of a user
+func (s *server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	userID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		log.Printf("❌ Failed to get user: %v", err)
+		return nil, rpcerror.Internal("failed to get user")
+	}
+
+	profile, err := s.userProfile(ctx, user)
+	if err != nil {
+		log.Printf("❌ Failed to get user profile: %v", err)
+		return nil, rpcerror.Internal("failed to get user")
+	}
+	return &pb.GetUserResponse{User: profile}, nil
+}
+
+// UpdateProfile changes the fields of a profile that are set in the request.
+// A new email address gets a verification link and replaces the current one when the link is opened.
+func (s *server) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
+	log.Printf("👤 UpdateProfile request received for user: %s", req.UserId)
+
+	userID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	// 1. Validate the fields that are set
+	params := database.UpdateUserProfileParams{ID: userID}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, rpcerror.InvalidField("name", "name must not be empty")
+		}
+		if utf8.RuneCountInString(name) > maxNameLength {
+			return nil, rpcerror.InvalidField("name", fmt.Sprintf("name must be at most %d characters", maxNameLength))
+		}
+		params.Name = pgtype.Text{String: name, Valid: true}
+	}
+	if req.Phone != nil {
+		phone, err := normalizePhone(*req.Phone)
+		if err != nil {
+			return nil, rpcerror.InvalidField("phone", err.Error())
+		}
+		params.Phone = pgtype.Text{String: phone, Valid: true}
+	}
+	if req.Locale != nil {
+		if !slices.Contains(supportedLocales, *req.Locale) {
+			return nil, rpcerror.InvalidField("locale", "locale must be one of "+strings.Join(supportedLocales, ", "))
+		}
+		params.Locale = pgtype.Text{String: *req.Locale, Valid: true}
+	}
+	var newEmail string
+	if req.Email != nil {
+		newEmail = strings.TrimSpace(*req.Email)
+		if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+			return nil, rpcerror.InvalidField("email", "invalid email format")
+		}
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		log.Printf("❌ Failed to get user: %v", err)
+		return nil, rpcerror.Internal("failed to update profile")
+	}
+
+	// 2. An email change needs the current password, so a stolen session cannot take the account over.
+	// Setting the current address again cancels a pending change.
+	if newEmail == user.Email {
+		newEmail = ""
+	}
+	if newEmail != "" {
+		if req.CurrentPassword == "" {
+			return nil, rpcerror.InvalidField("current_password", "current_password is required to change the email address")
+		}
+		if err := bcrypt.CompareHashAndPassword(user.HashedPassword, []byte(req.CurrentPassword)); err != nil {
+			log.Printf("❌ Invalid current password for user: %s", req.UserId)
+			return nil, rpcerror.New(codes.PermissionDenied, "INVALID_CURRENT_PASSWORD", "current password is incorrect")
+		}
+		registered, err := s.queries.EmailExists(ctx, newEmail)
+		if err != nil {
+			log.Printf("❌ Failed to check email: %v", err)
+			return nil, rpcerror.Internal("failed to update profile")
+		}
+		if registered {
+			return nil, errEmailAlreadyRegistered
+		}
+	}
+
+	// 3. Update the profile and replace any pending email change in one transaction
+	var updated database.User
+	var token string
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		var err error
+		updated, err = q.UpdateUserProfile(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update profile: %w", err)
+		}
+		if req.Email == nil {
+			return nil
+		}
+		if err := q.InvalidateEmailChangeTokens(ctx, userID); err != nil {
+			return fmt.Errorf("failed to invalidate email change tokens: %w", err)
+		}
+		if newEmail == "" {
+			return nil
+		}
+		token, err = issueVerificationToken(ctx, q, userID, newEmail, s.emailVerification.TokenTTL)
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Failed to update profile: %v", err)
+		return nil, rpcerror.Internal("failed to update profile")
+	}
+	if newEmail != "" {
+		log.Printf("📨 Email change requested for user: %s", req.UserId)
+		go s.sendEmailChangeVerification(newEmail, updated.Name, token)
+	}
+
+	profile, err := s.userProfile(ctx, updated)
+	if err != nil {
+		log.Printf("❌ Failed to get user profile: %v", err)
+		return nil, rpcerror.Internal("failed to update profile")
+	}
+	log.Printf("✅ Profile updated for user: %s", req.UserId)
+	return &pb.UpdateProfileResponse{
+		User:                  profile,
+		VerificationEmailSent: newEmail != "",
+	}, nil
+}
+
+// userProfile converts a user to its protobuf profile, including a pending email change
+func (s *server) userProfile(ctx context.Context, user database.User) (*pb.User, error) {
+	pendingEmail, err := s.queries.GetPendingEmailChange(ctx, user.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get pending email change: %w", err)
+	}
+	return &pb.User{
+		Id:            uuidToString(user.ID),
+		Email:         user.Email,
+		Name:          user.Name,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerifiedAt.Valid,
+		Phone:         user.Phone,
+		Locale:        user.Locale,
+		CreatedAt:     timestamppb.New(user.CreatedAt.Time),
+		PendingEmail:  pendingEmail,
+	}, nil
+}
+
+// normalizePhone removes spaces, hyphens and parentheses from a phone number and checks that
+// the rest is in E.164 format. An empty number is allowed (it removes the phone number).
+func normalizePhone(phone string) (string, error) {
+	phone = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, phone)
+	if phone != "" && !phonePattern.MatchString(phone) {
+		return "", errors.New("phone must be in international format, e.g. +819012345678")
+	}
+	return phone, nil
+}
+
+// sendEmailChangeVerification emails a verification link to a new email address;
+// failures are logged, as the RPC has already returned
+func (s *server) sendEmailChangeVerification(email, name, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	link, err := tokenLink(s.emailVerification.URL, token)
+	if err != nil {
+		log.Printf("❌ Invalid email verification URL: %v", err)
+		return
+	}
+
+	body := fmt.Sprintf(`Hello %s,
+
+We received a request to change the email address of your Smart Stay account to this address.
+Open the link below to confirm it. Until then, your current address stays in use. The link expires in %d hours.
+
+%s
+
+If you did not request this, you can ignore this email.
+`, name, int(s.emailVerification.TokenTTL.Hours()), link)
+
+	if err := s.mailer.Send(ctx, Message{
+		To:      email,
+		Subject: "Confirm your new Smart Stay email address",
+		Body:    body,
+	}); err != nil {
+		log.Printf("❌ Failed to send email change verification to %s: %v", email, err)
+	}
+}
+
+// sendEmailChangedNotice tells the previous email address that the account now uses another one;
+// failures are logged, as the RPC has already returned
+func (s *server) sendEmailChangedNotice(previousEmail, name, newEmail string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	body := fmt.Sprintf(`Hello %s,
+
+The email address of your Smart Stay account was changed to %s.
+Emails about your account and reservations will be sent to the new address from now on.
+
+If you did not make this change, please contact us right away.
+`, name, newEmail)
+
+	if err := s.mailer.Send(ctx, Message{
+		To:      previousEmail,
+		Subject: "Your Smart Stay email address was changed",
+		Body:    body,
+	}); err != nil {
+		log.Printf("❌ Failed to send email change notice to %s: %v", previousEmail, err)
+	}
+}
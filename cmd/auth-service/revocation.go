@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/jwt"
+)
+
+const (
+	// defaultRevocationCacheTTL is how long a "not revoked" answer is trusted without asking Postgres
+	defaultRevocationCacheTTL = 10 * time.Second
+	// maxRevocationCacheEntries bounds the cache; expired entries are dropped when it is full
+	maxRevocationCacheEntries = 10000
+	// revokedTokenPurgeInterval is how often expired rows are deleted from revoked_tokens
+	revokedTokenPurgeInterval = time.Hour
+)
+
+// revocationStore decides whether an access token was revoked before it expired.
+//
+// A token is revoked when its jti is in revoked_tokens (logout) or when the session it
+// was issued for is revoked (sign-out of a device, "log out everywhere", password change).
+// Answers are cached in memory. Revocations are permanent, so a revoked answer is cached
+// until the token expires; a "not revoked" answer only for the cache TTL, which bounds how
+// long a revocation made on another instance goes unnoticed.
+type revocationStore struct {
+	queries  *database.Queries
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]revocationCacheEntry
+}
+
+// revocationCacheEntry is a cached answer for a jti or a session
+type revocationCacheEntry struct {
+	revoked bool
+	until   time.Time
+}
+
+// newRevocationStore creates a revocation store
+func newRevocationStore(queries *database.Queries, cacheTTL time.Duration) *revocationStore {
+	return &revocationStore{
+		queries:  queries,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]revocationCacheEntry),
+	}
+}
+
+// loadRevocationCacheTTL reads the cache TTL of the revocation store from the environment
+func loadRevocationCacheTTL() (time.Duration, error) {
+	v := os.Getenv("REVOCATION_CACHE_TTL_SECONDS")
+	if v == "" {
+		return defaultRevocationCacheTTL, nil
+	}
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid REVOCATION_CACHE_TTL_SECONDS %q", v)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// IsRevoked reports whether a validly signed token has been revoked.
+// Tokens issued before jti and sid claims existed are only checked for what they carry.
+func (s *revocationStore) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	expiresAt := tokenExpiry(claims)
+
+	if claims.ID != "" {
+		revoked, err := s.lookup(ctx, "jti:"+claims.ID, expiresAt, func() (bool, error) {
+			return s.queries.IsTokenRevoked(ctx, claims.ID)
+		})
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	if claims.SessionID != "" {
+		sessionID, err := stringToUUID(claims.SessionID)
+		if err != nil {
+			return true, nil
+		}
+		return s.lookup(ctx, "sid:"+claims.SessionID, expiresAt, func() (bool, error) {
+			active, err := s.queries.IsSessionActive(ctx, sessionID)
+			return !active, err
+		})
+	}
+
+	return false, nil
+}
+
+// RevokeToken revokes a single access token until it expires
+func (s *revocationStore) RevokeToken(ctx context.Context, claims *jwt.Claims, reason string) error {
+	if claims.ID == "" {
+		return nil
+	}
+	userID, err := stringToUUID(claims.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user id in token: %w", err)
+	}
+
+	expiresAt := tokenExpiry(claims)
+	if err := s.queries.RevokeToken(ctx, database.RevokeTokenParams{
+		Jti:       claims.ID,
+		UserID:    userID,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		Reason:    reason,
+	}); err != nil {
+		return err
+	}
+	s.remember("jti:"+claims.ID, revocationCacheEntry{revoked: true, until: expiresAt})
+	return nil
+}
+
+// SessionRevoked records that a session was revoked, so that its access tokens are
+// rejected by this instance right away. Other instances notice within the cache TTL.
+func (s *revocationStore) SessionRevoked(sessionID string, accessTokenTTL time.Duration) {
+	s.remember("sid:"+sessionID, revocationCacheEntry{revoked: true, until: time.Now().Add(accessTokenTTL)})
+}
+
+// purgeExpired deletes revocations of tokens that have expired anyway
+func (s *revocationStore) purgeExpired(ctx context.Context) error {
+	deleted, err := s.queries.DeleteExpiredRevokedTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge revoked tokens: %w", err)
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Purged %d expired token revocation(s)", deleted)
+	}
+	return nil
+}
+
+// lookup returns the cached answer for key, or asks load and caches the result
+func (s *revocationStore) lookup(ctx context.Context, key string, expiresAt time.Time, load func() (bool, error)) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && now.Before(entry.until) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := load()
+	if err != nil {
+		return false, err
+	}
+	entry = revocationCacheEntry{revoked: revoked, until: expiresAt}
+	if !revoked {
+		entry.until = now.Add(s.cacheTTL)
+	}
+	s.remember(key, entry)
+	return revoked, nil
+}
+
+// remember caches an answer, dropping expired entries when the cache is full
+func (s *revocationStore) remember(key string, entry revocationCacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.cache) >= maxRevocationCacheEntries {
+		now := time.Now()
+		for k, e := range s.cache {
+			if !now.Before(e.until) {
+				delete(s.cache, k)
+			}
+		}
+		if len(s.cache) >= maxRevocationCacheEntries {
+			// Everything is still fresh; start over rather than grow without bound
+			s.cache = make(map[string]revocationCacheEntry)
+		}
+	}
+	s.cache[key] = entry
+}
+
+// tokenExpiry returns when a token expires
+func tokenExpiry(claims *jwt.Claims) time.Time {
+	if claims.ExpiresAt == nil {
+		return time.Now()
+	}
+	return claims.ExpiresAt.Time
+}
@@ -10,18 +10,43 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/karimiku/smart-stay-platform/cmd/auth-service/jwt"
 	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/jwt"
+	"github.com/karimiku/smart-stay-platform/internal/loginguard"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
 )
 
 // server implements the AuthServiceServer interface generated from protobuf.
 type server struct {
 	pb.UnimplementedAuthServiceServer
+	db      *pgxpool.Pool
 	queries *database.Queries
+	tokens  tokenConfig
+	// keys signs and verifies access tokens
+	keys *jwt.Keyring
+	// revocations rejects access tokens revoked before they expire
+	revocations *revocationStore
+	// passwordReset configures reset links, which are sent by mailer
+	passwordReset passwordResetConfig
+	mailer        Mailer
+	// emailVerification configures the verification links sent at signup
+	emailVerification emailVerificationConfig
+	// mfa configures TOTP; mfaSecrets encrypts the TOTP secrets at rest
+	mfa        mfaConfig
+	mfaSecrets *mfaSecretBox
+	// loginGuard limits failed logins per email address and client IP
+	loginGuard       *loginguard.Guard
+	loginGuardConfig loginGuardConfig
 }
 
 // Register creates a new user account.
@@ -31,41 +56,53 @@ func (s *server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	// Validate input
 	req.Email = strings.TrimSpace(req.Email)
 	if req.Email == "" {
-		return nil, errors.New("email is required")
+		return nil, rpcerror.InvalidField("email", "email is required")
 	}
 	if strings.TrimSpace(req.Password) == "" {
-		return nil, errors.New("password is required")
+		return nil, rpcerror.InvalidField("password", "password is required")
 	}
 	if err := validatePassword(req.Password); err != nil {
-		return nil, err
+		return nil, rpcerror.InvalidField("password", err.Error())
 	}
 	if strings.TrimSpace(req.Name) == "" {
-		return nil, errors.New("name is required")
+		return nil, rpcerror.InvalidField("name", "name is required")
 	}
 
 	// Hash password using bcrypt
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		log.Printf("❌ Failed to hash password: %v", err)
-		return nil, errors.New("failed to process password")
+		return nil, rpcerror.Internal("failed to process password")
 	}
 
-	// Create user in database
-	user, err := s.queries.CreateUser(ctx, database.CreateUserParams{
-		Email:          req.Email,
-		HashedPassword: hashedPassword,
-		Name:           strings.TrimSpace(req.Name),
-		Role:           "guest", // Default role
+	// Create user in database, with the token for the verification link
+	// The account can sign in right away, but cannot book until the address is verified
+	var user database.User
+	var token string
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		user, err = q.CreateUser(ctx, database.CreateUserParams{
+			Email:          req.Email,
+			HashedPassword: hashedPassword,
+			Name:           strings.TrimSpace(req.Name),
+			Role:           "guest", // Default role
+		})
+		if err != nil {
+			return err
+		}
+		token, err = issueVerificationToken(ctx, q, user.ID, "", s.emailVerification.TokenTTL)
+		return err
 	})
 	if err != nil {
 		log.Printf("❌ Failed to create user: %v", err)
-		// Check if it's a duplicate email error
-		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
-			return nil, errors.New("email already registered")
+		// A unique violation on users.email means the address is taken
+		if isUniqueViolation(err) {
+			return nil, errEmailAlreadyRegistered
 		}
-		return nil, errors.New("failed to create user")
+		return nil, rpcerror.Internal("failed to create user")
 	}
 
+	go s.sendVerificationEmail(user.Email, user.Name, token)
+
 	// Convert UUID to string
 	userID := uuidToString(user.ID)
 	log.Printf("✅ User registered: %s", userID)
@@ -81,41 +118,80 @@ func (s *server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 	// Validate input
 	req.Email = strings.TrimSpace(req.Email)
 	if req.Email == "" {
-		return nil, errors.New("email is required")
+		return nil, rpcerror.InvalidField("email", "email is required")
 	}
 	if strings.TrimSpace(req.Password) == "" {
-		return nil, errors.New("password is required")
+		return nil, rpcerror.InvalidField("password", "password is required")
+	}
+
+	// Refuse attempts while the email address or the client is backing off or locked out.
+	// Refused attempts are not counted and do not reach the password check. Others are counted
+	// as failures before the check, so that concurrent attempts cannot get past the limit.
+	attempt, wait, err := s.loginGuard.Begin(ctx, loginKeys(req.Email, req.IpAddress)...)
+	if err != nil {
+		log.Printf("❌ Failed to check failed logins: %v", err)
+		return nil, rpcerror.Internal("failed to check login attempts")
+	}
+	if wait > 0 {
+		log.Printf("⚠️  Login refused for email: %s (ip: %s, retry in %s)", req.Email, req.IpAddress, wait.Round(time.Second))
+		return nil, tooManyLoginAttemptsError(wait)
 	}
 
 	// Lookup user from database
 	user, err := s.queries.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		log.Printf("❌ User not found: %s", req.Email)
-		return nil, errors.New("invalid credentials")
+		s.recordLoginFailure(ctx, attempt, req.Email, req.IpAddress)
+		return nil, errInvalidCredentials
 	}
 
 	// Verify password
 	err = bcrypt.CompareHashAndPassword(user.HashedPassword, []byte(req.Password))
 	if err != nil {
 		log.Printf("❌ Invalid password for email: %s", req.Email)
-		return nil, errors.New("invalid credentials")
+		s.recordLoginFailure(ctx, attempt, req.Email, req.IpAddress)
+		return nil, errInvalidCredentials
+	}
+
+	// The password is right, so earlier failures for the address were the user's own typos.
+	// The client IP keeps its earlier failures: one valid account must not clear guesses at others.
+	if err := attempt.Release(ctx); err != nil {
+		log.Printf("⚠️  Failed to release login attempt for email %s: %v", req.Email, err)
+	}
+	if err := s.loginGuard.Reset(ctx, loginguard.EmailKey(req.Email)); err != nil {
+		log.Printf("⚠️  Failed to reset failed logins for email %s: %v", req.Email, err)
 	}
 
 	// Convert UUID to string
 	userID := uuidToString(user.ID)
 
-	// Generate JWT token
-	expiresIn := 3600 * time.Second // 1 hour
-	token, err := jwt.GenerateToken(userID, user.Role, user.Email, expiresIn)
+	// With MFA the password only gets the user to the second step
+	challenge, err := s.beginMFALogin(ctx, user)
 	if err != nil {
-		log.Printf("❌ Failed to generate JWT token: %v", err)
-		return nil, errors.New("failed to generate token")
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		log.Printf("❌ Failed to start MFA login: %v", err)
+		return nil, rpcerror.Internal("failed to generate token")
+	}
+	if challenge != nil {
+		log.Printf("🔐 MFA required for user: %s (enrollment required: %t)", userID, challenge.MfaEnrollmentRequired)
+		return challenge, nil
+	}
+
+	// Start a session and generate its JWT access token and refresh token
+	tokens, err := s.startSession(ctx, user, req.UserAgent, req.IpAddress)
+	if err != nil {
+		log.Printf("❌ Failed to start session: %v", err)
+		return nil, rpcerror.Internal("failed to generate token")
 	}
 
 	log.Printf("✅ JWT token generated for user: %s (role: %s)", userID, user.Role)
 	return &pb.LoginResponse{
-		AccessToken: token,
-		ExpiresIn:   int64(expiresIn.Seconds()),
+		AccessToken:      tokens.AccessToken,
+		ExpiresIn:        int64(s.tokens.AccessTokenTTL.Seconds()),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: int64(s.tokens.RefreshTokenTTL.Seconds()),
 	}, nil
 }
 
@@ -128,7 +204,7 @@ func (s *server) Validate(ctx context.Context, req *pb.ValidateRequest) (*pb.Val
 	log.Printf("🛡️ Validate request received for token: %s", tokenPreview)
 
 	// Validate JWT token
-	claims, err := jwt.ValidateToken(req.AccessToken)
+	claims, err := s.keys.ValidateToken(req.AccessToken)
 	if err != nil {
 		log.Printf("❌ Token validation failed: %v", err)
 		return &pb.ValidateResponse{
@@ -138,14 +214,170 @@ func (s *server) Validate(ctx context.Context, req *pb.ValidateRequest) (*pb.Val
 		}, nil
 	}
 
+	// A valid signature is not enough: the token may have been revoked (logout, password change)
+	revoked, err := s.revocations.IsRevoked(ctx, claims)
+	if err != nil {
+		// Fail closed: without the revocation store a stolen token cannot be told apart
+		log.Printf("❌ Failed to check token revocation: %v", err)
+		return &pb.ValidateResponse{Valid: false}, nil
+	}
+	if revoked {
+		log.Printf("❌ Token of user %s has been revoked", claims.UserID)
+		return &pb.ValidateResponse{Valid: false}, nil
+	}
+
 	log.Printf("✅ Token validated for user: %s, role: %s", claims.UserID, claims.Role)
 	return &pb.ValidateResponse{
-		Valid:  true,
-		UserId: claims.UserID,
-		Role:   claims.Role,
+		Valid:     true,
+		UserId:    claims.UserID,
+		Role:      claims.Role,
+		SessionId: claims.SessionID,
+	}, nil
+}
+
+// GetJWKS returns the public keys access tokens can be verified with
+func (s *server) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	keys := s.keys.Keys()
+	pbKeys := make([]*pb.JsonWebKey, 0, len(keys))
+	for _, key := range keys {
+		jwk := key.JWK()
+		pbKeys = append(pbKeys, &pb.JsonWebKey{
+			Kty: jwk.Kty,
+			Use: jwk.Use,
+			Alg: jwk.Alg,
+			Kid: jwk.Kid,
+			Crv: jwk.Crv,
+			X:   jwk.X,
+			N:   jwk.N,
+			E:   jwk.E,
+		})
+	}
+	return &pb.GetJWKSResponse{
+		Keys:   pbKeys,
+		MaxAge: int64(jwksMaxAge.Seconds()),
 	}, nil
 }
 
+// ListRevocations lists the revocations made since req.Since that can still matter.
+// A token revocation matters until the token expires; a session revocation for an access
+// token lifetime after revoked_at, since no token is issued for a revoked session.
+func (s *server) ListRevocations(ctx context.Context, req *pb.ListRevocationsRequest) (*pb.ListRevocationsResponse, error) {
+	asOf := time.Now()
+	since := asOf.Add(-s.tokens.AccessTokenTTL)
+	if req.Since != nil && req.Since.AsTime().After(since) {
+		since = req.Since.AsTime()
+	}
+	sinceTs := pgtype.Timestamptz{Time: since, Valid: true}
+
+	tokens, err := s.queries.ListTokenRevocationsSince(ctx, sinceTs)
+	if err != nil {
+		log.Printf("❌ Failed to list token revocations: %v", err)
+		return nil, rpcerror.Internal("failed to list revocations")
+	}
+	sessions, err := s.queries.ListSessionRevocationsSince(ctx, sinceTs)
+	if err != nil {
+		log.Printf("❌ Failed to list session revocations: %v", err)
+		return nil, rpcerror.Internal("failed to list revocations")
+	}
+
+	res := &pb.ListRevocationsResponse{
+		Tokens:           make([]*pb.TokenRevocation, 0, len(tokens)),
+		Sessions:         make([]*pb.SessionRevocation, 0, len(sessions)),
+		AsOf:             timestamppb.New(asOf),
+		RetentionSeconds: int64(s.tokens.AccessTokenTTL.Seconds()),
+	}
+	for _, token := range tokens {
+		res.Tokens = append(res.Tokens, &pb.TokenRevocation{
+			Jti:       token.Jti,
+			ExpiresAt: timestamppb.New(token.ExpiresAt.Time),
+		})
+	}
+	for _, session := range sessions {
+		res.Sessions = append(res.Sessions, &pb.SessionRevocation{
+			SessionId: uuidToString(session.ID),
+			RevokedAt: timestamppb.New(session.RevokedAt.Time),
+		})
+	}
+	return res, nil
+}
+
+// ChangePassword changes the password of a user and ends all of the user's other sessions
+func (s *server) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	log.Printf("🔐 ChangePassword request received for user: %s", req.UserId)
+
+	userID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+	if req.CurrentPassword == "" {
+		return nil, rpcerror.InvalidField("current_password", "current_password is required")
+	}
+	if err := validatePassword(req.NewPassword); err != nil {
+		return nil, rpcerror.InvalidField("new_password", err.Error())
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		log.Printf("❌ Failed to get user: %v", err)
+		return nil, rpcerror.Internal("failed to change password")
+	}
+	if err := bcrypt.CompareHashAndPassword(user.HashedPassword, []byte(req.CurrentPassword)); err != nil {
+		log.Printf("❌ Invalid current password for user: %s", req.UserId)
+		return nil, rpcerror.New(codes.PermissionDenied, "INVALID_CURRENT_PASSWORD", "current password is incorrect")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("❌ Failed to hash password: %v", err)
+		return nil, rpcerror.Internal("failed to process password")
+	}
+
+	// The current session stays signed in; every other device has to sign in with the new password
+	currentSessionID, _ := stringToUUID(req.SessionId)
+	var revoked int
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		if err := q.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+			ID:             user.ID,
+			HashedPassword: hashedPassword,
+		}); err != nil {
+			return fmt.Errorf("failed to update password: %w", err)
+		}
+		revoked, err = s.revokeUserSessions(ctx, q, user.ID, currentSessionID, "password changed")
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Failed to change password: %v", err)
+		return nil, rpcerror.Internal("failed to change password")
+	}
+
+	log.Printf("✅ Password changed for user: %s (%d other session(s) ended)", req.UserId, revoked)
+	return &pb.ChangePasswordResponse{RevokedCount: int32(revoked)}, nil
+}
+
+// inTx runs fn in a database transaction, committing if it returns nil
+func (s *server) inTx(ctx context.Context, fn func(q *database.Queries) error) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(s.queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// stringToUUID converts string UUID to pgtype.UUID
+func stringToUUID(s string) (pgtype.UUID, error) {
+	var uuid pgtype.UUID
+	err := uuid.Scan(s)
+	return uuid, err
+}
+
 // uuidToString converts pgtype.UUID to string format
 func uuidToString(uuid pgtype.UUID) string {
 	if !uuid.Valid {
@@ -200,4 +432,10 @@ func validatePassword(password string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation (23505)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/auth"
+)
+
+const (
+	// refreshTokenBytes is the amount of randomness in a refresh token
+	refreshTokenBytes = 32
+
+	// Limits for the client details stored with a session
+	maxUserAgentLength = 512
+	maxIPAddressLength = 45
+)
+
+// errInvalidRefreshToken is returned for unknown, expired or revoked refresh tokens
+var errInvalidRefreshToken = status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+
+// tokenConfig holds the lifetimes of issued tokens
+type tokenConfig struct {
+	AccessTokenTTL time.Duration
+	// RefreshTokenTTL is how long a session lasts without being refreshed
+	RefreshTokenTTL time.Duration
+}
+
+// defaultTokenConfig returns the token lifetimes used when nothing is configured
+func defaultTokenConfig() tokenConfig {
+	return tokenConfig{
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 30 * 24 * time.Hour,
+	}
+}
+
+// loadTokenConfig reads the token lifetimes from the environment
+func loadTokenConfig() (tokenConfig, error) {
+	config := defaultTokenConfig()
+
+	if v := os.Getenv("ACCESS_TOKEN_TTL_MINUTES"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil || minutes <= 0 {
+			return config, fmt.Errorf("invalid ACCESS_TOKEN_TTL_MINUTES %q", v)
+		}
+		config.AccessTokenTTL = time.Duration(minutes) * time.Minute
+	}
+	if v := os.Getenv("REFRESH_TOKEN_TTL_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days <= 0 {
+			return config, fmt.Errorf("invalid REFRESH_TOKEN_TTL_DAYS %q", v)
+		}
+		config.RefreshTokenTTL = time.Duration(days) * 24 * time.Hour
+	}
+
+	return config, nil
+}
+
+// sessionTokens are the tokens issued for a session by Login and Refresh
+type sessionTokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// startSession creates a session for a user and issues its first tokens
+func (s *server) startSession(ctx context.Context, user database.User, userAgent, ipAddress string) (sessionTokens, error) {
+	var session database.Session
+	var tokens sessionTokens
+	err := s.inTx(ctx, func(q *database.Queries) error {
+		var err error
+		session, err = q.CreateSession(ctx, database.CreateSessionParams{
+			UserID:    user.ID,
+			UserAgent: truncate(userAgent, maxUserAgentLength),
+			IpAddress: truncate(ipAddress, maxIPAddressLength),
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.tokens.RefreshTokenTTL), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+		tokens.RefreshToken, err = issueRefreshToken(ctx, q, session.ID)
+		return err
+	})
+	if err != nil {
+		return sessionTokens{}, err
+	}
+
+	tokens.AccessToken, err = s.keys.GenerateToken(uuidToString(user.ID), user.Role, user.Email, uuidToString(session.ID), s.tokens.AccessTokenTTL)
+	if err != nil {
+		return sessionTokens{}, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	return tokens, nil
+}
+
+// Refresh rotates a refresh token and issues a new access token for its session.
+// A refresh token that was already used means it has leaked (or the client replayed it),
+// so the whole session is revoked and both the attacker and the user must sign in again.
+func (s *server) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
+	log.Printf("🔄 Refresh request received")
+
+	if req.RefreshToken == "" {
+		return nil, rpcerror.InvalidField("refresh_token", "refresh_token is required")
+	}
+
+	var user database.User
+	var session database.Session
+	var tokens sessionTokens
+	reused := false
+	err := s.inTx(ctx, func(q *database.Queries) error {
+		token, err := q.GetRefreshTokenByHash(ctx, hashRefreshToken(req.RefreshToken))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errInvalidRefreshToken
+			}
+			return fmt.Errorf("failed to get refresh token: %w", err)
+		}
+		session, err = q.GetSession(ctx, token.SessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session.RevokedAt.Valid || !session.ExpiresAt.Time.After(time.Now()) {
+			return errInvalidRefreshToken
+		}
+
+		// Only one caller can mark a token as used; everyone else presents a used token
+		marked, err := q.MarkRefreshTokenUsed(ctx, token.ID)
+		if err != nil {
+			return fmt.Errorf("failed to mark refresh token as used: %w", err)
+		}
+		if marked == 0 {
+			reused = true
+			_, err := q.RevokeSession(ctx, database.RevokeSessionParams{
+				ID:           session.ID,
+				UserID:       session.UserID,
+				RevokeReason: pgtype.Text{String: "refresh token reuse", Valid: true},
+			})
+			return err
+		}
+
+		session, err = q.ExtendSession(ctx, database.ExtendSessionParams{
+			ID:        session.ID,
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.tokens.RefreshTokenTTL), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to extend session: %w", err)
+		}
+		user, err = q.GetUserByID(ctx, session.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		tokens.RefreshToken, err = issueRefreshToken(ctx, q, session.ID)
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, err
+		}
+		log.Printf("❌ Failed to refresh token: %v", err)
+		return nil, rpcerror.Internal("failed to refresh token")
+	}
+	if reused {
+		s.revocations.SessionRevoked(uuidToString(session.ID), s.tokens.AccessTokenTTL)
+		log.Printf("🚨 Refresh token reuse detected, revoked session %s of user %s", uuidToString(session.ID), uuidToString(session.UserID))
+		return nil, errInvalidRefreshToken
+	}
+
+	userID := uuidToString(user.ID)
+	tokens.AccessToken, err = s.keys.GenerateToken(userID, user.Role, user.Email, uuidToString(session.ID), s.tokens.AccessTokenTTL)
+	if err != nil {
+		log.Printf("❌ Failed to generate JWT token: %v", err)
+		return nil, rpcerror.Internal("failed to generate token")
+	}
+
+	log.Printf("✅ Tokens refreshed for user: %s (session: %s)", userID, uuidToString(session.ID))
+	return &pb.RefreshResponse{
+		AccessToken:      tokens.AccessToken,
+		ExpiresIn:        int64(s.tokens.AccessTokenTTL.Seconds()),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: int64(s.tokens.RefreshTokenTTL.Seconds()),
+	}, nil
+}
+
+// Logout revokes the access token and the session of a refresh token.
+// Unknown, invalid or expired tokens are ignored.
+func (s *server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	log.Printf("👋 Logout request received")
+
+	if req.AccessToken != "" {
+		if claims, err := s.keys.ValidateToken(req.AccessToken); err == nil {
+			if err := s.revocations.RevokeToken(ctx, claims, "logout"); err != nil {
+				log.Printf("❌ Failed to revoke access token: %v", err)
+				return nil, rpcerror.Internal("failed to logout")
+			}
+		}
+	}
+
+	if req.RefreshToken == "" {
+		return &pb.LogoutResponse{}, nil
+	}
+
+	token, err := s.queries.GetRefreshTokenByHash(ctx, hashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &pb.LogoutResponse{}, nil
+		}
+		log.Printf("❌ Failed to get refresh token: %v", err)
+		return nil, rpcerror.Internal("failed to logout")
+	}
+	session, err := s.queries.GetSession(ctx, token.SessionID)
+	if err != nil {
+		log.Printf("❌ Failed to get session: %v", err)
+		return nil, rpcerror.Internal("failed to logout")
+	}
+	if _, err := s.queries.RevokeSession(ctx, database.RevokeSessionParams{
+		ID:           session.ID,
+		UserID:       session.UserID,
+		RevokeReason: pgtype.Text{String: "logout", Valid: true},
+	}); err != nil {
+		log.Printf("❌ Failed to revoke session: %v", err)
+		return nil, rpcerror.Internal("failed to logout")
+	}
+	s.revocations.SessionRevoked(uuidToString(session.ID), s.tokens.AccessTokenTTL)
+
+	log.Printf("✅ Session %s ended", uuidToString(session.ID))
+	return &pb.LogoutResponse{}, nil
+}
+
+// ListSessions returns the active sessions of a user, most recently used first
+func (s *server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	userID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	sessions, err := s.queries.ListActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		log.Printf("❌ Failed to list sessions: %v", err)
+		return nil, rpcerror.Internal("failed to list sessions")
+	}
+
+	pbSessions := make([]*pb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		pbSessions = append(pbSessions, dbSessionToProto(session))
+	}
+	return &pb.ListSessionsResponse{Sessions: pbSessions}, nil
+}
+
+// RevokeSession signs one of the user's devices out, revoking its access tokens as well
+func (s *server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	userID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+	sessionID, err := stringToUUID(req.SessionId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("session_id", "invalid session_id format")
+	}
+
+	revoked, err := s.queries.RevokeSession(ctx, database.RevokeSessionParams{
+		ID:           sessionID,
+		UserID:       userID,
+		RevokeReason: pgtype.Text{String: "revoked by user", Valid: true},
+	})
+	if err != nil {
+		log.Printf("❌ Failed to revoke session: %v", err)
+		return nil, rpcerror.Internal("failed to revoke session")
+	}
+	if revoked == 0 {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+	s.revocations.SessionRevoked(req.SessionId, s.tokens.AccessTokenTTL)
+
+	log.Printf("✅ Session %s of user %s revoked", req.SessionId, req.UserId)
+	return &pb.RevokeSessionResponse{}, nil
+}
+
+// RevokeAllSessions signs the user out on every device
+func (s *server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	userID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	revoked, err := s.revokeUserSessions(ctx, s.queries, userID, pgtype.UUID{}, "logged out everywhere")
+	if err != nil {
+		log.Printf("❌ Failed to revoke sessions: %v", err)
+		return nil, rpcerror.Internal("failed to revoke sessions")
+	}
+
+	log.Printf("✅ Ended %d session(s) of user %s", revoked, req.UserId)
+	return &pb.RevokeAllSessionsResponse{RevokedCount: int32(revoked)}, nil
+}
+
+// revokeUserSessions revokes all active sessions of a user except exceptSessionID (if valid)
+// and returns how many were revoked
+func (s *server) revokeUserSessions(ctx context.Context, q *database.Queries, userID, exceptSessionID pgtype.UUID, reason string) (int, error) {
+	sessionIDs, err := q.RevokeUserSessions(ctx, database.RevokeUserSessionsParams{
+		RevokeReason:    pgtype.Text{String: reason, Valid: true},
+		UserID:          userID,
+		ExceptSessionID: exceptSessionID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range sessionIDs {
+		s.revocations.SessionRevoked(uuidToString(id), s.tokens.AccessTokenTTL)
+	}
+	return len(sessionIDs), nil
+}
+
+// issueRefreshToken creates a new refresh token for a session and returns it.
+// Only the hash is stored, so the token cannot be recovered from the database.
+func issueRefreshToken(ctx context.Context, q *database.Queries, sessionID pgtype.UUID) (string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	if _, err := q.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		SessionID: sessionID,
+		TokenHash: hashRefreshToken(token),
+	}); err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// hashRefreshToken returns the SHA-256 hash under which a refresh token is stored
+func hashRefreshToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// dbSessionToProto converts database Session to protobuf Session
+func dbSessionToProto(session database.Session) *pb.Session {
+	return &pb.Session{
+		SessionId:  uuidToString(session.ID),
+		UserAgent:  session.UserAgent,
+		IpAddress:  session.IpAddress,
+		CreatedAt:  timestamppb.New(session.CreatedAt.Time),
+		LastUsedAt: timestamppb.New(session.LastUsedAt.Time),
+		ExpiresAt:  timestamppb.New(session.ExpiresAt.Time),
+	}
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
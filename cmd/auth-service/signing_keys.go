@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/jwt"
+)
+
+const (
+	// jwksMaxAge is how long verifiers may cache the JWKS. A new key is published this long
+	// before it starts signing, so that verifiers know it before they see tokens signed with it.
+	jwksMaxAge = 5 * time.Minute
+	// keyReloadInterval is how often every instance reloads the keys from Postgres
+	keyReloadInterval = time.Minute
+	// keyRotationCheckInterval is how often the leader checks whether the signing key is due for rotation
+	keyRotationCheckInterval = time.Hour
+)
+
+// signingKeyConfig controls the algorithm and rotation of signing keys
+type signingKeyConfig struct {
+	Algorithm string
+	// RotationInterval is how long a key signs tokens before it is replaced
+	RotationInterval time.Duration
+	// Overlap is how long a replaced key still verifies tokens (at least the access token TTL)
+	Overlap time.Duration
+}
+
+// loadSigningKeyConfig reads the signing key configuration from the environment
+func loadSigningKeyConfig(accessTokenTTL time.Duration) (signingKeyConfig, error) {
+	config := signingKeyConfig{
+		Algorithm:        jwt.AlgorithmEdDSA,
+		RotationInterval: 30 * 24 * time.Hour,
+		Overlap:          24 * time.Hour,
+	}
+
+	if v := os.Getenv("JWT_SIGNING_ALGORITHM"); v != "" {
+		if v != jwt.AlgorithmEdDSA && v != jwt.AlgorithmRS256 {
+			return config, fmt.Errorf("invalid JWT_SIGNING_ALGORITHM %q (use %s or %s)", v, jwt.AlgorithmEdDSA, jwt.AlgorithmRS256)
+		}
+		config.Algorithm = v
+	}
+	if v := os.Getenv("JWT_KEY_ROTATION_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days <= 0 {
+			return config, fmt.Errorf("invalid JWT_KEY_ROTATION_DAYS %q", v)
+		}
+		config.RotationInterval = time.Duration(days) * 24 * time.Hour
+	}
+	if v := os.Getenv("JWT_KEY_OVERLAP_HOURS"); v != "" {
+		hours, err := strconv.Atoi(v)
+		if err != nil || hours <= 0 {
+			return config, fmt.Errorf("invalid JWT_KEY_OVERLAP_HOURS %q", v)
+		}
+		config.Overlap = time.Duration(hours) * time.Hour
+	}
+	if config.Overlap < accessTokenTTL {
+		return config, fmt.Errorf("JWT_KEY_OVERLAP_HOURS must cover the access token lifetime (%s)", accessTokenTTL)
+	}
+
+	return config, nil
+}
+
+// signingKeyManager stores signing keys in Postgres, rotates them and keeps the keyring up to date
+type signingKeyManager struct {
+	db      *pgxpool.Pool
+	queries *database.Queries
+	keyring *jwt.Keyring
+	config  signingKeyConfig
+	// aead encrypts private keys at rest
+	aead cipher.AEAD
+}
+
+// newSigningKeyManager creates a key manager. Private keys are encrypted with a key derived from secret.
+func newSigningKeyManager(db *pgxpool.Pool, queries *database.Queries, keyring *jwt.Keyring, config signingKeyConfig, secret string) (*signingKeyManager, error) {
+	encryptionKey := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(encryptionKey[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &signingKeyManager{db: db, queries: queries, keyring: keyring, config: config, aead: aead}, nil
+}
+
+// rotate creates a signing key if there is none, replaces the current key when it is due,
+// deletes keys past their overlap period and reloads the keyring.
+// Replaced keys keep verifying tokens for the overlap period after the new key takes over.
+func (m *signingKeyManager) rotate(ctx context.Context) error {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Instances starting at the same time must not each create a key
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('signing_keys'))"); err != nil {
+		return fmt.Errorf("failed to lock signing keys: %w", err)
+	}
+	q := m.queries.WithTx(tx)
+
+	rows, err := q.ListSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	var current *database.SigningKey
+	for i := range rows {
+		if !rows[i].NotAfter.Valid {
+			current = &rows[i]
+		}
+	}
+
+	now := time.Now()
+	var notBefore time.Time
+	switch {
+	case current == nil:
+		// First start: nobody can hold a token yet, so the key is used right away
+		notBefore = now
+	case current.NotBefore.Time.After(now):
+		// The next key is published and waiting to take over
+	case now.Sub(current.NotBefore.Time) >= m.config.RotationInterval || current.Algorithm != m.config.Algorithm:
+		notBefore = now.Add(jwksMaxAge)
+	}
+
+	if !notBefore.IsZero() {
+		key, err := m.createKey(ctx, q, notBefore)
+		if err != nil {
+			return err
+		}
+		if err := q.RetireSigningKeys(ctx, database.RetireSigningKeysParams{
+			NotAfter: pgtype.Timestamptz{Time: notBefore.Add(m.config.Overlap), Valid: true},
+			Kid:      key.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to retire signing keys: %w", err)
+		}
+		log.Printf("🔑 Created %s signing key %s (signing from %s)", key.Algorithm, key.ID, notBefore.Format(time.RFC3339))
+	}
+
+	deleted, err := q.DeleteExpiredSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired signing keys: %w", err)
+	}
+	if deleted > 0 {
+		log.Printf("🧹 Deleted %d expired signing key(s)", deleted)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return m.reload(ctx)
+}
+
+// reload loads the keys that still verify tokens into the keyring
+func (m *signingKeyManager) reload(ctx context.Context) error {
+	rows, err := m.queries.ListSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := make([]jwt.Key, 0, len(rows))
+	for _, row := range rows {
+		der, err := m.decrypt(row.Kid, row.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt signing key %s (was JWT_SECRET changed?): %w", row.Kid, err)
+		}
+		var notAfter time.Time
+		if row.NotAfter.Valid {
+			notAfter = row.NotAfter.Time
+		}
+		key, err := jwt.ParsePrivateKey(row.Kid, row.Algorithm, der, row.NotBefore.Time, notAfter)
+		if err != nil {
+			return fmt.Errorf("failed to parse signing key %s: %w", row.Kid, err)
+		}
+		keys = append(keys, key)
+	}
+	m.keyring.Replace(keys)
+	return nil
+}
+
+// watch reloads the keyring periodically so that every instance picks up rotated keys
+func (m *signingKeyManager) watch(ctx context.Context) {
+	ticker := time.NewTicker(keyReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.reload(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("❌ Failed to reload signing keys: %v", err)
+			}
+		}
+	}
+}
+
+// createKey generates a key and stores it with its private part encrypted
+func (m *signingKeyManager) createKey(ctx context.Context, q *database.Queries, notBefore time.Time) (jwt.Key, error) {
+	key, err := jwt.GenerateKey(m.config.Algorithm, notBefore)
+	if err != nil {
+		return jwt.Key{}, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	der, err := key.MarshalPrivateKey()
+	if err != nil {
+		return jwt.Key{}, fmt.Errorf("failed to encode signing key: %w", err)
+	}
+	encrypted, err := m.encrypt(key.ID, der)
+	if err != nil {
+		return jwt.Key{}, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	if _, err := q.CreateSigningKey(ctx, database.CreateSigningKeyParams{
+		Kid:        key.ID,
+		Algorithm:  key.Algorithm,
+		PrivateKey: encrypted,
+		NotBefore:  pgtype.Timestamptz{Time: notBefore, Valid: true},
+	}); err != nil {
+		return jwt.Key{}, fmt.Errorf("failed to store signing key: %w", err)
+	}
+	return key, nil
+}
+
+// encrypt seals a private key; the kid is authenticated so that rows cannot be swapped
+func (m *signingKeyManager) encrypt(kid string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, m.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return m.aead.Seal(nonce, nonce, plaintext, []byte(kid)), nil
+}
+
+// decrypt opens a private key sealed by encrypt
+func (m *signingKeyManager) decrypt(kid string, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < m.aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:m.aead.NonceSize()], ciphertext[m.aead.NonceSize():]
+	return m.aead.Open(nil, nonce, sealed, []byte(kid))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/events"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/key"
+)
+
+const (
+	// maxKeyIssueAttempts is how many times key generation is tried before giving up
+	maxKeyIssueAttempts = 3
+	// keyIssueRetryDelay is the delay before the first retry; it doubles on each attempt
+	keyIssueRetryDelay = 500 * time.Millisecond
+)
+
+// eventID returns the ID used to deduplicate a message: the outbox event ID set by the
+// publisher, or the Pub/Sub message ID for messages published without one.
+func eventID(msg *pubsub.Message) string {
+	if id := msg.Attributes["event_id"]; id != "" {
+		return id
+	}
+	return msg.ID
+}
+
+// isProcessed reports whether an event has already been handled
+func (s *server) isProcessed(ctx context.Context, eventID string) (bool, error) {
+	return s.queries.IsEventProcessed(ctx, eventID)
+}
+
+// markProcessed records that an event has been handled so that redeliveries are ignored
+func (s *server) markProcessed(ctx context.Context, eventID, eventType string) error {
+	return s.queries.MarkEventProcessed(ctx, database.MarkEventProcessedParams{
+		EventID:   eventID,
+		EventType: eventType,
+	})
+}
+
+// handleReservationCreated issues a key for a new reservation and reports the outcome
+// on the key-events topic so that the Reservation Service can finish the saga.
+// It returns false if the message should be redelivered.
+func (s *server) handleReservationCreated(ctx context.Context, event events.EventPayload) bool {
+	log.Printf("🔑 Processing ReservationCreated event for reservation: %s", event.ReservationID)
+
+	// Generate key for the reservation
+	// The key opens the door from check-in to check-out (property-local times)
+	// Note: UserID is retrieved from reservation in GenerateKey method
+	validFrom, validUntil := event.CheckInAt, event.CheckOutAt
+	if validFrom.IsZero() || validUntil.IsZero() {
+		// Events queued before check-in/check-out times existed only carry the dates
+		validFrom, validUntil = event.StartDate, event.EndDate
+	}
+	req := &pb.GenerateKeyRequest{
+		ReservationId: event.ReservationID,
+		ValidFrom:     timestamppb.New(validFrom),
+		ValidUntil:    timestamppb.New(validUntil),
+	}
+
+	var err error
+	delay := keyIssueRetryDelay
+	for attempt := 1; attempt <= maxKeyIssueAttempts; attempt++ {
+		_, err = s.GenerateKey(ctx, req)
+		if err == nil || status.Code(err) == codes.FailedPrecondition || status.Code(err) == codes.AlreadyExists {
+			break
+		}
+		log.Printf("⚠️ Key generation attempt %d/%d failed for reservation %s: %v",
+			attempt, maxKeyIssueAttempts, event.ReservationID, err)
+		if attempt < maxKeyIssueAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+
+	switch {
+	case err == nil:
+		log.Printf(" Key generated successfully for reservation: %s", event.ReservationID)
+		event.EventType = events.EventTypeKeyIssued
+	case status.Code(err) == codes.AlreadyExists:
+		// An earlier attempt or delivery stored the key; it may not have been reported yet
+		log.Printf("♻️ Key already issued for reservation: %s", event.ReservationID)
+		event.EventType = events.EventTypeKeyIssued
+	case status.Code(err) == codes.FailedPrecondition:
+		// Cancelled before the key was issued: nothing to do, don't redeliver
+		log.Printf("⚠️ Skipping key generation for reservation %s: %v", event.ReservationID, err)
+		return true
+	default:
+		log.Printf(" Failed to generate key: %v", err)
+		event.EventType = events.EventTypeKeyIssueFailed
+		event.Reason = err.Error()
+	}
+
+	// Redeliver if the outcome could not be reported; otherwise the reservation stays PENDING
+	return s.publishEvent(ctx, event) == nil
+}
+
+// handleReservationCancelled revokes the key of a cancelled reservation (compensation).
+// It returns false if the message should be redelivered.
+func (s *server) handleReservationCancelled(ctx context.Context, event events.EventPayload) bool {
+	log.Printf("🚫 Processing ReservationCancelled event for reservation: %s", event.ReservationID)
+
+	// Compensation: the guest must no longer be able to open the door
+	if _, err := s.RevokeKey(ctx, &pb.RevokeKeyRequest{
+		ReservationId: event.ReservationID,
+		Reason:        revokeReasonCancelled,
+	}); err != nil {
+		log.Printf(" Failed to revoke key: %v", err)
+		return false
+	}
+	return true
+}
+
+// publishEvent publishes an event to the key-events topic and waits for the result
+func (s *server) publishEvent(ctx context.Context, event events.EventPayload) error {
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("failed to marshal event: %v", err)
+		return err
+	}
+
+	result := s.eventsTopic.Publish(ctx, &pubsub.Message{
+		Data: eventData,
+		Attributes: map[string]string{
+			"origin": "key-service",
+		},
+	})
+	id, err := result.Get(ctx)
+	if err != nil {
+		log.Printf("❌ Failed to publish %s event: %v", event.EventType, err)
+		return err
+	}
+	log.Printf("📢 Published %s event ID: %s", event.EventType, id)
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+)
+
+// LockProvider programs access codes on smart locks.
+// Implementations wrap a vendor API (e.g., RemoteLock, NinjaLock) or the local simulator.
+type LockProvider interface {
+	// ProgramCode adds an access code to a device. Programming the same code again is not an error.
+	ProgramCode(ctx context.Context, deviceID string, code LockCode) error
+	// DeleteCode removes an access code from a device. Deleting a code that is not on the device is not an error.
+	DeleteCode(ctx context.Context, deviceID, code string) error
+	// GetDevice returns the current state of a device.
+	GetDevice(ctx context.Context, deviceID string) (LockDevice, error)
+	// ListCodes returns the access codes programmed on a device.
+	ListCodes(ctx context.Context, deviceID string) ([]LockCode, error)
+}
+
+// LockCode is an access code programmed on a lock, valid in [StartsAt, EndsAt)
+type LockCode struct {
+	Code     string    `json:"code"`
+	Name     string    `json:"name"` // Label shown in the lock's management tools (the reservation ID)
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// LockDevice is the state reported by a lock
+type LockDevice struct {
+	ID           string `json:"id"`
+	Online       bool   `json:"online"`
+	BatteryLevel int    `json:"battery_level"` // Percent
+	CodeCount    int    `json:"code_count"`
+}
+
+// Errors returned by lock providers. Other errors are treated as temporary.
+var (
+	errLockDeviceNotFound = errors.New("lock device not found")
+	errLockCodeConflict   = errors.New("code is already programmed on the lock")
+	errLockRejected       = errors.New("lock rejected the request")
+)
+
+const (
+	defaultLockSimulatorURL = "http://localhost:8090"
+
+	// lockMaxAttempts is how many times a provider call is tried before giving up
+	lockMaxAttempts = 3
+	// lockRetryDelay is the delay before the first retry; it doubles on each attempt
+	lockRetryDelay = 200 * time.Millisecond
+)
+
+// newLockProvider creates the provider selected by the LOCK_PROVIDER environment variable
+func newLockProvider() (LockProvider, error) {
+	switch provider := os.Getenv("LOCK_PROVIDER"); provider {
+	case "", "simulator":
+		baseURL := os.Getenv("LOCK_SIMULATOR_URL")
+		if baseURL == "" {
+			baseURL = defaultLockSimulatorURL
+		}
+		log.Printf("✅ Using lock simulator at %s", baseURL)
+		return newSimulatorLockProvider(baseURL), nil
+	default:
+		return nil, fmt.Errorf("unknown LOCK_PROVIDER %q", provider)
+	}
+}
+
+// isRetryableLockError reports whether repeating a failed provider call may succeed
+func isRetryableLockError(err error) bool {
+	return !errors.Is(err, errLockDeviceNotFound) &&
+		!errors.Is(err, errLockCodeConflict) &&
+		!errors.Is(err, errLockRejected)
+}
+
+// withLockRetry calls fn until it succeeds, fails permanently or runs out of attempts
+func withLockRetry(ctx context.Context, op string, fn func() error) error {
+	var err error
+	delay := lockRetryDelay
+	for attempt := 1; attempt <= lockMaxAttempts; attempt++ {
+		if err = fn(); err == nil || !isRetryableLockError(err) {
+			return err
+		}
+		log.Printf("⚠️ Lock %s attempt %d/%d failed: %v", op, attempt, lockMaxAttempts, err)
+		if attempt == lockMaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return err
+}
+
+// programKey installs a key's code on its lock and records the outcome on the key row
+func (s *server) programKey(ctx context.Context, key database.Key) (database.Key, error) {
+	err := withLockRetry(ctx, "program", func() error {
+		return s.lock.ProgramCode(ctx, key.DeviceID, LockCode{
+			Code:     key.KeyCode,
+			Name:     uuidToString(key.ReservationID),
+			StartsAt: key.ValidFrom.Time,
+			EndsAt:   key.ValidUntil.Time,
+		})
+	})
+	return s.recordLockSync(ctx, key, err)
+}
+
+// removeKeyFromLock deletes a key's code from its lock and records the outcome on the key row
+func (s *server) removeKeyFromLock(ctx context.Context, key database.Key) (database.Key, error) {
+	err := withLockRetry(ctx, "delete", func() error {
+		return s.lock.DeleteCode(ctx, key.DeviceID, key.KeyCode)
+	})
+	return s.recordLockSync(ctx, key, err)
+}
+
+// recordLockSync stores the result of a provider call on the key row and returns lockErr
+func (s *server) recordLockSync(ctx context.Context, key database.Key, lockErr error) (database.Key, error) {
+	if lockErr != nil {
+		log.Printf("❌ Lock %s was not updated for reservation %s: %v",
+			key.DeviceID, uuidToString(key.ReservationID), lockErr)
+		if err := s.queries.RecordKeyProviderError(ctx, database.RecordKeyProviderErrorParams{
+			ID:            key.ID,
+			ProviderError: pgtype.Text{String: lockErr.Error(), Valid: true},
+		}); err != nil {
+			log.Printf("⚠️ Failed to record lock error: %v", err)
+		}
+		return key, lockErr
+	}
+
+	synced, err := s.queries.MarkKeyProviderSynced(ctx, key.ID)
+	if err != nil {
+		// The lock itself is up to date; only the bookkeeping failed
+		log.Printf("⚠️ Failed to record lock sync: %v", err)
+		return key, nil
+	}
+	return synced, nil
+}
+
+// lockSyncPending reports whether the lock may not reflect the key's status yet: an ACTIVE key
+// that was never programmed, or a REVOKED/EXPIRED key whose code was not confirmed deleted.
+func lockSyncPending(key database.Key) bool {
+	switch key.Status {
+	case "ACTIVE":
+		return !key.ProviderSyncedAt.Valid
+	case "REVOKED":
+		return !key.ProviderSyncedAt.Valid || key.ProviderSyncedAt.Time.Before(key.RevokedAt.Time)
+	case "EXPIRED":
+		return !key.ProviderSyncedAt.Valid || key.ProviderSyncedAt.Time.Before(key.ExpiredAt.Time)
+	}
+	return false
+}
+
+// lockStatusError converts a provider error into a gRPC error
+func lockStatusError(err error) error {
+	if isRetryableLockError(err) {
+		return status.Errorf(codes.Unavailable, "smart lock is unavailable: %v", err)
+	}
+	return status.Errorf(codes.Internal, "smart lock rejected the request: %v", err)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// errLockCodeNotFound is returned by the simulator when deleting a code that is not programmed
+var errLockCodeNotFound = errors.New("code not found on the lock")
+
+// simulatorLockProvider talks to the local lock simulator (cmd/lock-simulator) over HTTP
+type simulatorLockProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// newSimulatorLockProvider creates a provider for the simulator listening at baseURL
+func newSimulatorLockProvider(baseURL string) *simulatorLockProvider {
+	return &simulatorLockProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// ProgramCode adds an access code to a device
+func (p *simulatorLockProvider) ProgramCode(ctx context.Context, deviceID string, code LockCode) error {
+	return p.do(ctx, http.MethodPut, codePath(deviceID, code.Code), code, nil)
+}
+
+// DeleteCode removes an access code from a device
+func (p *simulatorLockProvider) DeleteCode(ctx context.Context, deviceID, code string) error {
+	err := p.do(ctx, http.MethodDelete, codePath(deviceID, code), nil, nil)
+	if errors.Is(err, errLockCodeNotFound) {
+		return nil
+	}
+	return err
+}
+
+// GetDevice returns the current state of a device
+func (p *simulatorLockProvider) GetDevice(ctx context.Context, deviceID string) (LockDevice, error) {
+	var device LockDevice
+	err := p.do(ctx, http.MethodGet, "/devices/"+url.PathEscape(deviceID), nil, &device)
+	return device, err
+}
+
+// ListCodes returns the access codes programmed on a device
+func (p *simulatorLockProvider) ListCodes(ctx context.Context, deviceID string) ([]LockCode, error) {
+	var res struct {
+		Codes []LockCode `json:"codes"`
+	}
+	err := p.do(ctx, http.MethodGet, "/devices/"+url.PathEscape(deviceID)+"/codes", nil, &res)
+	return res.Codes, err
+}
+
+// do sends a JSON request and decodes the JSON response into out (if not nil)
+func (p *simulatorLockProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("lock simulator request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return simulatorError(res)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode lock simulator response: %w", err)
+	}
+	return nil
+}
+
+// simulatorError maps an error response of the simulator to the provider errors
+func simulatorError(res *http.Response) error {
+	var errBody struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+	json.NewDecoder(res.Body).Decode(&errBody)
+
+	switch {
+	case errBody.Code == "device_not_found":
+		return errLockDeviceNotFound
+	case errBody.Code == "code_not_found":
+		return errLockCodeNotFound
+	case errBody.Code == "code_conflict":
+		return errLockCodeConflict
+	case res.StatusCode >= 500:
+		// Offline devices and simulated outages are worth retrying
+		return fmt.Errorf("lock simulator returned %d: %s", res.StatusCode, errBody.Error)
+	default:
+		return fmt.Errorf("%w: %s", errLockRejected, errBody.Error)
+	}
+}
+
+// codePath returns the simulator path of an access code
+func codePath(deviceID, code string) string {
+	return "/devices/" + url.PathEscape(deviceID) + "/codes/" + url.PathEscape(code)
+}
@@ -13,18 +13,19 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/karimiku/smart-stay-platform/internal/database"
 	"github.com/karimiku/smart-stay-platform/internal/events"
+	"github.com/karimiku/smart-stay-platform/internal/scheduler"
 	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/key"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 const (
-	defaultTopicID        = "reservation-events"
-	defaultSubscriptionID = "key-service-subscription"
+	defaultTopicID          = "reservation-events"
+	defaultSubscriptionID   = "key-service-subscription"
+	defaultKeyEventsTopicID = "key-events" // Topic for KeyIssued / KeyIssueFailed events
 )
 
 func main() {
@@ -105,14 +106,62 @@ func main() {
 		}
 	}
 
-	// 8. Start Pub/Sub Listener (in background)
+	// 8. Create the topic for key events if not exists (Idempotent)
+	keyEventsTopicID := os.Getenv("KEY_EVENTS_TOPIC_ID")
+	if keyEventsTopicID == "" {
+		keyEventsTopicID = defaultKeyEventsTopicID
+	}
+	keyEventsTopic := pubsubClient.Topic(keyEventsTopicID)
+	topicExists, err := keyEventsTopic.Exists(ctx)
+	if err != nil {
+		log.Fatalf("Failed to check if topic exists: %v", err)
+	}
+	if !topicExists {
+		log.Printf("📢 Creating topic: %s", keyEventsTopicID)
+		keyEventsTopic, err = pubsubClient.CreateTopic(ctx, keyEventsTopicID)
+		if err != nil {
+			log.Fatalf("Failed to create topic: %v", err)
+		}
+	}
+	defer keyEventsTopic.Stop()
+	log.Printf("✅ Publishing key events to: %s", keyEventsTopicID)
+
+	// 9. Initialize the smart lock provider
+	lockProvider, err := newLockProvider()
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize lock provider: %v", err)
+	}
+	fallbackDeviceID := os.Getenv("SMART_LOCK_DEVICE_ID")
+	if fallbackDeviceID != "" {
+		log.Printf("✅ Rooms without a lock use device: %s", fallbackDeviceID)
+	}
+	pinPolicy, err := loadPINPolicy()
+	if err != nil {
+		log.Fatalf("❌ Invalid PIN policy: %v", err)
+	}
+	log.Printf("✅ Default PIN policy: %d digits, revoked codes reusable after %s", pinPolicy.Length, pinPolicy.ReuseCooldown)
+
+	// 10. Start Pub/Sub Listener (in background)
 	queries := database.New(dbPool)
 	keySvc := &server{
-		queries: queries,
+		queries:          queries,
+		eventsTopic:      keyEventsTopic,
+		lock:             lockProvider,
+		fallbackDeviceID: fallbackDeviceID,
+		pinPolicy:        pinPolicy,
 	}
+	// Receive must outlive the startup timeout above
+	receiveCtx, stopReceiving := context.WithCancel(context.Background())
+	defer stopReceiving()
+
+	// Expire keys in the background; only one instance runs the jobs at a time (Postgres advisory lock)
+	sched := scheduler.New(dbPool, "key-service")
+	sched.Every("expire-keys", keyExpiryInterval, keySvc.expireKeys)
+	go sched.Run(receiveCtx)
+
 	go func() {
 		log.Printf(" Started listening to Pub/Sub subscription: %s", subscriptionID)
-		err := sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		err := sub.Receive(receiveCtx, func(ctx context.Context, msg *pubsub.Message) {
 			log.Printf(" Received message: ID=%s", msg.ID)
 			
 			// Parse event payload
@@ -123,31 +172,38 @@ func main() {
 				return
 			}
 
+			// Skip events that were already handled (Pub/Sub delivers at least once)
+			id := eventID(msg)
+			processed, err := keySvc.isProcessed(ctx, id)
+			if err != nil {
+				log.Printf(" Failed to check processed events: %v", err)
+				msg.Nack()
+				return
+			}
+			if processed {
+				log.Printf("♻️ Event %s (%s) already processed, skipping", id, event.EventType)
+				msg.Ack()
+				return
+			}
+
 			// Process event
-			if event.EventType == events.EventTypeReservationCreated {
-				log.Printf("🔑 Processing ReservationCreated event for reservation: %s", event.ReservationID)
-				
-				// Generate key for the reservation
-				// Use reservation start/end dates
-				// Note: UserID is retrieved from reservation in GenerateKey method
-				validFrom := event.StartDate
-				validUntil := event.EndDate
-				
-				_, err := keySvc.GenerateKey(ctx, &pb.GenerateKeyRequest{
-					ReservationId: event.ReservationID,
-					ValidFrom:     timestamppb.New(validFrom),
-					ValidUntil:    timestamppb.New(validUntil),
-				})
-				
-				if err != nil {
-					log.Printf(" Failed to generate key: %v", err)
-					msg.Nack()
-					return
-				}
-				
-				log.Printf(" Key generated successfully for reservation: %s", event.ReservationID)
+			handled := true
+			switch event.EventType {
+			case events.EventTypeReservationCreated:
+				handled = keySvc.handleReservationCreated(ctx, event)
+			case events.EventTypeReservationCancelled:
+				handled = keySvc.handleReservationCancelled(ctx, event)
+			}
+			if !handled {
+				msg.Nack()
+				return
+			}
+
+			if err := keySvc.markProcessed(ctx, id, event.EventType); err != nil {
+				// Still ack: GenerateKey refuses a second key for a reservation whatever the status of
+				// the first, and revoking an already revoked key is a no-op, so a redelivery changes nothing
+				log.Printf("⚠️ Failed to record processed event %s: %v", id, err)
 			}
-			
 			msg.Ack()
 		})
 		if err != nil {
@@ -155,7 +211,7 @@ func main() {
 		}
 	}()
 
-	// 9. Start gRPC Server
+	// 11. Start gRPC Server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -172,7 +228,7 @@ func main() {
 		}
 	}()
 
-	// 10. Graceful Shutdown
+	// 12. Graceful Shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+)
+
+const (
+	minPINLength = 4
+	maxPINLength = 10
+
+	// maxPINAttempts is how many candidate codes are tried before giving up on a device
+	maxPINAttempts = 10
+)
+
+// pinPolicy is the PIN format of a smart lock device
+type pinPolicy struct {
+	Length int
+	// ReuseCooldown is how long a revoked code stays unavailable on the same device
+	ReuseCooldown time.Duration
+}
+
+// defaultPINPolicy returns the policy used when nothing is configured
+func defaultPINPolicy() pinPolicy {
+	return pinPolicy{
+		Length:        4,
+		ReuseCooldown: 30 * 24 * time.Hour,
+	}
+}
+
+// loadPINPolicy reads the default PIN policy from the environment
+func loadPINPolicy() (pinPolicy, error) {
+	policy := defaultPINPolicy()
+
+	if v := os.Getenv("PIN_LENGTH"); v != "" {
+		length, err := strconv.Atoi(v)
+		if err != nil || length < minPINLength || length > maxPINLength {
+			return policy, fmt.Errorf("invalid PIN_LENGTH %q (use %d-%d)", v, minPINLength, maxPINLength)
+		}
+		policy.Length = length
+	}
+	if v := os.Getenv("PIN_REUSE_COOLDOWN_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return policy, fmt.Errorf("invalid PIN_REUSE_COOLDOWN_DAYS %q", v)
+		}
+		policy.ReuseCooldown = time.Duration(days) * 24 * time.Hour
+	}
+
+	return policy, nil
+}
+
+// generate returns a random PIN of the policy's length that is not a trivial sequence.
+// Uses crypto/rand for cryptographically secure random number generation.
+func (p pinPolicy) generate() (string, error) {
+	for {
+		var sb strings.Builder
+		for range p.Length {
+			digit, err := rand.Int(rand.Reader, big.NewInt(10))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteByte('0' + byte(digit.Int64()))
+		}
+		if code := sb.String(); !isTrivialPIN(code) {
+			return code, nil
+		}
+	}
+}
+
+// isTrivialPIN reports whether a PIN is easy to guess: a repeated digit or pair
+// of digits (1111, 1212) or a run of consecutive digits (1234, 9876).
+func isTrivialPIN(code string) bool {
+	repeated, ascending, descending := true, true, true
+	for i := 1; i < len(code); i++ {
+		repeated = repeated && code[i] == code[i%2]
+		ascending = ascending && code[i] == code[i-1]+1
+		descending = descending && code[i] == code[i-1]-1
+	}
+	return repeated || ascending || descending
+}
+
+// pinPolicyForDevice returns the PIN policy configured for a device, or the default one
+func (s *server) pinPolicyForDevice(ctx context.Context, deviceID string) (pinPolicy, error) {
+	row, err := s.queries.GetLockPinPolicy(ctx, deviceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.pinPolicy, nil
+	}
+	if err != nil {
+		return pinPolicy{}, err
+	}
+	return pinPolicy{
+		Length:        int(row.PinLength),
+		ReuseCooldown: time.Duration(row.ReuseCooldownDays) * 24 * time.Hour,
+	}, nil
+}
+
+// pickPIN generates a PIN for a key on deviceID valid in [validFrom, validUntil).
+// Codes used by another active key on the device during an overlapping window,
+// or revoked on the device within the reuse cooldown, are rejected.
+func (s *server) pickPIN(ctx context.Context, deviceID string, validFrom, validUntil pgtype.Timestamptz) (string, error) {
+	policy, err := s.pinPolicyForDevice(ctx, deviceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get PIN policy: %w", err)
+	}
+
+	for attempt := 1; attempt <= maxPINAttempts; attempt++ {
+		code, err := policy.generate()
+		if err != nil {
+			return "", fmt.Errorf("failed to generate secure PIN: %w", err)
+		}
+		inUse, err := s.queries.IsKeyCodeInUse(ctx, database.IsKeyCodeInUseParams{
+			DeviceID:     deviceID,
+			KeyCode:      code,
+			ValidFrom:    validFrom,
+			ValidUntil:   validUntil,
+			RevokedAfter: pgtype.Timestamptz{Time: time.Now().Add(-policy.ReuseCooldown), Valid: true},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to check PIN collisions: %w", err)
+		}
+		if !inUse {
+			return code, nil
+		}
+		log.Printf("♻️ PIN collision on device %s (attempt %d/%d), generating another code", deviceID, attempt, maxPINAttempts)
+	}
+	return "", fmt.Errorf("no free PIN found for device %s after %d attempts", deviceID, maxPINAttempts)
+}
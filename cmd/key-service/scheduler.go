@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// keyExpiryInterval is how often expired keys are looked for
+	keyExpiryInterval = time.Minute
+	// lockRemovalBatchSize is the maximum number of codes removed from locks per run
+	lockRemovalBatchSize = 100
+)
+
+// expireKeys marks ACTIVE keys past valid_until EXPIRED and removes their codes from the locks.
+// Codes of revoked keys whose removal failed earlier are retried here as well.
+func (s *server) expireKeys(ctx context.Context) error {
+	expired, err := s.queries.ExpireKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to expire keys: %w", err)
+	}
+	if expired > 0 {
+		log.Printf("⌛ Marked %d key(s) EXPIRED", expired)
+	}
+
+	keys, err := s.queries.ListKeysPendingLockRemoval(ctx, lockRemovalBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list keys pending lock removal: %w", err)
+	}
+	var failed int
+	for _, key := range keys {
+		// Failures are recorded on the key row and retried on the next run
+		if _, err := s.removeKeyFromLock(ctx, key); err != nil {
+			failed++
+		}
+	}
+	if len(keys) > 0 {
+		log.Printf("🔒 Removed %d code(s) from locks (%d failed)", len(keys)-failed, failed)
+	}
+	return nil
+}
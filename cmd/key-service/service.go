@@ -2,29 +2,40 @@ package main
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
-	"math/big"
-	"os"
-	"strconv"
+	"time"
 
+	"cloud.google.com/go/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
 	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/key"
 )
 
+// errKeyAlreadyIssued is returned by GenerateKey when the reservation already has an active key
+var errKeyAlreadyIssued = rpcerror.New(codes.AlreadyExists, "KEY_ALREADY_ISSUED", "reservation already has a key")
+
 type server struct {
 	pb.UnimplementedKeyServiceServer
-	queries *database.Queries
+	queries          *database.Queries
+	eventsTopic      *pubsub.Topic // Topic for KeyIssued / KeyIssueFailed events
+	lock             LockProvider
+	fallbackDeviceID string    // Lock used for rooms without a lock_device_id
+	pinPolicy        pinPolicy // PIN format for devices without a lock_pin_policies row
 }
 
-// GenerateKey generates a time-sensitive PIN code for a specific reservation.
-// In a real implementation, this would call an external Smart Lock API (e.g., RemoteLock, NinjaLock).
+// GenerateKey generates a time-sensitive PIN code for a specific reservation
+// and programs it on the smart lock of the reserved room.
+// It is only called for ReservationCreated events; duplicate events are filtered by the consumer.
 func (s *server) GenerateKey(ctx context.Context, req *pb.GenerateKeyRequest) (*pb.GenerateKeyResponse, error) {
 	log.Printf("🔑 Generating Key for Reservation: %s (Valid: %s - %s)",
 		req.ReservationId, req.ValidFrom.AsTime(), req.ValidUntil.AsTime())
@@ -32,46 +43,64 @@ func (s *server) GenerateKey(ctx context.Context, req *pb.GenerateKeyRequest) (*
 	// Parse reservation_id and user_id from request
 	resUUID, err := stringToUUID(req.ReservationId)
 	if err != nil {
-		return nil, errors.New("invalid reservation_id format")
+		return nil, rpcerror.InvalidField("reservation_id", "invalid reservation_id format")
 	}
 
 	// Get reservation to get user_id
 	reservation, err := s.queries.GetReservation(ctx, resUUID)
 	if err != nil {
-		return nil, errors.New("reservation not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "reservation not found")
+		}
+		log.Printf("❌ Failed to get reservation: %v", err)
+		return nil, rpcerror.Internal("failed to get reservation")
 	}
 
-	// TODO: Integrate with actual Smart Lock API here.
-	
-	// Generate secure PIN code (4-digit code: 1000-9999)
-	// Using crypto/rand for cryptographically secure random number generation
-	maxPin := big.NewInt(9000) // 0-8999 range
-	randomNum, err := rand.Int(rand.Reader, maxPin)
-	if err != nil {
-		log.Printf("❌ Failed to generate secure PIN: %v", err)
-		return nil, errors.New("failed to generate secure key code")
+	// The reservation may have been cancelled before its creation event was processed
+	if reservation.Status == "CANCELLED" {
+		return nil, status.Error(codes.FailedPrecondition, "reservation is cancelled")
+	}
+
+	// A reservation is issued at most one key, whatever has become of it since: a redelivered
+	// event must not issue a new PIN after the key was revoked or expired. The existing key's
+	// code is never returned; the caller only learns that the key is issued.
+	if existing, err := s.queries.GetKeyByReservationID(ctx, resUUID); err == nil {
+		log.Printf("♻️ Key already issued for reservation: %s (status: %s)", req.ReservationId, existing.Status)
+		// A previous attempt may have stored the key without reaching the lock
+		if existing.Status == "ACTIVE" && lockSyncPending(existing) {
+			if _, err = s.programNewKey(ctx, existing); err != nil {
+				return nil, lockStatusError(err)
+			}
+		}
+		return nil, errKeyAlreadyIssued
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("❌ Failed to look up existing key: %v", err)
+		return nil, rpcerror.Internal("failed to generate key")
 	}
-	pin := 1000 + int(randomNum.Int64()) // Generates a number between 1000 and 9999
 
-	keyCode := strconv.Itoa(pin)
-	// Get device ID from environment variable, with default fallback
-	deviceID := os.Getenv("SMART_LOCK_DEVICE_ID")
-	if deviceID == "" {
-		deviceID = "smart-lock-device-001"
+	deviceID, err := s.lockDeviceForRoom(ctx, reservation.RoomID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert timestamps
-	validFrom := pgtype.Timestamp{
+	validFrom := pgtype.Timestamptz{
 		Time:  req.ValidFrom.AsTime(),
 		Valid: true,
 	}
-	validUntil := pgtype.Timestamp{
+	validUntil := pgtype.Timestamptz{
 		Time:  req.ValidUntil.AsTime(),
 		Valid: true,
 	}
 
-	// Store key in database
-	_, err = s.queries.CreateKey(ctx, database.CreateKeyParams{
+	keyCode, err := s.pickPIN(ctx, deviceID, validFrom, validUntil)
+	if err != nil {
+		log.Printf("❌ Failed to pick PIN: %v", err)
+		return nil, rpcerror.Internal("failed to generate secure key code")
+	}
+
+	// Store the key before programming the lock, so that a failed call is recorded on the row
+	key, err := s.queries.CreateKey(ctx, database.CreateKeyParams{
 		ReservationID: resUUID,
 		UserID:        reservation.UserID,
 		KeyCode:       keyCode,
@@ -80,26 +109,81 @@ func (s *server) GenerateKey(ctx context.Context, req *pb.GenerateKeyRequest) (*
 		ValidUntil:    validUntil,
 	})
 	if err != nil {
+		// A concurrent delivery of the same event created the key first
+		if isUniqueViolation(err) {
+			return nil, errKeyAlreadyIssued
+		}
 		log.Printf("❌ Failed to create key in database: %v", err)
-		return nil, errors.New("failed to create key")
+		return nil, rpcerror.Internal("failed to create key")
 	}
 
+	// The key stays ACTIVE on failure; retrying GenerateKey programs the same code again
+	if key, err = s.programNewKey(ctx, key); err != nil {
+		return nil, lockStatusError(err)
+	}
+	log.Printf("🔐 Key programmed on lock %s for reservation %s", deviceID, req.ReservationId)
+
 	return &pb.GenerateKeyResponse{
-		KeyCode:  keyCode,
-		DeviceId: deviceID,
+		KeyCode:  key.KeyCode,
+		DeviceId: key.DeviceID,
 	}, nil
 }
 
 // RevokeKey immediately invalidates a key for a given reservation.
 // This is critical for security scenarios like check-out or cancellation.
 func (s *server) RevokeKey(ctx context.Context, req *pb.RevokeKeyRequest) (*pb.RevokeKeyResponse, error) {
-	log.Printf("🚫 Revoking Key for Reservation: %s", req.ReservationId)
+	log.Printf("🚫 Revoking Key for Reservation: %s (requester: %q)", req.ReservationId, req.RequesterId)
 
-	// TODO: Call Smart Lock API to delete/disable the key.
-	// For now, we just return success (in production, we'd update the key status in DB)
+	resUUID, err := stringToUUID(req.ReservationId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("reservation_id", "invalid reservation_id format")
+	}
+	reservation, err := s.queries.GetReservation(ctx, resUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "reservation not found")
+		}
+		log.Printf("❌ Failed to get reservation: %v", err)
+		return nil, rpcerror.Internal("failed to get reservation")
+	}
+
+	// Internal revocations (e.g. compensation) have no requester; users must be allowed explicitly
+	reason := req.Reason
+	if req.RequesterId != "" {
+		defaultReason, err := s.authorizeRevoke(ctx, reservation, req.RequesterId, req.RequesterRole)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			reason = defaultReason
+		}
+	}
+	if reason == "" {
+		reason = revokeReasonSystem
+	}
+
+	// The status update is a single statement, so concurrent revocations cannot both succeed.
+	key, err := s.queries.RevokeActiveKey(ctx, database.RevokeActiveKeyParams{
+		ReservationID: resUUID,
+		RevokeReason:  pgtype.Text{String: reason, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.revokeWithoutActiveKey(ctx, resUUID, req)
+	}
+	if err != nil {
+		log.Printf("❌ Failed to revoke key: %v", err)
+		return nil, rpcerror.Internal("failed to revoke key")
+	}
+	log.Printf("🚫 Key revoked for reservation %s (reason: %s)", req.ReservationId, reason)
+
+	// The key is already unusable in our records; retrying the revocation retries the lock update
+	if key, err = s.removeKeyFromLock(ctx, key); err != nil {
+		return nil, lockStatusError(err)
+	}
 
 	return &pb.RevokeKeyResponse{
 		Success: true,
+		Key:     dbKeyToProto(key),
 	}, nil
 }
 
@@ -107,13 +191,13 @@ func (s *server) RevokeKey(ctx context.Context, req *pb.RevokeKeyRequest) (*pb.R
 func (s *server) ListKeys(ctx context.Context, req *pb.ListKeysRequest) (*pb.ListKeysResponse, error) {
 	userUUID, err := stringToUUID(req.UserId)
 	if err != nil {
-		return nil, errors.New("invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	dbKeys, err := s.queries.ListActiveKeysByUserID(ctx, userUUID)
 	if err != nil {
 		log.Printf("❌ Failed to list keys: %v", err)
-		return nil, errors.New("failed to list keys")
+		return nil, rpcerror.Internal("failed to list keys")
 	}
 
 	var keys []*pb.Key
@@ -128,6 +212,113 @@ func (s *server) ListKeys(ctx context.Context, req *pb.ListKeysRequest) (*pb.Lis
 
 // Helper functions
 
+// Revoke reasons recorded on keys
+const (
+	revokeReasonSystem     = "REVOKED_BY_SYSTEM"
+	revokeReasonCancelled  = "RESERVATION_CANCELLED"
+	revokeReasonCheckedOut = "CHECKED_OUT"
+	revokeReasonOwner      = "REVOKED_BY_OWNER"
+)
+
+// authorizeRevoke checks that the requester may revoke the key of a reservation:
+// the guest at check-out, or the owner of the property the room belongs to.
+// It returns the default revoke reason for the requester.
+func (s *server) authorizeRevoke(ctx context.Context, reservation database.Reservation, requesterID, role string) (string, error) {
+	if role == "owner" {
+		room, err := s.queries.GetRoom(ctx, reservation.RoomID)
+		if err == nil {
+			property, err := s.queries.GetProperty(ctx, room.PropertyID)
+			if err == nil && uuidToString(property.OwnerID) == requesterID {
+				return revokeReasonOwner, nil
+			}
+		}
+	}
+
+	if uuidToString(reservation.UserID) != requesterID {
+		return "", status.Error(codes.PermissionDenied, "not allowed to revoke this key")
+	}
+	// Before check-in the guest should cancel the reservation instead
+	if time.Now().Before(reservation.CheckInAt.Time) {
+		return "", status.Error(codes.FailedPrecondition, "the stay has not started yet; cancel the reservation instead")
+	}
+	return revokeReasonCheckedOut, nil
+}
+
+// revokeWithoutActiveKey handles a revocation when the reservation has no ACTIVE key.
+// Revoking twice is a no-op; compensation may also arrive before any key was issued.
+func (s *server) revokeWithoutActiveKey(ctx context.Context, resUUID pgtype.UUID, req *pb.RevokeKeyRequest) (*pb.RevokeKeyResponse, error) {
+	key, err := s.queries.GetKeyByReservationID(ctx, resUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if req.RequesterId == "" {
+			log.Printf("🚫 No key to revoke for reservation: %s", req.ReservationId)
+			return &pb.RevokeKeyResponse{Success: true}, nil
+		}
+		return nil, status.Error(codes.NotFound, "no key has been issued for this reservation")
+	}
+	if err != nil {
+		log.Printf("❌ Failed to get key: %v", err)
+		return nil, rpcerror.Internal("failed to revoke key")
+	}
+
+	if key.Status == "EXPIRED" {
+		return nil, status.Error(codes.FailedPrecondition, "key has already expired")
+	}
+	log.Printf("🚫 Key already revoked for reservation: %s", req.ReservationId)
+
+	// A previous revocation may have failed to remove the code from the lock
+	if lockSyncPending(key) {
+		if key, err = s.removeKeyFromLock(ctx, key); err != nil {
+			return nil, lockStatusError(err)
+		}
+	}
+	return &pb.RevokeKeyResponse{
+		Success: true,
+		Key:     dbKeyToProto(key),
+	}, nil
+}
+
+// programNewKey programs a key that has not reached the lock yet.
+// If the lock already holds the same code (e.g. a key created outside this service),
+// the key gets a new code and programming is retried.
+func (s *server) programNewKey(ctx context.Context, key database.Key) (database.Key, error) {
+	for attempt := 1; ; attempt++ {
+		programmed, err := s.programKey(ctx, key)
+		if !errors.Is(err, errLockCodeConflict) || attempt == maxPINAttempts {
+			return programmed, err
+		}
+		log.Printf("♻️ Lock %s already holds the code (attempt %d/%d), generating another code",
+			key.DeviceID, attempt, maxPINAttempts)
+
+		code, err := s.pickPIN(ctx, key.DeviceID, key.ValidFrom, key.ValidUntil)
+		if err != nil {
+			return key, err
+		}
+		if key, err = s.queries.UpdateKeyCode(ctx, database.UpdateKeyCodeParams{
+			ID:      key.ID,
+			KeyCode: code,
+		}); err != nil {
+			return key, fmt.Errorf("failed to update key code: %w", err)
+		}
+	}
+}
+
+// lockDeviceForRoom returns the smart lock installed in a room.
+// Rooms without a configured lock fall back to SMART_LOCK_DEVICE_ID, if set.
+func (s *server) lockDeviceForRoom(ctx context.Context, roomID int64) (string, error) {
+	room, err := s.queries.GetRoom(ctx, roomID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("❌ Failed to get room: %v", err)
+		return "", rpcerror.Internal("failed to get room")
+	}
+	if err == nil && room.LockDeviceID.String != "" {
+		return room.LockDeviceID.String, nil
+	}
+	if s.fallbackDeviceID != "" {
+		return s.fallbackDeviceID, nil
+	}
+	return "", status.Errorf(codes.Internal, "room %d has no smart lock", roomID)
+}
+
 // stringToUUID converts string UUID to pgtype.UUID
 func stringToUUID(s string) (pgtype.UUID, error) {
 	var uuid pgtype.UUID
@@ -153,6 +344,13 @@ func uuidToString(uuid pgtype.UUID) string {
 	return hex.EncodeToString(uuid.Bytes[:])
 }
 
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation (23505),
+// raised by keys_one_active_per_reservation when a reservation already has a key.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
 // dbKeyToProto converts database Key to protobuf Key
 func dbKeyToProto(dbKey database.Key) *pb.Key {
 	var validFrom, validUntil *timestamppb.Timestamp
@@ -163,11 +361,26 @@ func dbKeyToProto(dbKey database.Key) *pb.Key {
 		validUntil = timestamppb.New(dbKey.ValidUntil.Time)
 	}
 
+	keyStatus := pb.KeyStatus_ACTIVE
+	switch dbKey.Status {
+	case "REVOKED":
+		keyStatus = pb.KeyStatus_REVOKED
+	case "EXPIRED":
+		keyStatus = pb.KeyStatus_EXPIRED
+	}
+	var revokedAt *timestamppb.Timestamp
+	if dbKey.RevokedAt.Valid {
+		revokedAt = timestamppb.New(dbKey.RevokedAt.Time)
+	}
+
 	return &pb.Key{
 		KeyCode:       dbKey.KeyCode,
 		DeviceId:      dbKey.DeviceID,
 		ReservationId: uuidToString(dbKey.ReservationID),
 		ValidFrom:     validFrom,
 		ValidUntil:    validUntil,
+		Status:        keyStatus,
+		RevokedAt:     revokedAt,
+		RevokeReason:  dbKey.RevokeReason.String,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,294 @@
+// Command lock-simulator is a local stand-in for a smart-lock vendor API.
+// It keeps devices and their access codes in memory and exposes them over HTTP,
+// so that the Key Service can be developed and tested without real hardware.
+//
+// Failures can be simulated by taking a device offline
+// (PATCH /devices/{id} {"online": false}) or with LOCK_SIMULATOR_FAILURE_RATE.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// accessCode is an access code programmed on a device, valid in [StartsAt, EndsAt)
+type accessCode struct {
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// device is a simulated smart lock
+type device struct {
+	id           string
+	online       bool
+	batteryLevel int
+	codes        map[string]accessCode
+}
+
+// simulator holds all simulated devices
+type simulator struct {
+	mu          sync.Mutex
+	devices     map[string]*device
+	failureRate float64 // Share of requests answered with 503 (0.0-1.0)
+}
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8090"
+	}
+
+	sim := &simulator{devices: make(map[string]*device)}
+
+	// Devices available at startup (more can be registered with PUT /devices/{id})
+	deviceIDs := os.Getenv("LOCK_SIMULATOR_DEVICES")
+	if deviceIDs == "" {
+		deviceIDs = "smart-lock-device-001"
+	}
+	for _, id := range strings.Split(deviceIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			sim.register(id)
+			log.Printf("🔒 Registered device: %s", id)
+		}
+	}
+
+	if v := os.Getenv("LOCK_SIMULATOR_FAILURE_RATE"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			log.Fatalf("❌ Invalid LOCK_SIMULATOR_FAILURE_RATE %q (use 0.0-1.0)", v)
+		}
+		sim.failureRate = rate
+		log.Printf("⚠️ Simulating failures for %.0f%% of requests", rate*100)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /devices/{id}", sim.registerDevice)
+	mux.HandleFunc("GET /devices/{id}", sim.getDevice)
+	mux.HandleFunc("PATCH /devices/{id}", sim.updateDevice)
+	mux.HandleFunc("GET /devices/{id}/codes", sim.listCodes)
+	mux.HandleFunc("PUT /devices/{id}/codes/{code}", sim.programCode)
+	mux.HandleFunc("DELETE /devices/{id}/codes/{code}", sim.deleteCode)
+	mux.HandleFunc("POST /devices/{id}/unlock", sim.unlock)
+
+	log.Printf("🚀 Lock Simulator is running on port %s", port)
+	if err := http.ListenAndServe(":"+port, logRequests(mux)); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// register adds a device if it does not exist yet
+func (s *simulator) register(id string) *device {
+	if d, ok := s.devices[id]; ok {
+		return d
+	}
+	d := &device{id: id, online: true, batteryLevel: 100, codes: make(map[string]accessCode)}
+	s.devices[id] = d
+	return d
+}
+
+// lookup returns an online device, or writes an error response and returns nil
+func (s *simulator) lookup(w http.ResponseWriter, r *http.Request) *device {
+	if s.failureRate > 0 && rand.Float64() < s.failureRate {
+		writeError(w, http.StatusServiceUnavailable, "simulated_failure", "simulated failure")
+		return nil
+	}
+	d, ok := s.devices[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "device_not_found", "device not found")
+		return nil
+	}
+	if !d.online {
+		writeError(w, http.StatusServiceUnavailable, "device_offline", "device is offline")
+		return nil
+	}
+	return d
+}
+
+// registerDevice handles PUT /devices/{id}
+func (s *simulator) registerDevice(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.register(r.PathValue("id"))
+	writeJSON(w, http.StatusOK, d.state())
+}
+
+// getDevice handles GET /devices/{id}; offline devices are reported rather than rejected
+func (s *simulator) getDevice(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.devices[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "device_not_found", "device not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, d.state())
+}
+
+// updateDevice handles PATCH /devices/{id} to take a device offline or change its battery level
+func (s *simulator) updateDevice(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		Online       *bool `json:"online"`
+		BatteryLevel *int  `json:"battery_level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.devices[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "device_not_found", "device not found")
+		return
+	}
+	if reqBody.Online != nil {
+		d.online = *reqBody.Online
+	}
+	if reqBody.BatteryLevel != nil {
+		d.batteryLevel = *reqBody.BatteryLevel
+	}
+	writeJSON(w, http.StatusOK, d.state())
+}
+
+// listCodes handles GET /devices/{id}/codes
+func (s *simulator) listCodes(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.lookup(w, r)
+	if d == nil {
+		return
+	}
+	codes := make([]accessCode, 0, len(d.codes))
+	for _, c := range d.codes {
+		codes = append(codes, c)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].StartsAt.Before(codes[j].StartsAt)
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"codes": codes})
+}
+
+// programCode handles PUT /devices/{id}/codes/{code}.
+// Programming the same code for the same label again is idempotent.
+func (s *simulator) programCode(w http.ResponseWriter, r *http.Request) {
+	var code accessCode
+	if err := json.NewDecoder(r.Body).Decode(&code); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid request body")
+		return
+	}
+	code.Code = r.PathValue("code")
+	if code.Code == "" || !code.EndsAt.After(code.StartsAt) {
+		writeError(w, http.StatusBadRequest, "invalid_request", "code and a valid time window are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.lookup(w, r)
+	if d == nil {
+		return
+	}
+	if existing, ok := d.codes[code.Code]; ok && existing.Name != code.Name {
+		writeError(w, http.StatusConflict, "code_conflict", "code is already in use on this device")
+		return
+	}
+	d.codes[code.Code] = code
+	log.Printf("🔐 Programmed code for %q on %s (%s - %s)", code.Name, d.id, code.StartsAt, code.EndsAt)
+	writeJSON(w, http.StatusOK, code)
+}
+
+// deleteCode handles DELETE /devices/{id}/codes/{code}
+func (s *simulator) deleteCode(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.lookup(w, r)
+	if d == nil {
+		return
+	}
+	code, ok := d.codes[r.PathValue("code")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "code_not_found", "code not found")
+		return
+	}
+	delete(d.codes, code.Code)
+	log.Printf("🚫 Deleted code for %q from %s", code.Name, d.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// unlock handles POST /devices/{id}/unlock, simulating a guest typing a code on the keypad
+func (s *simulator) unlock(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.lookup(w, r)
+	if d == nil {
+		return
+	}
+	now := time.Now()
+	code, ok := d.codes[reqBody.Code]
+	if !ok || now.Before(code.StartsAt) || !now.Before(code.EndsAt) {
+		log.Printf("⛔ Rejected code on %s", d.id)
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"unlocked": false})
+		return
+	}
+	log.Printf("🔓 Unlocked %s for %q", d.id, code.Name)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"unlocked": true, "name": code.Name})
+}
+
+// state returns the JSON representation of a device
+func (d *device) state() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            d.id,
+		"online":        d.online,
+		"battery_level": d.batteryLevel,
+		"code_count":    len(d.codes),
+	}
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response; code is a machine-readable error code for clients
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": message,
+		"code":  code,
+	})
+}
+
+// logRequests logs every request
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL.Path)
+		next.ServeHTTP(w, r)
+	})
+}
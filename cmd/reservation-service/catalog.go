@@ -0,0 +1,519 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/catalog"
+)
+
+// defaultTimezone is used for properties created without an explicit time zone.
+const defaultTimezone = "Asia/Tokyo"
+
+// catalogServer implements the CatalogServiceServer interface.
+// It lives in the reservation-service because reservations reference rooms directly.
+type catalogServer struct {
+	pb.UnimplementedCatalogServiceServer
+	queries *database.Queries
+}
+
+// CreateProperty registers a new property for the calling owner
+func (s *catalogServer) CreateProperty(ctx context.Context, req *pb.CreatePropertyRequest) (*pb.CreatePropertyResponse, error) {
+	ownerUUID, err := stringToUUID(req.OwnerId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("owner_id", "invalid owner_id format")
+	}
+	if req.Timezone == "" {
+		req.Timezone = defaultTimezone
+	}
+	if err := validateProperty(req.Name, req.Address, req.Timezone); err != nil {
+		return nil, err
+	}
+	checkIn, checkOut, err := parseCheckInOutTimes(req.CheckInTime, req.CheckOutTime)
+	if err != nil {
+		return nil, err
+	}
+
+	property, err := s.queries.CreateProperty(ctx, database.CreatePropertyParams{
+		OwnerID:      ownerUUID,
+		Name:         strings.TrimSpace(req.Name),
+		Address:      strings.TrimSpace(req.Address),
+		Timezone:     req.Timezone,
+		CheckInTime:  checkIn,
+		CheckOutTime: checkOut,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to create property: %v", err)
+		return nil, rpcerror.Internal("failed to create property")
+	}
+
+	log.Printf("🏠 Property created: %d (owner: %s)", property.ID, req.OwnerId)
+	return &pb.CreatePropertyResponse{
+		Property: dbPropertyToProto(property),
+	}, nil
+}
+
+// GetProperty retrieves a property owned by the caller
+func (s *catalogServer) GetProperty(ctx context.Context, req *pb.GetPropertyRequest) (*pb.GetPropertyResponse, error) {
+	property, err := s.getOwnedProperty(ctx, req.OwnerId, req.PropertyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetPropertyResponse{
+		Property: dbPropertyToProto(property),
+	}, nil
+}
+
+// ListProperties retrieves all properties owned by the caller
+func (s *catalogServer) ListProperties(ctx context.Context, req *pb.ListPropertiesRequest) (*pb.ListPropertiesResponse, error) {
+	ownerUUID, err := stringToUUID(req.OwnerId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("owner_id", "invalid owner_id format")
+	}
+
+	dbProperties, err := s.queries.ListPropertiesByOwnerID(ctx, ownerUUID)
+	if err != nil {
+		log.Printf("❌ Failed to list properties: %v", err)
+		return nil, rpcerror.Internal("failed to list properties")
+	}
+
+	var properties []*pb.Property
+	for _, dbProperty := range dbProperties {
+		properties = append(properties, dbPropertyToProto(dbProperty))
+	}
+
+	return &pb.ListPropertiesResponse{
+		Properties: properties,
+	}, nil
+}
+
+// UpdateProperty replaces the editable fields of a property owned by the caller
+func (s *catalogServer) UpdateProperty(ctx context.Context, req *pb.UpdatePropertyRequest) (*pb.UpdatePropertyResponse, error) {
+	if _, err := s.getOwnedProperty(ctx, req.OwnerId, req.PropertyId); err != nil {
+		return nil, err
+	}
+	if req.Timezone == "" {
+		req.Timezone = defaultTimezone
+	}
+	if err := validateProperty(req.Name, req.Address, req.Timezone); err != nil {
+		return nil, err
+	}
+	checkIn, checkOut, err := parseCheckInOutTimes(req.CheckInTime, req.CheckOutTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Existing reservations keep the check-in/check-out instants they were booked with
+	property, err := s.queries.UpdateProperty(ctx, database.UpdatePropertyParams{
+		ID:           req.PropertyId,
+		Name:         strings.TrimSpace(req.Name),
+		Address:      strings.TrimSpace(req.Address),
+		Timezone:     req.Timezone,
+		IsActive:     req.IsActive,
+		CheckInTime:  checkIn,
+		CheckOutTime: checkOut,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to update property: %v", err)
+		return nil, rpcerror.Internal("failed to update property")
+	}
+
+	return &pb.UpdatePropertyResponse{
+		Property: dbPropertyToProto(property),
+	}, nil
+}
+
+// DeleteProperty deletes a property owned by the caller together with its rooms
+func (s *catalogServer) DeleteProperty(ctx context.Context, req *pb.DeletePropertyRequest) (*pb.DeletePropertyResponse, error) {
+	if _, err := s.getOwnedProperty(ctx, req.OwnerId, req.PropertyId); err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.DeleteProperty(ctx, req.PropertyId); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, status.Error(codes.FailedPrecondition, "property has reservations; deactivate it instead")
+		}
+		log.Printf("❌ Failed to delete property: %v", err)
+		return nil, rpcerror.Internal("failed to delete property")
+	}
+
+	log.Printf("🗑️ Property deleted: %d", req.PropertyId)
+	return &pb.DeletePropertyResponse{
+		Success: true,
+	}, nil
+}
+
+// CreateRoom adds a room to a property owned by the caller
+func (s *catalogServer) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.CreateRoomResponse, error) {
+	if _, err := s.getOwnedProperty(ctx, req.OwnerId, req.PropertyId); err != nil {
+		return nil, err
+	}
+	if req.MinStayNights == 0 {
+		req.MinStayNights = 1
+	}
+	if err := validateRoom(req.Name, req.Capacity, req.BaseRate, req.MinStayNights); err != nil {
+		return nil, err
+	}
+
+	room, err := s.queries.CreateRoom(ctx, database.CreateRoomParams{
+		PropertyID:    req.PropertyId,
+		Name:          strings.TrimSpace(req.Name),
+		Capacity:      req.Capacity,
+		Amenities:     normalizeAmenities(req.Amenities),
+		LockDeviceID:  optionalText(req.LockDeviceId),
+		BaseRate:      req.BaseRate,
+		MinStayNights: req.MinStayNights,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to create room: %v", err)
+		return nil, rpcerror.Internal("failed to create room")
+	}
+
+	log.Printf("🛏️ Room created: %d (property: %d)", room.ID, room.PropertyID)
+	return &pb.CreateRoomResponse{
+		Room: dbRoomToProto(room),
+	}, nil
+}
+
+// GetRoom retrieves a room that belongs to a property owned by the caller
+func (s *catalogServer) GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.GetRoomResponse, error) {
+	room, err := s.getOwnedRoom(ctx, req.OwnerId, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetRoomResponse{
+		Room: dbRoomToProto(room),
+	}, nil
+}
+
+// ListRooms retrieves all rooms of a property owned by the caller
+func (s *catalogServer) ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	if _, err := s.getOwnedProperty(ctx, req.OwnerId, req.PropertyId); err != nil {
+		return nil, err
+	}
+
+	dbRooms, err := s.queries.ListRoomsByPropertyID(ctx, req.PropertyId)
+	if err != nil {
+		log.Printf("❌ Failed to list rooms: %v", err)
+		return nil, rpcerror.Internal("failed to list rooms")
+	}
+
+	var rooms []*pb.Room
+	for _, dbRoom := range dbRooms {
+		rooms = append(rooms, dbRoomToProto(dbRoom))
+	}
+
+	return &pb.ListRoomsResponse{
+		Rooms: rooms,
+	}, nil
+}
+
+// UpdateRoom replaces the editable fields of a room owned by the caller
+func (s *catalogServer) UpdateRoom(ctx context.Context, req *pb.UpdateRoomRequest) (*pb.UpdateRoomResponse, error) {
+	if _, err := s.getOwnedRoom(ctx, req.OwnerId, req.RoomId); err != nil {
+		return nil, err
+	}
+	if req.MinStayNights == 0 {
+		req.MinStayNights = 1
+	}
+	if err := validateRoom(req.Name, req.Capacity, req.BaseRate, req.MinStayNights); err != nil {
+		return nil, err
+	}
+
+	room, err := s.queries.UpdateRoom(ctx, database.UpdateRoomParams{
+		ID:            req.RoomId,
+		Name:          strings.TrimSpace(req.Name),
+		Capacity:      req.Capacity,
+		Amenities:     normalizeAmenities(req.Amenities),
+		LockDeviceID:  optionalText(req.LockDeviceId),
+		IsActive:      req.IsActive,
+		BaseRate:      req.BaseRate,
+		MinStayNights: req.MinStayNights,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to update room: %v", err)
+		return nil, rpcerror.Internal("failed to update room")
+	}
+
+	return &pb.UpdateRoomResponse{
+		Room: dbRoomToProto(room),
+	}, nil
+}
+
+// DeleteRoom deletes a room owned by the caller
+func (s *catalogServer) DeleteRoom(ctx context.Context, req *pb.DeleteRoomRequest) (*pb.DeleteRoomResponse, error) {
+	if _, err := s.getOwnedRoom(ctx, req.OwnerId, req.RoomId); err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.DeleteRoom(ctx, req.RoomId); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, status.Error(codes.FailedPrecondition, "room has reservations; deactivate it instead")
+		}
+		log.Printf("❌ Failed to delete room: %v", err)
+		return nil, rpcerror.Internal("failed to delete room")
+	}
+
+	log.Printf("🗑️ Room deleted: %d", req.RoomId)
+	return &pb.DeleteRoomResponse{
+		Success: true,
+	}, nil
+}
+
+// CreateSeasonalRate adds a seasonal rate to a room owned by the caller
+func (s *catalogServer) CreateSeasonalRate(ctx context.Context, req *pb.CreateSeasonalRateRequest) (*pb.CreateSeasonalRateResponse, error) {
+	if _, err := s.getOwnedRoom(ctx, req.OwnerId, req.RoomId); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, rpcerror.InvalidField("name", "name is required")
+	}
+	if req.StartDate == nil || req.EndDate == nil || !req.EndDate.AsTime().After(req.StartDate.AsTime()) {
+		return nil, rpcerror.InvalidField("end_date", "end_date must be after start_date")
+	}
+	if req.NightlyRate < 0 {
+		return nil, rpcerror.InvalidField("nightly_rate", "nightly_rate must not be negative")
+	}
+
+	rate, err := s.queries.CreateSeasonalRate(ctx, database.CreateSeasonalRateParams{
+		RoomID:      req.RoomId,
+		Name:        strings.TrimSpace(req.Name),
+		StartDate:   pgtype.Date{Time: req.StartDate.AsTime(), Valid: true},
+		EndDate:     pgtype.Date{Time: req.EndDate.AsTime(), Valid: true},
+		NightlyRate: req.NightlyRate,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to create seasonal rate: %v", err)
+		return nil, rpcerror.Internal("failed to create seasonal rate")
+	}
+
+	return &pb.CreateSeasonalRateResponse{
+		SeasonalRate: dbSeasonalRateToProto(rate),
+	}, nil
+}
+
+// ListSeasonalRates retrieves all seasonal rates of a room owned by the caller
+func (s *catalogServer) ListSeasonalRates(ctx context.Context, req *pb.ListSeasonalRatesRequest) (*pb.ListSeasonalRatesResponse, error) {
+	if _, err := s.getOwnedRoom(ctx, req.OwnerId, req.RoomId); err != nil {
+		return nil, err
+	}
+
+	dbRates, err := s.queries.ListSeasonalRatesByRoomID(ctx, req.RoomId)
+	if err != nil {
+		log.Printf("❌ Failed to list seasonal rates: %v", err)
+		return nil, rpcerror.Internal("failed to list seasonal rates")
+	}
+
+	var rates []*pb.SeasonalRate
+	for _, dbRate := range dbRates {
+		rates = append(rates, dbSeasonalRateToProto(dbRate))
+	}
+
+	return &pb.ListSeasonalRatesResponse{
+		SeasonalRates: rates,
+	}, nil
+}
+
+// DeleteSeasonalRate deletes a seasonal rate of a room owned by the caller
+func (s *catalogServer) DeleteSeasonalRate(ctx context.Context, req *pb.DeleteSeasonalRateRequest) (*pb.DeleteSeasonalRateResponse, error) {
+	rate, err := s.queries.GetSeasonalRate(ctx, req.SeasonalRateId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "seasonal rate not found")
+		}
+		log.Printf("❌ Failed to get seasonal rate: %v", err)
+		return nil, rpcerror.Internal("failed to get seasonal rate")
+	}
+	if _, err := s.getOwnedRoom(ctx, req.OwnerId, rate.RoomID); err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.DeleteSeasonalRate(ctx, req.SeasonalRateId); err != nil {
+		log.Printf("❌ Failed to delete seasonal rate: %v", err)
+		return nil, rpcerror.Internal("failed to delete seasonal rate")
+	}
+
+	return &pb.DeleteSeasonalRateResponse{
+		Success: true,
+	}, nil
+}
+
+// getOwnedProperty loads a property and verifies that it belongs to ownerID
+func (s *catalogServer) getOwnedProperty(ctx context.Context, ownerID string, propertyID int64) (database.Property, error) {
+	ownerUUID, err := stringToUUID(ownerID)
+	if err != nil {
+		return database.Property{}, rpcerror.InvalidField("owner_id", "invalid owner_id format")
+	}
+
+	property, err := s.queries.GetProperty(ctx, propertyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.Property{}, status.Error(codes.NotFound, "property not found")
+		}
+		log.Printf("❌ Failed to get property: %v", err)
+		return database.Property{}, rpcerror.Internal("failed to get property")
+	}
+	if property.OwnerID != ownerUUID {
+		return database.Property{}, status.Error(codes.PermissionDenied, "property is owned by another user")
+	}
+	return property, nil
+}
+
+// getOwnedRoom loads a room and verifies that its property belongs to ownerID
+func (s *catalogServer) getOwnedRoom(ctx context.Context, ownerID string, roomID int64) (database.Room, error) {
+	room, err := s.queries.GetRoom(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.Room{}, status.Error(codes.NotFound, "room not found")
+		}
+		log.Printf("❌ Failed to get room: %v", err)
+		return database.Room{}, rpcerror.Internal("failed to get room")
+	}
+	if _, err := s.getOwnedProperty(ctx, ownerID, room.PropertyID); err != nil {
+		return database.Room{}, err
+	}
+	return room, nil
+}
+
+// validateProperty validates the editable fields of a property
+func validateProperty(name, address, timezone string) error {
+	if strings.TrimSpace(name) == "" {
+		return rpcerror.InvalidField("name", "name is required")
+	}
+	if strings.TrimSpace(address) == "" {
+		return rpcerror.InvalidField("address", "address is required")
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return rpcerror.InvalidField("timezone", "invalid timezone (use an IANA name such as Asia/Tokyo)")
+	}
+	return nil
+}
+
+// parseCheckInOutTimes parses the "HH:MM" check-in and check-out times of a property,
+// applying the defaults to empty values
+func parseCheckInOutTimes(checkIn, checkOut string) (pgtype.Time, pgtype.Time, error) {
+	in, err := parseTimeOfDay(checkIn, defaultCheckInTime)
+	if err != nil {
+		return pgtype.Time{}, pgtype.Time{}, rpcerror.InvalidField("check_in_time", "invalid check_in_time (use HH:MM)")
+	}
+	out, err := parseTimeOfDay(checkOut, defaultCheckOutTime)
+	if err != nil {
+		return pgtype.Time{}, pgtype.Time{}, rpcerror.InvalidField("check_out_time", "invalid check_out_time (use HH:MM)")
+	}
+	return in, out, nil
+}
+
+// validateRoom validates the editable fields of a room
+func validateRoom(name string, capacity int32, baseRate int64, minStayNights int32) error {
+	if strings.TrimSpace(name) == "" {
+		return rpcerror.InvalidField("name", "name is required")
+	}
+	if capacity < 1 {
+		return rpcerror.InvalidField("capacity", "capacity must be at least 1")
+	}
+	if baseRate < 0 {
+		return rpcerror.InvalidField("base_rate", "base_rate must not be negative")
+	}
+	if minStayNights < 1 {
+		return rpcerror.InvalidField("min_stay_nights", "min_stay_nights must be at least 1")
+	}
+	return nil
+}
+
+// normalizeAmenities trims amenities and drops empty entries
+func normalizeAmenities(amenities []string) []string {
+	normalized := []string{}
+	for _, amenity := range amenities {
+		if amenity = strings.TrimSpace(amenity); amenity != "" {
+			normalized = append(normalized, amenity)
+		}
+	}
+	return normalized
+}
+
+// optionalText converts an empty string to SQL NULL
+func optionalText(s string) pgtype.Text {
+	s = strings.TrimSpace(s)
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign_key_violation (23503)
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+// dbPropertyToProto converts database Property to protobuf Property
+func dbPropertyToProto(dbProperty database.Property) *pb.Property {
+	var createdAt *timestamppb.Timestamp
+	if dbProperty.CreatedAt.Valid {
+		createdAt = timestamppb.New(dbProperty.CreatedAt.Time)
+	}
+
+	return &pb.Property{
+		Id:           dbProperty.ID,
+		OwnerId:      uuidToString(dbProperty.OwnerID),
+		Name:         dbProperty.Name,
+		Address:      dbProperty.Address,
+		Timezone:     dbProperty.Timezone,
+		IsActive:     dbProperty.IsActive,
+		CreatedAt:    createdAt,
+		CheckInTime:  formatTimeOfDay(dbProperty.CheckInTime),
+		CheckOutTime: formatTimeOfDay(dbProperty.CheckOutTime),
+	}
+}
+
+// dbRoomToProto converts database Room to protobuf Room
+func dbRoomToProto(dbRoom database.Room) *pb.Room {
+	var createdAt *timestamppb.Timestamp
+	if dbRoom.CreatedAt.Valid {
+		createdAt = timestamppb.New(dbRoom.CreatedAt.Time)
+	}
+
+	return &pb.Room{
+		Id:            dbRoom.ID,
+		PropertyId:    dbRoom.PropertyID,
+		Name:          dbRoom.Name,
+		Capacity:      dbRoom.Capacity,
+		Amenities:     dbRoom.Amenities,
+		LockDeviceId:  dbRoom.LockDeviceID.String,
+		IsActive:      dbRoom.IsActive,
+		CreatedAt:     createdAt,
+		BaseRate:      dbRoom.BaseRate,
+		MinStayNights: dbRoom.MinStayNights,
+	}
+}
+
+// dbSeasonalRateToProto converts database SeasonalRate to protobuf SeasonalRate
+func dbSeasonalRateToProto(dbRate database.SeasonalRate) *pb.SeasonalRate {
+	var startDate, endDate *timestamppb.Timestamp
+	if dbRate.StartDate.Valid {
+		startDate = timestamppb.New(dbRate.StartDate.Time)
+	}
+	if dbRate.EndDate.Valid {
+		endDate = timestamppb.New(dbRate.EndDate.Time)
+	}
+
+	return &pb.SeasonalRate{
+		Id:          dbRate.ID,
+		RoomId:      dbRate.RoomID,
+		Name:        dbRate.Name,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		NightlyRate: dbRate.NightlyRate,
+	}
+}
@@ -7,20 +7,27 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Embed the time zone database (alpine images ship without it)
 
 	"cloud.google.com/go/pubsub"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
-	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
 	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/pricing"
+	"github.com/karimiku/smart-stay-platform/internal/scheduler"
+	pbCatalog "github.com/karimiku/smart-stay-platform/pkg/genproto/catalog"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
 )
 
 const (
-	defaultTopicID = "reservation-events" // デフォルトのトピック名
+	defaultTopicID                 = "reservation-events"               // デフォルトのトピック名
+	defaultKeyEventsTopicID        = "key-events"                       // Key Service の結果イベント
+	defaultKeyEventsSubscriptionID = "reservation-service-subscription" // 鍵イベントの購読名
 )
 
 func main() {
@@ -91,26 +98,103 @@ func main() {
 		}
 	}
 
-	// 7. Start TCP Listener
+	// 7. Subscribe to key events (KeyIssued / KeyIssueFailed) to complete the saga
+	keyEventsTopicID := os.Getenv("KEY_EVENTS_TOPIC_ID")
+	if keyEventsTopicID == "" {
+		keyEventsTopicID = defaultKeyEventsTopicID
+	}
+	subscriptionID := os.Getenv("KEY_EVENTS_SUBSCRIPTION_ID")
+	if subscriptionID == "" {
+		subscriptionID = defaultKeyEventsSubscriptionID
+	}
+	keyEventsTopic := pubsubClient.Topic(keyEventsTopicID)
+	exists, err = keyEventsTopic.Exists(ctx)
+	if err != nil {
+		log.Fatalf("Failed to check if topic exists: %v", err)
+	}
+	if !exists {
+		log.Printf("📢 Creating topic: %s", keyEventsTopicID)
+		keyEventsTopic, err = pubsubClient.CreateTopic(ctx, keyEventsTopicID)
+		if err != nil {
+			log.Fatalf("Failed to create topic: %v", err)
+		}
+	}
+	sub := pubsubClient.Subscription(subscriptionID)
+	exists, err = sub.Exists(ctx)
+	if err != nil {
+		log.Fatalf("Failed to check if subscription exists: %v", err)
+	}
+	if !exists {
+		log.Printf("Creating subscription: %s", subscriptionID)
+		sub, err = pubsubClient.CreateSubscription(ctx, subscriptionID, pubsub.SubscriptionConfig{
+			Topic: keyEventsTopic,
+		})
+		if err != nil {
+			log.Fatalf("Failed to create subscription: %v", err)
+		}
+	}
+	log.Printf("✅ Using Pub/Sub Subscription: %s (topic: %s)", subscriptionID, keyEventsTopicID)
+
+	// 8. Load pricing rules (surcharges, length-of-stay discounts and cancellation fees)
+	policy, err := loadPricingPolicy()
+	if err != nil {
+		log.Fatalf("❌ Invalid pricing configuration: %v", err)
+	}
+	log.Printf("✅ Pricing: weekend +%d%%, holiday eve +%d%%, stay discounts %v",
+		policy.WeekendSurchargePercent, policy.HolidaySurchargePercent, policy.StayDiscounts)
+
+	cancellation, err := loadCancellationPolicy()
+	if err != nil {
+		log.Fatalf("❌ Invalid cancellation policy: %v", err)
+	}
+	log.Printf("✅ Cancellation: free until %s before check-in, %d%% fee after",
+		cancellation.Cutoff, cancellation.FeePercent)
+
+	// 9. Start TCP Listener
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	// 8. Create gRPC Server & Register Service
+	// 10. Create gRPC Server & Register Service
 	grpcServer := grpc.NewServer()
-	
-	// Pass the topic and database queries to the service implementation
+
+	// Pass the database and the outbox relay to the service implementation
 	queries := database.New(dbPool)
+	relay := newOutboxRelay(dbPool, queries, topic)
 	svc := &server{
-		pubsubTopic: topic,
-		queries:     queries,
+		db:           dbPool,
+		queries:      queries,
+		outbox:       relay,
+		policy:       policy,
+		cancellation: cancellation,
 	}
 	pb.RegisterReservationServiceServer(grpcServer, svc)
 
+	// The room catalog is served from the same process because reservations reference rooms
+	pbCatalog.RegisterCatalogServiceServer(grpcServer, &catalogServer{
+		queries: queries,
+	})
+
 	reflection.Register(grpcServer)
 
-	// 9. Start Server
+	// Start Pub/Sub Listener, Outbox Relay and Scheduler (in background); all must outlive the startup timeout above
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go relay.Run(workerCtx)
+
+	// Only one instance runs the scheduled jobs at a time (Postgres advisory lock)
+	sched := scheduler.New(dbPool, "reservation-service")
+	sched.Every("complete-stays", stayCompletionInterval, svc.completeStays)
+	go sched.Run(workerCtx)
+	go func() {
+		log.Printf("📥 Started listening to Pub/Sub subscription: %s", subscriptionID)
+		if err := sub.Receive(workerCtx, svc.handleKeyEvent); err != nil {
+			log.Fatalf("Failed to receive messages: %v", err)
+		}
+	}()
+
+	// 11. Start Server
 	go func() {
 		log.Printf("📝 Reservation Service is running on port %s", port)
 		if err := grpcServer.Serve(lis); err != nil {
@@ -118,11 +202,62 @@ func main() {
 		}
 	}()
 
-	// 10. Graceful Shutdown
+	// 12. Graceful Shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("🛑 Shutting down Reservation Service...")
 	grpcServer.GracefulStop()
 	log.Println("✅ Reservation Service stopped")
-}
\ No newline at end of file
+}
+
+// loadPricingPolicy builds the pricing policy from the environment, falling back to the defaults
+func loadPricingPolicy() (pricing.Policy, error) {
+	policy := pricing.DefaultPolicy()
+
+	if v := os.Getenv("PRICING_WEEKEND_SURCHARGE_PERCENT"); v != "" {
+		pct, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || pct < 0 {
+			return policy, fmt.Errorf("invalid PRICING_WEEKEND_SURCHARGE_PERCENT %q", v)
+		}
+		policy.WeekendSurchargePercent = pct
+	}
+	if v := os.Getenv("PRICING_HOLIDAY_SURCHARGE_PERCENT"); v != "" {
+		pct, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || pct < 0 {
+			return policy, fmt.Errorf("invalid PRICING_HOLIDAY_SURCHARGE_PERCENT %q", v)
+		}
+		policy.HolidaySurchargePercent = pct
+	}
+	if v, ok := os.LookupEnv("PRICING_STAY_DISCOUNTS"); ok {
+		discounts, err := pricing.ParseStayDiscounts(v)
+		if err != nil {
+			return policy, fmt.Errorf("invalid PRICING_STAY_DISCOUNTS: %w", err)
+		}
+		policy.StayDiscounts = discounts
+	}
+
+	return policy, nil
+}
+
+// loadCancellationPolicy builds the cancellation policy from the environment, falling back to the defaults
+func loadCancellationPolicy() (pricing.CancellationPolicy, error) {
+	policy := pricing.DefaultCancellationPolicy()
+
+	if v := os.Getenv("CANCELLATION_CUTOFF_HOURS"); v != "" {
+		hours, err := strconv.Atoi(v)
+		if err != nil || hours < 0 {
+			return policy, fmt.Errorf("invalid CANCELLATION_CUTOFF_HOURS %q", v)
+		}
+		policy.Cutoff = time.Duration(hours) * time.Hour
+	}
+	if v := os.Getenv("CANCELLATION_FEE_PERCENT"); v != "" {
+		pct, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return policy, fmt.Errorf("invalid CANCELLATION_FEE_PERCENT %q", v)
+		}
+		policy.FeePercent = pct
+	}
+
+	return policy, nil
+}
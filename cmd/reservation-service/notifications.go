@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
+)
+
+// Notification types
+const (
+	notificationReservationConfirmed = "RESERVATION_CONFIRMED"
+	notificationReservationCancelled = "RESERVATION_CANCELLED"
+	notificationStayCompleted        = "STAY_COMPLETED"
+)
+
+const (
+	// defaultNotificationLimit is the number of notifications listed when no limit is given
+	defaultNotificationLimit = 20
+	// maxNotificationLimit is the maximum number of notifications listed at once
+	maxNotificationLimit = 100
+)
+
+// ListNotifications lists the notifications of a user, newest first
+func (s *server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	userUUID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+	limit := req.Limit
+	if limit < 0 || limit > maxNotificationLimit {
+		return nil, rpcerror.InvalidField("limit", fmt.Sprintf("limit must be between 1 and %d", maxNotificationLimit))
+	}
+	if limit == 0 {
+		limit = defaultNotificationLimit
+	}
+
+	dbNotifications, err := s.queries.ListNotificationsByUserID(ctx, database.ListNotificationsByUserIDParams{
+		UserID:     userUUID,
+		UnreadOnly: req.UnreadOnly,
+		MaxResults: limit,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to list notifications: %v", err)
+		return nil, rpcerror.Internal("failed to list notifications")
+	}
+	unread, err := s.queries.CountUnreadNotifications(ctx, userUUID)
+	if err != nil {
+		log.Printf("❌ Failed to count unread notifications: %v", err)
+		return nil, rpcerror.Internal("failed to list notifications")
+	}
+
+	notifications := make([]*pb.Notification, 0, len(dbNotifications))
+	for _, n := range dbNotifications {
+		notifications = append(notifications, dbNotificationToProto(n))
+	}
+	return &pb.ListNotificationsResponse{
+		Notifications: notifications,
+		UnreadCount:   unread,
+	}, nil
+}
+
+// MarkNotificationRead marks a notification owned by the caller as read
+func (s *server) MarkNotificationRead(ctx context.Context, req *pb.MarkNotificationReadRequest) (*pb.MarkNotificationReadResponse, error) {
+	notificationUUID, err := stringToUUID(req.NotificationId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("notification_id", "invalid notification_id format")
+	}
+	userUUID, err := stringToUUID(req.UserId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+
+	// Notifications of other users are reported as missing, not revealing that they exist
+	notification, err := s.queries.MarkNotificationRead(ctx, database.MarkNotificationReadParams{
+		ID:     notificationUUID,
+		UserID: userUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "notification not found")
+		}
+		log.Printf("❌ Failed to mark notification as read: %v", err)
+		return nil, rpcerror.Internal("failed to mark notification as read")
+	}
+
+	return &pb.MarkNotificationReadResponse{
+		Notification: dbNotificationToProto(notification),
+	}, nil
+}
+
+// notifyReservation records a notification for the guest of a reservation.
+// It is called with the transaction that changes the reservation, so the two are never out of step.
+func notifyReservation(ctx context.Context, q *database.Queries, notificationType string, r database.Reservation, message string) error {
+	_, err := q.CreateNotification(ctx, database.CreateNotificationParams{
+		UserID:        r.UserID,
+		ReservationID: r.ID,
+		Type:          notificationType,
+		Message:       message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// stayLabel describes the dates of a reservation in notification messages.
+// The booked dates are the source of truth; check_in_at / check_out_at are derived from them.
+func stayLabel(r database.Reservation) string {
+	return fmt.Sprintf("%s to %s", r.StartDate.Time.Format("2006-01-02"), r.EndDate.Time.Format("2006-01-02"))
+}
+
+// dbNotificationToProto converts a database notification to its protobuf representation
+func dbNotificationToProto(n database.Notification) *pb.Notification {
+	var readAt *timestamppb.Timestamp
+	if n.ReadAt.Valid {
+		readAt = timestamppb.New(n.ReadAt.Time)
+	}
+	return &pb.Notification{
+		Id:            uuidToString(n.ID),
+		ReservationId: uuidToString(n.ReservationID),
+		Type:          n.Type,
+		Message:       n.Message,
+		CreatedAt:     timestamppb.New(n.CreatedAt.Time),
+		ReadAt:        readAt,
+	}
+}
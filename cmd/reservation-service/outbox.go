@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/events"
+)
+
+const (
+	// outboxPollInterval is how often the relay looks for pending events
+	outboxPollInterval = time.Second
+	// outboxBatchSize is the maximum number of events published per poll
+	outboxBatchSize = 100
+	// outboxMaxBackoff caps the delay between retries of a failing event
+	outboxMaxBackoff = 5 * time.Minute
+)
+
+// enqueueEvent writes an event to the outbox.
+// Pass transaction-bound queries so that the event is committed together with the change it describes.
+func enqueueEvent(ctx context.Context, q *database.Queries, event events.EventPayload) error {
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	if _, err := q.CreateOutboxEvent(ctx, database.CreateOutboxEventParams{
+		EventType: event.EventType,
+		Payload:   eventData,
+	}); err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
+
+// outboxRelay publishes outbox events to Pub/Sub and marks them sent.
+// Rows are locked with FOR UPDATE SKIP LOCKED, so several instances can relay concurrently.
+type outboxRelay struct {
+	db      *pgxpool.Pool
+	queries *database.Queries
+	topic   *pubsub.Topic
+	wake    chan struct{} // Signalled after an event is enqueued to publish without waiting for the next poll
+}
+
+// newOutboxRelay creates a new outbox relay
+func newOutboxRelay(db *pgxpool.Pool, queries *database.Queries, topic *pubsub.Topic) *outboxRelay {
+	return &outboxRelay{
+		db:      db,
+		queries: queries,
+		topic:   topic,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes the relay up; it never blocks
+func (r *outboxRelay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run relays pending events until ctx is cancelled
+func (r *outboxRelay) Run(ctx context.Context) {
+	log.Printf("📤 Outbox relay started (poll interval: %s)", outboxPollInterval)
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("📤 Outbox relay stopped")
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+
+		// Keep going while full batches come back so that a backlog drains quickly
+		for {
+			n, err := r.relayBatch(ctx)
+			if err != nil {
+				log.Printf("❌ Outbox relay failed: %v", err)
+				break
+			}
+			if n < outboxBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// relayBatch publishes one batch of pending events and returns how many were processed
+func (r *outboxRelay) relayBatch(ctx context.Context) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	pending, err := q.ListPendingOutboxEvents(ctx, outboxBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending events: %w", err)
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	// Publish all events first, then wait for the results (the client batches them)
+	results := make([]*pubsub.PublishResult, len(pending))
+	for i, event := range pending {
+		results[i] = r.topic.Publish(ctx, &pubsub.Message{
+			Data: event.Payload,
+			Attributes: map[string]string{
+				"origin":     "reservation-service",
+				"event_id":   uuidToString(event.ID),
+				"event_type": event.EventType,
+			},
+		})
+	}
+
+	for i, event := range pending {
+		eventID := uuidToString(event.ID)
+		msgID, err := results[i].Get(ctx)
+		if err != nil {
+			backoff := outboxBackoff(event.Attempts)
+			log.Printf("❌ Failed to publish %s event %s (attempt %d, retry in %s): %v",
+				event.EventType, eventID, event.Attempts+1, backoff, err)
+			if err := q.MarkOutboxEventFailed(ctx, database.MarkOutboxEventFailedParams{
+				ID:             event.ID,
+				LastError:      pgtype.Text{String: err.Error(), Valid: true},
+				BackoffSeconds: int32(backoff / time.Second),
+			}); err != nil {
+				return 0, fmt.Errorf("failed to record publish failure: %w", err)
+			}
+			continue
+		}
+
+		if err := q.MarkOutboxEventSent(ctx, event.ID); err != nil {
+			return 0, fmt.Errorf("failed to mark event sent: %w", err)
+		}
+		log.Printf("📢 Published %s event %s (message ID: %s)", event.EventType, eventID, msgID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(pending), nil
+}
+
+// outboxBackoff returns the retry delay after the given number of failed attempts (exponential, capped)
+func outboxBackoff(attempts int32) time.Duration {
+	backoff := time.Second
+	for i := int32(0); i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
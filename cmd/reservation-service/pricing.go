@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/pricing"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
+	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
+)
+
+// QuotePrice returns the itemized price of a stay without booking it
+func (s *server) QuotePrice(ctx context.Context, req *pb.QuotePriceRequest) (*pb.QuotePriceResponse, error) {
+	if req.StartDate == nil || req.EndDate == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_date and end_date are required")
+	}
+
+	room, _, err := s.getBookableRoom(ctx, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.quoteRoom(ctx, room, req.StartDate.AsTime(), req.EndDate.AsTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.QuotePriceResponse{
+		RoomId:     room.ID,
+		Nights:     nightsToProto(quote.Nights),
+		LineItems:  lineItemsToProto(quote.LineItems),
+		Subtotal:   quote.Subtotal,
+		Discount:   quote.Discount,
+		TotalPrice: quote.Total,
+	}, nil
+}
+
+// quoteRoom prices a stay in a single room, loading the seasonal rates that overlap it
+func (s *server) quoteRoom(ctx context.Context, room database.Room, start, end time.Time) (pricing.Quote, error) {
+	seasons, err := s.seasonalRatesInRange(ctx, start, end)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+
+	quote, err := s.policy.Quote(roomRates(room, seasons[room.ID]), start, end)
+	if err != nil {
+		return pricing.Quote{}, pricingError(err)
+	}
+	return quote, nil
+}
+
+// seasonalRatesInRange loads the seasonal rates overlapping a stay, grouped by room
+func (s *server) seasonalRatesInRange(ctx context.Context, start, end time.Time) (map[int64][]pricing.SeasonalRate, error) {
+	dbRates, err := s.queries.ListSeasonalRatesInRange(ctx, database.ListSeasonalRatesInRangeParams{
+		StartDate: pgtype.Date{Time: start, Valid: true},
+		EndDate:   pgtype.Date{Time: end, Valid: true},
+	})
+	if err != nil {
+		log.Printf("❌ Failed to list seasonal rates: %v", err)
+		return nil, rpcerror.Internal("failed to load seasonal rates")
+	}
+
+	seasons := make(map[int64][]pricing.SeasonalRate)
+	for _, rate := range dbRates {
+		seasons[rate.RoomID] = append(seasons[rate.RoomID], pricing.SeasonalRate{
+			Name:        rate.Name,
+			Start:       rate.StartDate.Time,
+			End:         rate.EndDate.Time,
+			NightlyRate: rate.NightlyRate,
+		})
+	}
+	return seasons, nil
+}
+
+// roomRates builds the pricing configuration of a room
+func roomRates(room database.Room, seasons []pricing.SeasonalRate) pricing.RoomRates {
+	return pricing.RoomRates{
+		BaseRate:      room.BaseRate,
+		MinStayNights: int(room.MinStayNights),
+		Seasons:       seasons,
+	}
+}
+
+// pricingError maps pricing engine errors to gRPC status errors
+func pricingError(err error) error {
+	switch {
+	case errors.Is(err, pricing.ErrInvalidStay):
+		return rpcerror.InvalidField("end_date", "end_date must be after start_date")
+	case errors.Is(err, pricing.ErrMinimumStay):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+// nightsToProto converts priced nights to protobuf NightlyPrice
+func nightsToProto(nights []pricing.Night) []*pb.NightlyPrice {
+	var result []*pb.NightlyPrice
+	for _, night := range nights {
+		result = append(result, &pb.NightlyPrice{
+			Date:  timestamppb.New(night.Date),
+			Price: night.Price,
+		})
+	}
+	return result
+}
+
+// lineItemsToProto converts a price breakdown to protobuf PriceLineItem
+func lineItemsToProto(items []pricing.LineItem) []*pb.PriceLineItem {
+	var result []*pb.PriceLineItem
+	for _, item := range items {
+		lineItem := &pb.PriceLineItem{
+			Type:        item.Type,
+			Description: item.Description,
+			Amount:      item.Amount,
+		}
+		if !item.Date.IsZero() {
+			lineItem.Date = timestamppb.New(item.Date)
+		}
+		result = append(result, lineItem)
+	}
+	return result
+}
+
+// priceBreakdownToProto decodes the price breakdown stored with a reservation
+func priceBreakdownToProto(data []byte) []*pb.PriceLineItem {
+	if len(data) == 0 {
+		return nil
+	}
+	var items []pricing.LineItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		log.Printf("⚠️ Failed to decode price breakdown: %v", err)
+		return nil
+	}
+	return lineItemsToProto(items)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/events"
+)
+
+const (
+	// stayCompletionInterval is how often finished stays are looked for
+	stayCompletionInterval = time.Minute
+	// stayCompletionBatchSize is the maximum number of reservations completed per transaction
+	stayCompletionBatchSize = 100
+)
+
+// completeStays moves confirmed reservations past their check-out time to COMPLETED and
+// queues a StayCompleted event and a notification for each of them in the same transaction.
+func (s *server) completeStays(ctx context.Context) error {
+	for {
+		var completed []database.Reservation
+		err := s.inTx(ctx, func(q *database.Queries) error {
+			var err error
+			completed, err = q.CompleteFinishedReservations(ctx, stayCompletionBatchSize)
+			if err != nil {
+				return fmt.Errorf("failed to complete reservations: %w", err)
+			}
+			for _, reservation := range completed {
+				if err := enqueueEvent(ctx, q, reservationEvent(events.EventTypeStayCompleted, reservation)); err != nil {
+					return err
+				}
+				message := fmt.Sprintf("Thank you for staying with us (%s). We hope to see you again.", stayLabel(reservation))
+				if err := notifyReservation(ctx, q, notificationStayCompleted, reservation, message); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(completed) > 0 {
+			log.Printf("🏁 Completed %d finished stay(s)", len(completed))
+			s.outbox.Notify()
+		}
+		if len(completed) < stayCompletionBatchSize {
+			return nil
+		}
+	}
+}
@@ -7,21 +7,35 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
-	"cloud.google.com/go/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/karimiku/smart-stay-platform/internal/database"
 	"github.com/karimiku/smart-stay-platform/internal/events"
+	"github.com/karimiku/smart-stay-platform/internal/pricing"
+	"github.com/karimiku/smart-stay-platform/internal/rpcerror"
 	pb "github.com/karimiku/smart-stay-platform/pkg/genproto/reservation"
 )
 
+// errEmailNotVerified refuses bookings from accounts whose email address is not verified.
+// The gateway recognizes it by the PermissionDenied code.
+var errEmailNotVerified = rpcerror.New(codes.PermissionDenied, "EMAIL_NOT_VERIFIED", "email address is not verified")
+
 // server implements the ReservationServiceServer interface.
 type server struct {
 	pb.UnimplementedReservationServiceServer
-	pubsubTopic *pubsub.Topic // Reference to the Pub/Sub topic
-	queries     *database.Queries
+	db           *pgxpool.Pool // Used to run a change and its outbox event in one transaction
+	queries      *database.Queries
+	outbox       *outboxRelay               // Publishes outbox events to Pub/Sub
+	policy       pricing.Policy             // Surcharge and discount rules applied to every quote
+	cancellation pricing.CancellationPolicy // Fee rules applied by CancelReservation
 }
 
 // CreateReservation handles new booking requests.
@@ -31,17 +45,43 @@ func (s *server) CreateReservation(ctx context.Context, req *pb.CreateReservatio
 	// 1. Parse user_id from string to UUID
 	userUUID, err := stringToUUID(req.UserId)
 	if err != nil {
-		return nil, errors.New("invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
+	}
+	if !req.EndDate.AsTime().After(req.StartDate.AsTime()) {
+		return nil, rpcerror.InvalidField("end_date", "end_date must be after start_date")
+	}
+
+	// Only verified accounts can book, so that throwaway signups cannot hold rooms
+	verified, err := s.queries.IsUserEmailVerified(ctx, userUUID)
+	if err != nil {
+		log.Printf("❌ Failed to check email verification: %v", err)
+		return nil, rpcerror.Internal("failed to create reservation")
+	}
+	if !verified {
+		log.Printf("⚠️ User %s tried to book before verifying their email address", req.UserId)
+		return nil, errEmailNotVerified
 	}
 
-	// 2. Calculate total price (simplified: 1 night = 50000 yen)
-	days := int(req.EndDate.AsTime().Sub(req.StartDate.AsTime()).Hours() / 24)
-	if days < 1 {
-		days = 1
+	// 2. Make sure the room exists and can be booked
+	room, _, err := s.getBookableRoom(ctx, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Calculate total price and keep the itemized breakdown with the reservation
+	quote, err := s.quoteRoom(ctx, room, req.StartDate.AsTime(), req.EndDate.AsTime())
+	if err != nil {
+		return nil, err
+	}
+	breakdown, err := json.Marshal(quote.LineItems)
+	if err != nil {
+		log.Printf("❌ Failed to marshal price breakdown: %v", err)
+		return nil, rpcerror.Internal("failed to create reservation")
 	}
-	totalPrice := int64(days * 50000)
 
-	// 3. Convert timestamps
+	// 4. Convert timestamps
+	// The booked dates are calendar dates; the database derives the check-in/check-out
+	// instants from them at the property's local times (set_reservations_stay_instants)
 	startTimestamp := pgtype.Timestamp{
 		Time:  req.StartDate.AsTime(),
 		Valid: true,
@@ -51,56 +91,41 @@ func (s *server) CreateReservation(ctx context.Context, req *pb.CreateReservatio
 		Valid: true,
 	}
 
-	// 4. Create reservation in database
-	dbReservation, err := s.queries.CreateReservation(ctx, database.CreateReservationParams{
-		UserID:     userUUID,
-		RoomID:     req.RoomId,
-		StartDate:  startTimestamp,
-		EndDate:    endTimestamp,
-		TotalPrice: totalPrice,
-		Status:     "PENDING",
-	})
-	if err != nil {
-		log.Printf("❌ Failed to create reservation: %v", err)
-		return nil, errors.New("failed to create reservation")
-	}
-
-	resID := uuidToString(dbReservation.ID)
+	// 5. Create reservation and its ReservationCreated event in one transaction
+	// The outbox relay publishes the event to Pub/Sub (Asynchronous), so a Pub/Sub outage
+	// delays key generation but can never lose it.
+	var resID string
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		dbReservation, err := q.CreateReservation(ctx, database.CreateReservationParams{
+			UserID:         userUUID,
+			RoomID:         req.RoomId,
+			StartDate:      startTimestamp,
+			EndDate:        endTimestamp,
+			TotalPrice:     quote.Total,
+			Status:         "PENDING",
+			PriceBreakdown: breakdown,
+		})
+		if err != nil {
+			return err
+		}
+		resID = uuidToString(dbReservation.ID)
 
-	// 5. Publish Event to Pub/Sub (Asynchronous)
-	// We don't wait for Key Service here. We just shout "Created!" and return.
-	event := events.EventPayload{
-		EventType:     events.EventTypeReservationCreated,
-		ReservationID: resID,
-		UserID:        req.UserId,
-		StartDate:     req.StartDate.AsTime(),
-		EndDate:       req.EndDate.AsTime(),
-	}
-	eventData, err := json.Marshal(event)
-	if err != nil {
-		log.Printf("failed to marshal event: %v", err)
-	}
-
-	// Publish the message
-	result := s.pubsubTopic.Publish(ctx, &pubsub.Message{
-		Data: eventData,
-		Attributes: map[string]string{
-			"origin": "reservation-service",
-		},
+		// 6. Queue Event for the Key Service
+		// We don't wait for Key Service here. We just shout "Created!" and return.
+		return enqueueEvent(ctx, q, reservationEvent(events.EventTypeReservationCreated, dbReservation))
 	})
-
-	// Optionally wait for the publish result (or handle it in background)
-	// For high throughput, you might just fire and forget, but here we check for errors.
-	id, err := result.Get(ctx)
 	if err != nil {
-		log.Printf("❌ Failed to publish event: %v", err)
-		// Note: Even if publish fails, we might still return success if DB commit worked,
-		// but in a robust Saga, we'd need an outbox pattern.
-	} else {
-		log.Printf("📢 Published event ID: %s", id)
+		// The exclusion constraint rejects overlapping bookings atomically
+		if isExclusionViolation(err) {
+			log.Printf("⚠️ Room %d is already booked between %s and %s", req.RoomId, req.StartDate.AsTime(), req.EndDate.AsTime())
+			return nil, rpcerror.New(codes.AlreadyExists, "ROOM_ALREADY_BOOKED", "room is already booked for the selected dates")
+		}
+		log.Printf("❌ Failed to create reservation: %v", err)
+		return nil, rpcerror.Internal("failed to create reservation")
 	}
+	s.outbox.Notify()
 
-	// 6. Return Response (Immediately PENDING)
+	// 7. Return Response (Immediately PENDING)
 	return &pb.CreateReservationResponse{
 		ReservationId: resID,
 		Status:        pb.ReservationStatus_PENDING,
@@ -111,12 +136,16 @@ func (s *server) CreateReservation(ctx context.Context, req *pb.CreateReservatio
 func (s *server) GetReservation(ctx context.Context, req *pb.GetReservationRequest) (*pb.GetReservationResponse, error) {
 	resUUID, err := stringToUUID(req.ReservationId)
 	if err != nil {
-		return nil, errors.New("invalid reservation_id format")
+		return nil, rpcerror.InvalidField("reservation_id", "invalid reservation_id format")
 	}
 
 	dbReservation, err := s.queries.GetReservation(ctx, resUUID)
 	if err != nil {
-		return nil, errors.New("reservation not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "reservation not found")
+		}
+		log.Printf("❌ Failed to get reservation: %v", err)
+		return nil, rpcerror.Internal("failed to get reservation")
 	}
 
 	reservation := dbReservationToProto(dbReservation)
@@ -129,13 +158,13 @@ func (s *server) GetReservation(ctx context.Context, req *pb.GetReservationReque
 func (s *server) ListReservations(ctx context.Context, req *pb.ListReservationsRequest) (*pb.ListReservationsResponse, error) {
 	userUUID, err := stringToUUID(req.UserId)
 	if err != nil {
-		return nil, errors.New("invalid user_id format")
+		return nil, rpcerror.InvalidField("user_id", "invalid user_id format")
 	}
 
 	dbReservations, err := s.queries.ListReservationsByUserID(ctx, userUUID)
 	if err != nil {
 		log.Printf("❌ Failed to list reservations: %v", err)
-		return nil, errors.New("failed to list reservations")
+		return nil, rpcerror.Internal("failed to list reservations")
 	}
 
 	var reservations []*pb.Reservation
@@ -148,8 +177,192 @@ func (s *server) ListReservations(ctx context.Context, req *pb.ListReservationsR
 	}, nil
 }
 
+// SearchAvailability returns the rooms that have no active reservation overlapping the requested stay
+func (s *server) SearchAvailability(ctx context.Context, req *pb.SearchAvailabilityRequest) (*pb.SearchAvailabilityResponse, error) {
+	if req.StartDate == nil || req.EndDate == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_date and end_date are required")
+	}
+	start := req.StartDate.AsTime()
+	end := req.EndDate.AsTime()
+	if !end.After(start) {
+		return nil, rpcerror.InvalidField("end_date", "end_date must be after start_date")
+	}
+	if req.Guests < 1 {
+		return nil, rpcerror.InvalidField("guests", "guests must be at least 1")
+	}
+
+	// 1. Determine the candidate rooms (active and large enough for the party)
+	rooms, err := s.queries.ListBookableRooms(ctx, req.Guests)
+	if err != nil {
+		log.Printf("❌ Failed to list rooms: %v", err)
+		return nil, rpcerror.Internal("failed to search availability")
+	}
+	requested := make(map[int64]bool, len(req.RoomIds))
+	for _, roomID := range req.RoomIds {
+		requested[roomID] = true
+	}
+
+	// 2. Exclude rooms that are booked during the requested stay
+	booked, err := s.queries.ListBookedRoomIDs(ctx, database.ListBookedRoomIDsParams{
+		StartDate: pgtype.Timestamp{Time: start, Valid: true},
+		EndDate:   pgtype.Timestamp{Time: end, Valid: true},
+	})
+	if err != nil {
+		log.Printf("❌ Failed to list booked rooms: %v", err)
+		return nil, rpcerror.Internal("failed to search availability")
+	}
+	bookedSet := make(map[int64]bool, len(booked))
+	for _, roomID := range booked {
+		bookedSet[roomID] = true
+	}
+
+	// 3. Attach the per-night price breakdown
+	seasons, err := s.seasonalRatesInRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	var available []*pb.AvailableRoom
+	for _, room := range rooms {
+		if bookedSet[room.ID] || (len(requested) > 0 && !requested[room.ID]) {
+			continue
+		}
+		quote, err := s.policy.Quote(roomRates(room, seasons[room.ID]), start, end)
+		if errors.Is(err, pricing.ErrMinimumStay) {
+			// Rooms whose minimum stay is longer than the request are not available
+			continue
+		}
+		if err != nil {
+			log.Printf("❌ Failed to price room %d: %v", room.ID, err)
+			return nil, rpcerror.Internal("failed to search availability")
+		}
+		available = append(available, &pb.AvailableRoom{
+			RoomId:     room.ID,
+			Nights:     nightsToProto(quote.Nights),
+			TotalPrice: quote.Total,
+		})
+	}
+
+	return &pb.SearchAvailabilityResponse{
+		Rooms: available,
+	}, nil
+}
+
+// CancelReservation cancels a reservation owned by the caller and charges the cancellation fee
+func (s *server) CancelReservation(ctx context.Context, req *pb.CancelReservationRequest) (*pb.CancelReservationResponse, error) {
+	log.Printf("🚫 Received CancelReservation request. User: %s, Reservation: %s", req.UserId, req.ReservationId)
+
+	// 1. Load the reservation and check ownership
+	resUUID, err := stringToUUID(req.ReservationId)
+	if err != nil {
+		return nil, rpcerror.InvalidField("reservation_id", "invalid reservation_id format")
+	}
+	dbReservation, err := s.queries.GetReservation(ctx, resUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "reservation not found")
+		}
+		log.Printf("❌ Failed to get reservation: %v", err)
+		return nil, rpcerror.Internal("failed to get reservation")
+	}
+	if uuidToString(dbReservation.UserID) != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "reservation belongs to another user")
+	}
+
+	// 2. Apply the cancellation policy
+	fee, err := s.cancellation.Fee(dbReservation.TotalPrice, dbReservation.CheckInAt.Time, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// 3. Cancel and queue the ReservationCancelled event in one transaction
+	// (the status guard makes concurrent cancellations safe)
+	var cancelled database.Reservation
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		cancelled, err = q.CancelReservation(ctx, database.CancelReservationParams{
+			ID:              resUUID,
+			CancellationFee: fee,
+		})
+		if err != nil {
+			return err
+		}
+		message := fmt.Sprintf("Your reservation for %s was cancelled. Cancellation fee: %d, refund: %d.",
+			stayLabel(cancelled), cancelled.CancellationFee, cancelled.TotalPrice-cancelled.CancellationFee)
+		if err := notifyReservation(ctx, q, notificationReservationCancelled, cancelled, message); err != nil {
+			return err
+		}
+
+		// 4. Queue Event so that the Key Service revokes the key (compensation)
+		return enqueueEvent(ctx, q, reservationEvent(events.EventTypeReservationCancelled, cancelled))
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "reservation is already %s", dbReservation.Status)
+		}
+		log.Printf("❌ Failed to cancel reservation: %v", err)
+		return nil, rpcerror.Internal("failed to cancel reservation")
+	}
+	s.outbox.Notify()
+
+	log.Printf("🚫 Reservation cancelled: %s (fee: %d)", req.ReservationId, fee)
+	return &pb.CancelReservationResponse{
+		ReservationId:   req.ReservationId,
+		Status:          pb.ReservationStatus_CANCELLED,
+		CancellationFee: cancelled.CancellationFee,
+		RefundAmount:    cancelled.TotalPrice - cancelled.CancellationFee,
+	}, nil
+}
+
 // Helper functions
 
+// inTx runs fn in a database transaction, committing if it returns nil
+func (s *server) inTx(ctx context.Context, fn func(q *database.Queries) error) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(s.queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// getBookableRoom loads a room and its property and checks that both are active
+func (s *server) getBookableRoom(ctx context.Context, roomID int64) (database.Room, database.Property, error) {
+	room, err := s.queries.GetRoom(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.Room{}, database.Property{}, status.Errorf(codes.NotFound, "room %d not found", roomID)
+		}
+		log.Printf("❌ Failed to get room: %v", err)
+		return database.Room{}, database.Property{}, rpcerror.Internal("failed to get room")
+	}
+
+	property, err := s.queries.GetProperty(ctx, room.PropertyID)
+	if err != nil {
+		log.Printf("❌ Failed to get property: %v", err)
+		return database.Room{}, database.Property{}, rpcerror.Internal("failed to get property")
+	}
+	if !room.IsActive || !property.IsActive {
+		return database.Room{}, database.Property{}, status.Errorf(codes.FailedPrecondition, "room %d is not available for booking", roomID)
+	}
+	return room, property, nil
+}
+
+// reservationEvent builds the event payload describing a reservation
+func reservationEvent(eventType string, r database.Reservation) events.EventPayload {
+	return events.EventPayload{
+		EventType:     eventType,
+		ReservationID: uuidToString(r.ID),
+		UserID:        uuidToString(r.UserID),
+		StartDate:     r.StartDate.Time,
+		EndDate:       r.EndDate.Time,
+		CheckInAt:     r.CheckInAt.Time,
+		CheckOutAt:    r.CheckOutAt.Time,
+	}
+}
+
 // stringToUUID converts string UUID to pgtype.UUID
 func stringToUUID(s string) (pgtype.UUID, error) {
 	var uuid pgtype.UUID
@@ -175,6 +388,13 @@ func uuidToString(uuid pgtype.UUID) string {
 	return hex.EncodeToString(uuid.Bytes[:])
 }
 
+// isExclusionViolation reports whether err is a PostgreSQL exclusion_violation (23P01),
+// raised by the reservations_no_overlap constraint when date ranges overlap.
+func isExclusionViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23P01"
+}
+
 // dbReservationToProto converts database Reservation to protobuf Reservation
 func dbReservationToProto(dbRes database.Reservation) *pb.Reservation {
 	status := pb.ReservationStatus_PENDING
@@ -194,14 +414,30 @@ func dbReservationToProto(dbRes database.Reservation) *pb.Reservation {
 	if dbRes.EndDate.Valid {
 		endDate = timestamppb.New(dbRes.EndDate.Time)
 	}
+	var cancelledAt *timestamppb.Timestamp
+	if dbRes.CancelledAt.Valid {
+		cancelledAt = timestamppb.New(dbRes.CancelledAt.Time)
+	}
+	var checkInAt, checkOutAt *timestamppb.Timestamp
+	if dbRes.CheckInAt.Valid {
+		checkInAt = timestamppb.New(dbRes.CheckInAt.Time)
+	}
+	if dbRes.CheckOutAt.Valid {
+		checkOutAt = timestamppb.New(dbRes.CheckOutAt.Time)
+	}
 
 	return &pb.Reservation{
-		Id:         uuidToString(dbRes.ID),
-		UserId:     uuidToString(dbRes.UserID),
-		RoomId:     dbRes.RoomID,
-		StartDate:  startDate,
-		EndDate:    endDate,
-		TotalPrice: dbRes.TotalPrice,
-		Status:     status,
-	}
-}
\ No newline at end of file
+		Id:              uuidToString(dbRes.ID),
+		UserId:          uuidToString(dbRes.UserID),
+		RoomId:          dbRes.RoomID,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		TotalPrice:      dbRes.TotalPrice,
+		Status:          status,
+		PriceBreakdown:  priceBreakdownToProto(dbRes.PriceBreakdown),
+		CancellationFee: dbRes.CancellationFee,
+		CancelledAt:     cancelledAt,
+		CheckInAt:       checkInAt,
+		CheckOutAt:      checkOutAt,
+	}
+}
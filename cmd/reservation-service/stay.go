@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Default property-local check-in and check-out times
+const (
+	defaultCheckInTime  = "15:00"
+	defaultCheckOutTime = "10:00"
+)
+
+// parseTimeOfDay parses an "HH:MM" time of day, using fallback when s is empty
+func parseTimeOfDay(s, fallback string) (pgtype.Time, error) {
+	if s == "" {
+		s = fallback
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return pgtype.Time{}, err
+	}
+	minutes := int64(t.Hour()*60 + t.Minute())
+	return pgtype.Time{Microseconds: minutes * int64(time.Minute/time.Microsecond), Valid: true}, nil
+}
+
+// formatTimeOfDay formats a time of day as "HH:MM"
+func formatTimeOfDay(t pgtype.Time) string {
+	if !t.Valid {
+		return ""
+	}
+	minutes := t.Microseconds / int64(time.Minute/time.Microsecond)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
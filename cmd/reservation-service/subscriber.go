@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+	"github.com/karimiku/smart-stay-platform/internal/events"
+)
+
+// handleKeyEvent completes the reservation saga from the Key Service's outcome:
+// KeyIssued confirms the reservation, KeyIssueFailed cancels it (compensation).
+func (s *server) handleKeyEvent(ctx context.Context, msg *pubsub.Message) {
+	log.Printf("📥 Received message: ID=%s", msg.ID)
+
+	var event events.EventPayload
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		log.Printf("❌ Failed to parse event: %v", err)
+		msg.Ack() // Malformed messages will never succeed; don't redeliver
+		return
+	}
+
+	var newStatus string
+	switch event.EventType {
+	case events.EventTypeKeyIssued:
+		newStatus = "CONFIRMED"
+	case events.EventTypeKeyIssueFailed:
+		log.Printf("⚠️ Key issuance failed for reservation %s: %s", event.ReservationID, event.Reason)
+		newStatus = "CANCELLED"
+	default:
+		msg.Ack()
+		return
+	}
+
+	resUUID, err := stringToUUID(event.ReservationID)
+	if err != nil {
+		log.Printf("❌ Invalid reservation_id in %s event: %s", event.EventType, event.ReservationID)
+		msg.Ack()
+		return
+	}
+
+	// Update the status and tell the guest in one transaction
+	var reservation database.Reservation
+	err = s.inTx(ctx, func(q *database.Queries) error {
+		reservation, err = q.UpdatePendingReservationStatus(ctx, database.UpdatePendingReservationStatusParams{
+			ID:     resUUID,
+			Status: newStatus,
+		})
+		if err != nil {
+			return err
+		}
+		if newStatus == "CONFIRMED" {
+			return notifyReservation(ctx, q, notificationReservationConfirmed, reservation,
+				fmt.Sprintf("Your reservation for %s is confirmed. Your key is ready.", stayLabel(reservation)))
+		}
+		return notifyReservation(ctx, q, notificationReservationCancelled, reservation,
+			fmt.Sprintf("Your reservation for %s was cancelled because a key could not be issued. You have not been charged.", stayLabel(reservation)))
+	})
+	if err == nil {
+		log.Printf("✅ Reservation %s moved to %s", event.ReservationID, reservation.Status)
+		msg.Ack()
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("❌ Failed to update reservation status: %v", err)
+		msg.Nack()
+		return
+	}
+
+	// The reservation is no longer PENDING (e.g. the guest cancelled it while the key was being issued)
+	current, err := s.queries.GetReservation(ctx, resUUID)
+	if err != nil {
+		log.Printf("⚠️ Reservation %s not found for %s event: %v", event.ReservationID, event.EventType, err)
+		msg.Ack()
+		return
+	}
+	if event.EventType == events.EventTypeKeyIssued && current.Status == "CANCELLED" {
+		// A key was issued for a cancelled reservation: ask the Key Service to revoke it again
+		log.Printf("⚠️ Key issued for cancelled reservation %s, requesting revocation", event.ReservationID)
+		if err := enqueueEvent(ctx, s.queries, reservationEvent(events.EventTypeReservationCancelled, current)); err != nil {
+			log.Printf("❌ %v", err)
+			msg.Nack()
+			return
+		}
+		s.outbox.Notify()
+	}
+	msg.Ack()
+}
@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: account_unlock_tokens.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAccountUnlockToken = `-- name: CreateAccountUnlockToken :one
+INSERT INTO account_unlock_tokens (user_id, token_hash, expires_at)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, token_hash, expires_at, used_at, created_at
+`
+
+type CreateAccountUnlockTokenParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateAccountUnlockToken(ctx context.Context, arg CreateAccountUnlockTokenParams) (AccountUnlockToken, error) {
+	row := q.db.QueryRow(ctx, createAccountUnlockToken, arg.UserID, arg.TokenHash, arg.ExpiresAt)
+	var i AccountUnlockToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteExpiredAccountUnlockTokens = `-- name: DeleteExpiredAccountUnlockTokens :execrows
+DELETE FROM account_unlock_tokens
+WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredAccountUnlockTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredAccountUnlockTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const invalidateAccountUnlockTokens = `-- name: InvalidateAccountUnlockTokens :exec
+UPDATE account_unlock_tokens
+SET used_at = NOW()
+WHERE user_id = $1
+  AND used_at IS NULL
+`
+
+func (q *Queries) InvalidateAccountUnlockTokens(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, invalidateAccountUnlockTokens, userID)
+	return err
+}
+
+const useAccountUnlockToken = `-- name: UseAccountUnlockToken :one
+UPDATE account_unlock_tokens
+SET used_at = NOW()
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING user_id
+`
+
+func (q *Queries) UseAccountUnlockToken(ctx context.Context, tokenHash []byte) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, useAccountUnlockToken, tokenHash)
+	var user_id pgtype.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
+}
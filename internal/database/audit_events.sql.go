@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: audit_events.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAuditEvent = `-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (event_type, user_id, subject, ip_address, details)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateAuditEventParams struct {
+	EventType string      `json:"event_type"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Subject   string      `json:"subject"`
+	IpAddress string      `json:"ip_address"`
+	Details   []byte      `json:"details"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error {
+	_, err := q.db.Exec(ctx, createAuditEvent,
+		arg.EventType,
+		arg.UserID,
+		arg.Subject,
+		arg.IpAddress,
+		arg.Details,
+	)
+	return err
+}
@@ -0,0 +1,123 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: email_verification_tokens.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createEmailVerificationToken = `-- name: CreateEmailVerificationToken :one
+INSERT INTO email_verification_tokens (user_id, token_hash, expires_at, email)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, token_hash, expires_at, used_at, created_at, email
+`
+
+type CreateEmailVerificationTokenParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	Email     pgtype.Text        `json:"email"`
+}
+
+func (q *Queries) CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error) {
+	row := q.db.QueryRow(ctx, createEmailVerificationToken,
+		arg.UserID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.Email,
+	)
+	var i EmailVerificationToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+		&i.Email,
+	)
+	return i, err
+}
+
+const deleteExpiredEmailVerificationTokens = `-- name: DeleteExpiredEmailVerificationTokens :execrows
+DELETE FROM email_verification_tokens
+WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredEmailVerificationTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredEmailVerificationTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getPendingEmailChange = `-- name: GetPendingEmailChange :one
+SELECT email::VARCHAR
+FROM email_verification_tokens
+WHERE user_id = $1
+  AND email IS NOT NULL
+  AND used_at IS NULL
+  AND expires_at > NOW()
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetPendingEmailChange(ctx context.Context, userID pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getPendingEmailChange, userID)
+	var email string
+	err := row.Scan(&email)
+	return email, err
+}
+
+const invalidateEmailChangeTokens = `-- name: InvalidateEmailChangeTokens :exec
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE user_id = $1
+  AND email IS NOT NULL
+  AND used_at IS NULL
+`
+
+func (q *Queries) InvalidateEmailChangeTokens(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, invalidateEmailChangeTokens, userID)
+	return err
+}
+
+const invalidateEmailVerificationTokens = `-- name: InvalidateEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE user_id = $1
+  AND email IS NULL
+  AND used_at IS NULL
+`
+
+func (q *Queries) InvalidateEmailVerificationTokens(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, invalidateEmailVerificationTokens, userID)
+	return err
+}
+
+const useEmailVerificationToken = `-- name: UseEmailVerificationToken :one
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING user_id, email
+`
+
+type UseEmailVerificationTokenRow struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Email  pgtype.Text `json:"email"`
+}
+
+func (q *Queries) UseEmailVerificationToken(ctx context.Context, tokenHash []byte) (UseEmailVerificationTokenRow, error) {
+	row := q.db.QueryRow(ctx, useEmailVerificationToken, tokenHash)
+	var i UseEmailVerificationTokenRow
+	err := row.Scan(&i.UserID, &i.Email)
+	return i, err
+}
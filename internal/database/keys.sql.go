@@ -14,16 +14,16 @@ import (
 const createKey = `-- name: CreateKey :one
 INSERT INTO keys (reservation_id, user_id, key_code, device_id, valid_from, valid_until)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
 `
 
 type CreateKeyParams struct {
-	ReservationID pgtype.UUID      `json:"reservation_id"`
-	UserID        pgtype.UUID      `json:"user_id"`
-	KeyCode       string           `json:"key_code"`
-	DeviceID      string           `json:"device_id"`
-	ValidFrom     pgtype.Timestamp `json:"valid_from"`
-	ValidUntil    pgtype.Timestamp `json:"valid_until"`
+	ReservationID pgtype.UUID        `json:"reservation_id"`
+	UserID        pgtype.UUID        `json:"user_id"`
+	KeyCode       string             `json:"key_code"`
+	DeviceID      string             `json:"device_id"`
+	ValidFrom     pgtype.Timestamptz `json:"valid_from"`
+	ValidUntil    pgtype.Timestamptz `json:"valid_until"`
 }
 
 func (q *Queries) CreateKey(ctx context.Context, arg CreateKeyParams) (Key, error) {
@@ -46,14 +46,37 @@ func (q *Queries) CreateKey(ctx context.Context, arg CreateKeyParams) (Key, erro
 		&i.ValidUntil,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.ProviderSyncedAt,
+		&i.ProviderError,
+		&i.ExpiredAt,
 	)
 	return i, err
 }
 
+const expireKeys = `-- name: ExpireKeys :execrows
+UPDATE keys
+SET status = 'EXPIRED', expired_at = NOW(), updated_at = NOW()
+WHERE status = 'ACTIVE'
+  AND valid_until <= NOW()
+`
+
+func (q *Queries) ExpireKeys(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, expireKeys)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getKeyByReservationID = `-- name: GetKeyByReservationID :one
-SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
 FROM keys
-WHERE reservation_id = $1 LIMIT 1
+WHERE reservation_id = $1
+ORDER BY created_at DESC
+LIMIT 1
 `
 
 func (q *Queries) GetKeyByReservationID(ctx context.Context, reservationID pgtype.UUID) (Key, error) {
@@ -69,16 +92,57 @@ func (q *Queries) GetKeyByReservationID(ctx context.Context, reservationID pgtyp
 		&i.ValidUntil,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.ProviderSyncedAt,
+		&i.ProviderError,
+		&i.ExpiredAt,
 	)
 	return i, err
 }
 
+const isKeyCodeInUse = `-- name: IsKeyCodeInUse :one
+SELECT EXISTS (
+    SELECT 1
+    FROM keys
+    WHERE device_id = $1
+      AND key_code = $2
+      AND (
+          (status = 'ACTIVE' AND valid_from < $3 AND valid_until > $4)
+          OR (status = 'REVOKED' AND revoked_at > $5)
+      )
+) AS in_use
+`
+
+type IsKeyCodeInUseParams struct {
+	DeviceID     string             `json:"device_id"`
+	KeyCode      string             `json:"key_code"`
+	ValidUntil   pgtype.Timestamptz `json:"valid_until"`
+	ValidFrom    pgtype.Timestamptz `json:"valid_from"`
+	RevokedAfter pgtype.Timestamptz `json:"revoked_after"`
+}
+
+func (q *Queries) IsKeyCodeInUse(ctx context.Context, arg IsKeyCodeInUseParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isKeyCodeInUse,
+		arg.DeviceID,
+		arg.KeyCode,
+		arg.ValidUntil,
+		arg.ValidFrom,
+		arg.RevokedAfter,
+	)
+	var in_use bool
+	err := row.Scan(&in_use)
+	return in_use, err
+}
+
 const listActiveKeysByUserID = `-- name: ListActiveKeysByUserID :many
-SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
 FROM keys
 WHERE user_id = $1
-  AND DATE(valid_from) <= CURRENT_DATE
-  AND DATE(valid_until) >= CURRENT_DATE
+  AND status = 'ACTIVE'
+  AND valid_from <= NOW()
+  AND valid_until > NOW()
 ORDER BY valid_from DESC
 `
 
@@ -101,6 +165,12 @@ func (q *Queries) ListActiveKeysByUserID(ctx context.Context, userID pgtype.UUID
 			&i.ValidUntil,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.RevokedAt,
+			&i.RevokeReason,
+			&i.ProviderSyncedAt,
+			&i.ProviderError,
+			&i.ExpiredAt,
 		); err != nil {
 			return nil, err
 		}
@@ -113,9 +183,10 @@ func (q *Queries) ListActiveKeysByUserID(ctx context.Context, userID pgtype.UUID
 }
 
 const listKeysByUserID = `-- name: ListKeysByUserID :many
-SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
 FROM keys
 WHERE user_id = $1
+  AND status <> 'REVOKED'
 ORDER BY valid_from DESC
 `
 
@@ -138,6 +209,12 @@ func (q *Queries) ListKeysByUserID(ctx context.Context, userID pgtype.UUID) ([]K
 			&i.ValidUntil,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.RevokedAt,
+			&i.RevokeReason,
+			&i.ProviderSyncedAt,
+			&i.ProviderError,
+			&i.ExpiredAt,
 		); err != nil {
 			return nil, err
 		}
@@ -148,3 +225,165 @@ func (q *Queries) ListKeysByUserID(ctx context.Context, userID pgtype.UUID) ([]K
 	}
 	return items, nil
 }
+
+const listKeysPendingLockRemoval = `-- name: ListKeysPendingLockRemoval :many
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
+FROM keys
+WHERE status IN ('REVOKED', 'EXPIRED')
+  AND (provider_synced_at IS NULL OR provider_synced_at < COALESCE(revoked_at, expired_at))
+ORDER BY updated_at
+LIMIT $1
+`
+
+func (q *Queries) ListKeysPendingLockRemoval(ctx context.Context, limit int32) ([]Key, error) {
+	rows, err := q.db.Query(ctx, listKeysPendingLockRemoval, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Key
+	for rows.Next() {
+		var i Key
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReservationID,
+			&i.UserID,
+			&i.KeyCode,
+			&i.DeviceID,
+			&i.ValidFrom,
+			&i.ValidUntil,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.RevokedAt,
+			&i.RevokeReason,
+			&i.ProviderSyncedAt,
+			&i.ProviderError,
+			&i.ExpiredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markKeyProviderSynced = `-- name: MarkKeyProviderSynced :one
+UPDATE keys
+SET provider_synced_at = NOW(), provider_error = NULL, updated_at = NOW()
+WHERE id = $1
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
+`
+
+func (q *Queries) MarkKeyProviderSynced(ctx context.Context, id pgtype.UUID) (Key, error) {
+	row := q.db.QueryRow(ctx, markKeyProviderSynced, id)
+	var i Key
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.UserID,
+		&i.KeyCode,
+		&i.DeviceID,
+		&i.ValidFrom,
+		&i.ValidUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.ProviderSyncedAt,
+		&i.ProviderError,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const recordKeyProviderError = `-- name: RecordKeyProviderError :exec
+UPDATE keys
+SET provider_error = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type RecordKeyProviderErrorParams struct {
+	ID            pgtype.UUID `json:"id"`
+	ProviderError pgtype.Text `json:"provider_error"`
+}
+
+func (q *Queries) RecordKeyProviderError(ctx context.Context, arg RecordKeyProviderErrorParams) error {
+	_, err := q.db.Exec(ctx, recordKeyProviderError, arg.ID, arg.ProviderError)
+	return err
+}
+
+const revokeActiveKey = `-- name: RevokeActiveKey :one
+UPDATE keys
+SET status = 'REVOKED', revoked_at = NOW(), revoke_reason = $2, updated_at = NOW()
+WHERE reservation_id = $1
+  AND status = 'ACTIVE'
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
+`
+
+type RevokeActiveKeyParams struct {
+	ReservationID pgtype.UUID `json:"reservation_id"`
+	RevokeReason  pgtype.Text `json:"revoke_reason"`
+}
+
+func (q *Queries) RevokeActiveKey(ctx context.Context, arg RevokeActiveKeyParams) (Key, error) {
+	row := q.db.QueryRow(ctx, revokeActiveKey, arg.ReservationID, arg.RevokeReason)
+	var i Key
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.UserID,
+		&i.KeyCode,
+		&i.DeviceID,
+		&i.ValidFrom,
+		&i.ValidUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.ProviderSyncedAt,
+		&i.ProviderError,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const updateKeyCode = `-- name: UpdateKeyCode :one
+UPDATE keys
+SET key_code = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
+`
+
+type UpdateKeyCodeParams struct {
+	ID      pgtype.UUID `json:"id"`
+	KeyCode string      `json:"key_code"`
+}
+
+func (q *Queries) UpdateKeyCode(ctx context.Context, arg UpdateKeyCodeParams) (Key, error) {
+	row := q.db.QueryRow(ctx, updateKeyCode, arg.ID, arg.KeyCode)
+	var i Key
+	err := row.Scan(
+		&i.ID,
+		&i.ReservationID,
+		&i.UserID,
+		&i.KeyCode,
+		&i.DeviceID,
+		&i.ValidFrom,
+		&i.ValidUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.ProviderSyncedAt,
+		&i.ProviderError,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
@@ -0,0 +1,29 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: lock_pin_policies.sql
+
+package database
+
+import (
+	"context"
+)
+
+const getLockPinPolicy = `-- name: GetLockPinPolicy :one
+SELECT device_id, pin_length, reuse_cooldown_days, created_at, updated_at
+FROM lock_pin_policies
+WHERE device_id = $1
+`
+
+func (q *Queries) GetLockPinPolicy(ctx context.Context, deviceID string) (LockPinPolicy, error) {
+	row := q.db.QueryRow(ctx, getLockPinPolicy, deviceID)
+	var i LockPinPolicy
+	err := row.Scan(
+		&i.DeviceID,
+		&i.PinLength,
+		&i.ReuseCooldownDays,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,127 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: login_attempts.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addLoginFailure = `-- name: AddLoginFailure :one
+INSERT INTO login_attempts (key, failures, last_failure_at)
+VALUES ($1, 1, $2)
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+        WHEN login_attempts.last_failure_at < $3::TIMESTAMPTZ THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = EXCLUDED.last_failure_at
+RETURNING failures
+`
+
+type AddLoginFailureParams struct {
+	Key         string             `json:"key"`
+	FailedAt    pgtype.Timestamptz `json:"failed_at"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+}
+
+func (q *Queries) AddLoginFailure(ctx context.Context, arg AddLoginFailureParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addLoginFailure, arg.Key, arg.FailedAt, arg.WindowStart)
+	var failures int32
+	err := row.Scan(&failures)
+	return failures, err
+}
+
+const blockLoginKey = `-- name: BlockLoginKey :exec
+UPDATE login_attempts
+SET blocked_until = GREATEST(COALESCE(blocked_until, $1::TIMESTAMPTZ), $1::TIMESTAMPTZ)
+WHERE key = $2
+`
+
+type BlockLoginKeyParams struct {
+	BlockedUntil pgtype.Timestamptz `json:"blocked_until"`
+	Key          string             `json:"key"`
+}
+
+func (q *Queries) BlockLoginKey(ctx context.Context, arg BlockLoginKeyParams) error {
+	_, err := q.db.Exec(ctx, blockLoginKey, arg.BlockedUntil, arg.Key)
+	return err
+}
+
+const deleteLoginAttempt = `-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts
+WHERE key = $1
+`
+
+func (q *Queries) DeleteLoginAttempt(ctx context.Context, key string) error {
+	_, err := q.db.Exec(ctx, deleteLoginAttempt, key)
+	return err
+}
+
+const deleteStaleLoginAttempts = `-- name: DeleteStaleLoginAttempts :execrows
+DELETE FROM login_attempts
+WHERE last_failure_at < $1::TIMESTAMPTZ
+  AND (blocked_until IS NULL OR blocked_until < $2::TIMESTAMPTZ)
+`
+
+type DeleteStaleLoginAttemptsParams struct {
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+	Now         pgtype.Timestamptz `json:"now"`
+}
+
+func (q *Queries) DeleteStaleLoginAttempts(ctx context.Context, arg DeleteStaleLoginAttemptsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleLoginAttempts, arg.WindowStart, arg.Now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getLoginAttempt = `-- name: GetLoginAttempt :one
+SELECT key, failures, last_failure_at, blocked_until FROM login_attempts
+WHERE key = $1
+`
+
+func (q *Queries) GetLoginAttempt(ctx context.Context, key string) (LoginAttempt, error) {
+	row := q.db.QueryRow(ctx, getLoginAttempt, key)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Key,
+		&i.Failures,
+		&i.LastFailureAt,
+		&i.BlockedUntil,
+	)
+	return i, err
+}
+
+const removeLoginFailure = `-- name: RemoveLoginFailure :exec
+UPDATE login_attempts
+SET failures = failures - 1
+WHERE key = $1
+  AND failures > 0
+`
+
+func (q *Queries) RemoveLoginFailure(ctx context.Context, key string) error {
+	_, err := q.db.Exec(ctx, removeLoginFailure, key)
+	return err
+}
+
+const setLoginFailures = `-- name: SetLoginFailures :exec
+UPDATE login_attempts
+SET failures = $1
+WHERE key = $2
+`
+
+type SetLoginFailuresParams struct {
+	Failures int32  `json:"failures"`
+	Key      string `json:"key"`
+}
+
+func (q *Queries) SetLoginFailures(ctx context.Context, arg SetLoginFailuresParams) error {
+	_, err := q.db.Exec(ctx, setLoginFailures, arg.Failures, arg.Key)
+	return err
+}
@@ -0,0 +1,266 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: mfa.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countUnusedMFARecoveryCodes = `-- name: CountUnusedMFARecoveryCodes :one
+SELECT COUNT(*) FROM mfa_recovery_codes
+WHERE user_id = $1
+  AND used_at IS NULL
+`
+
+func (q *Queries) CountUnusedMFARecoveryCodes(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countUnusedMFARecoveryCodes, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createMFAChallenge = `-- name: CreateMFAChallenge :one
+INSERT INTO mfa_challenges (user_id, token_hash, expires_at)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, token_hash, expires_at, failed_attempts, used_at, created_at
+`
+
+type CreateMFAChallengeParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) (MfaChallenge, error) {
+	row := q.db.QueryRow(ctx, createMFAChallenge, arg.UserID, arg.TokenHash, arg.ExpiresAt)
+	var i MfaChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.FailedAttempts,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createMFARecoveryCode = `-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (user_id, code_hash)
+VALUES ($1, $2)
+`
+
+type CreateMFARecoveryCodeParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	CodeHash []byte      `json:"code_hash"`
+}
+
+func (q *Queries) CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) error {
+	_, err := q.db.Exec(ctx, createMFARecoveryCode, arg.UserID, arg.CodeHash)
+	return err
+}
+
+const deleteExpiredMFAChallenges = `-- name: DeleteExpiredMFAChallenges :execrows
+DELETE FROM mfa_challenges
+WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredMFAChallenges(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredMFAChallenges)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteMFARecoveryCodes = `-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteMFARecoveryCodes(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteMFARecoveryCodes, userID)
+	return err
+}
+
+const deleteUserMFA = `-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserMFA(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserMFA, userID)
+	return err
+}
+
+const enableMFA = `-- name: EnableMFA :execrows
+UPDATE user_mfa
+SET enabled_at = NOW(),
+    last_used_step = $2
+WHERE user_id = $1
+  AND enabled_at IS NULL
+`
+
+type EnableMFAParams struct {
+	UserID       pgtype.UUID `json:"user_id"`
+	LastUsedStep int64       `json:"last_used_step"`
+}
+
+func (q *Queries) EnableMFA(ctx context.Context, arg EnableMFAParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enableMFA, arg.UserID, arg.LastUsedStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getMFAChallengeByHash = `-- name: GetMFAChallengeByHash :one
+SELECT id, user_id, token_hash, expires_at, failed_attempts, used_at, created_at FROM mfa_challenges
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+`
+
+func (q *Queries) GetMFAChallengeByHash(ctx context.Context, tokenHash []byte) (MfaChallenge, error) {
+	row := q.db.QueryRow(ctx, getMFAChallengeByHash, tokenHash)
+	var i MfaChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.FailedAttempts,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserMFA = `-- name: GetUserMFA :one
+SELECT user_id, encrypted_secret, enabled_at, last_used_step, created_at FROM user_mfa
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserMFA(ctx context.Context, userID pgtype.UUID) (UserMfa, error) {
+	row := q.db.QueryRow(ctx, getUserMFA, userID)
+	var i UserMfa
+	err := row.Scan(
+		&i.UserID,
+		&i.EncryptedSecret,
+		&i.EnabledAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const recordMFAChallengeFailure = `-- name: RecordMFAChallengeFailure :one
+UPDATE mfa_challenges
+SET failed_attempts = failed_attempts + 1,
+    used_at = CASE WHEN failed_attempts + 1 >= $1::INTEGER THEN NOW() ELSE used_at END
+WHERE id = $2
+RETURNING failed_attempts
+`
+
+type RecordMFAChallengeFailureParams struct {
+	MaxAttempts int32       `json:"max_attempts"`
+	ID          pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) RecordMFAChallengeFailure(ctx context.Context, arg RecordMFAChallengeFailureParams) (int32, error) {
+	row := q.db.QueryRow(ctx, recordMFAChallengeFailure, arg.MaxAttempts, arg.ID)
+	var failed_attempts int32
+	err := row.Scan(&failed_attempts)
+	return failed_attempts, err
+}
+
+const upsertPendingMFA = `-- name: UpsertPendingMFA :one
+INSERT INTO user_mfa (user_id, encrypted_secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET encrypted_secret = EXCLUDED.encrypted_secret,
+    last_used_step = 0,
+    created_at = NOW()
+WHERE user_mfa.enabled_at IS NULL
+RETURNING user_id, encrypted_secret, enabled_at, last_used_step, created_at
+`
+
+type UpsertPendingMFAParams struct {
+	UserID          pgtype.UUID `json:"user_id"`
+	EncryptedSecret []byte      `json:"encrypted_secret"`
+}
+
+func (q *Queries) UpsertPendingMFA(ctx context.Context, arg UpsertPendingMFAParams) (UserMfa, error) {
+	row := q.db.QueryRow(ctx, upsertPendingMFA, arg.UserID, arg.EncryptedSecret)
+	var i UserMfa
+	err := row.Scan(
+		&i.UserID,
+		&i.EncryptedSecret,
+		&i.EnabledAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useMFAChallenge = `-- name: UseMFAChallenge :execrows
+UPDATE mfa_challenges
+SET used_at = NOW()
+WHERE id = $1
+  AND used_at IS NULL
+`
+
+func (q *Queries) UseMFAChallenge(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, useMFAChallenge, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useMFARecoveryCode = `-- name: UseMFARecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL
+`
+
+type UseMFARecoveryCodeParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	CodeHash []byte      `json:"code_hash"`
+}
+
+func (q *Queries) UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useMFARecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useMFAStep = `-- name: UseMFAStep :execrows
+UPDATE user_mfa
+SET last_used_step = $2
+WHERE user_id = $1
+  AND last_used_step < $2
+`
+
+type UseMFAStepParams struct {
+	UserID       pgtype.UUID `json:"user_id"`
+	LastUsedStep int64       `json:"last_used_step"`
+}
+
+func (q *Queries) UseMFAStep(ctx context.Context, arg UseMFAStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useMFAStep, arg.UserID, arg.LastUsedStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,12 @@
+-- Enable btree_gist so that scalar columns (room_id) can be used in a GiST exclusion constraint
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Prevent double-booking: the same room cannot have two active reservations
+-- whose [start_date, end_date) ranges overlap. Cancelled reservations release the room.
+ALTER TABLE reservations
+    ADD CONSTRAINT reservations_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        tsrange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status <> 'CANCELLED');
@@ -0,0 +1,46 @@
+-- Create properties table (a villa or building owned by an owner account)
+CREATE TABLE IF NOT EXISTS properties (
+    id BIGSERIAL PRIMARY KEY,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_properties_owner_id ON properties(owner_id);
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_properties_updated_at BEFORE UPDATE ON properties
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create rooms table (the bookable unit referenced by reservations.room_id)
+CREATE TABLE IF NOT EXISTS rooms (
+    id BIGSERIAL PRIMARY KEY,
+    property_id BIGINT NOT NULL REFERENCES properties(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    amenities TEXT[] NOT NULL DEFAULT '{}',
+    lock_device_id VARCHAR(255),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_rooms_property_id ON rooms(property_id);
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_rooms_updated_at BEFORE UPDATE ON rooms
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Reservations must now point at a catalogued room.
+-- NOT VALID keeps rows created before the catalog existed, while enforcing the rule for new rows.
+ALTER TABLE reservations
+    ADD CONSTRAINT reservations_room_id_fkey
+    FOREIGN KEY (room_id) REFERENCES rooms(id) NOT VALID;
+
+CREATE INDEX IF NOT EXISTS idx_reservations_room_id ON reservations(room_id);
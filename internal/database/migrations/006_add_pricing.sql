@@ -0,0 +1,23 @@
+-- Per-room pricing configuration
+ALTER TABLE rooms
+    ADD COLUMN base_rate BIGINT NOT NULL DEFAULT 50000 CHECK (base_rate >= 0),
+    ADD COLUMN min_stay_nights INTEGER NOT NULL DEFAULT 1 CHECK (min_stay_nights >= 1);
+
+-- Create seasonal_rates table (overrides the base rate for nights in [start_date, end_date))
+CREATE TABLE IF NOT EXISTS seasonal_rates (
+    id BIGSERIAL PRIMARY KEY,
+    room_id BIGINT NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    nightly_rate BIGINT NOT NULL CHECK (nightly_rate >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (end_date > start_date)
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_seasonal_rates_room_id ON seasonal_rates(room_id);
+
+-- Itemized price breakdown (JSON array of line items) computed at booking time
+ALTER TABLE reservations
+    ADD COLUMN price_breakdown JSONB NOT NULL DEFAULT '[]';
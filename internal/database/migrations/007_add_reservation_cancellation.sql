@@ -0,0 +1,4 @@
+-- Cancellation details (the fee is charged according to the cancellation policy in effect)
+ALTER TABLE reservations
+    ADD COLUMN cancellation_fee BIGINT NOT NULL DEFAULT 0 CHECK (cancellation_fee >= 0),
+    ADD COLUMN cancelled_at TIMESTAMP;
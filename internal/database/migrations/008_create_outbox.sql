@@ -0,0 +1,16 @@
+-- Create outbox table (transactional outbox for reservation events)
+-- Rows are written in the same transaction as the reservation change and
+-- published to Pub/Sub by the relay worker in reservation-service.
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP
+);
+
+-- Create index for the relay's polling query (pending rows only)
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(next_attempt_at) WHERE sent_at IS NULL;
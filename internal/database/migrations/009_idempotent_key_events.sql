@@ -0,0 +1,18 @@
+-- Create processed_events table (events already handled by the key-service consumer)
+-- Keyed by the outbox event ID (or the Pub/Sub message ID for events without one),
+-- so that redelivered messages are acknowledged without being processed again.
+CREATE TABLE IF NOT EXISTS processed_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Remove duplicate keys created by redelivered events (keep the first key of each reservation)
+DELETE FROM keys k
+USING keys older
+WHERE k.reservation_id = older.reservation_id
+  AND (older.created_at, older.id) < (k.created_at, k.id);
+
+-- One key per reservation (revoked keys are deleted, so every remaining key is active)
+DROP INDEX IF EXISTS idx_keys_reservation_id;
+CREATE UNIQUE INDEX IF NOT EXISTS keys_one_active_per_reservation ON keys(reservation_id);
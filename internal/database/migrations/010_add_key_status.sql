@@ -0,0 +1,12 @@
+-- Key lifecycle: ACTIVE -> REVOKED (cancellation, check-out, owner action) or EXPIRED
+ALTER TABLE keys
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'REVOKED', 'EXPIRED')),
+    ADD COLUMN revoked_at TIMESTAMP,
+    ADD COLUMN revoke_reason VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_keys_status ON keys(status);
+
+-- Revoked keys are kept for auditing, so only one ACTIVE key per reservation is enforced
+DROP INDEX IF EXISTS keys_one_active_per_reservation;
+CREATE UNIQUE INDEX IF NOT EXISTS keys_one_active_per_reservation ON keys(reservation_id) WHERE status = 'ACTIVE';
+CREATE INDEX IF NOT EXISTS idx_keys_reservation_id ON keys(reservation_id);
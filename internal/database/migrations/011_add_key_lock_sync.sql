@@ -0,0 +1,6 @@
+-- Outcome of the last call to the smart-lock provider for each key.
+-- provider_synced_at is set when the lock accepted the code (ACTIVE) or removed it (REVOKED);
+-- provider_error holds the last failure and is cleared on success.
+ALTER TABLE keys
+    ADD COLUMN provider_synced_at TIMESTAMP,
+    ADD COLUMN provider_error TEXT;
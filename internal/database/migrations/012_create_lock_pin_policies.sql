@@ -0,0 +1,16 @@
+-- Create lock_pin_policies table (PIN format per smart lock device)
+-- Devices without a row use the key-service defaults (PIN_LENGTH, PIN_REUSE_COOLDOWN_DAYS).
+CREATE TABLE IF NOT EXISTS lock_pin_policies (
+    device_id VARCHAR(255) PRIMARY KEY,
+    pin_length INTEGER NOT NULL DEFAULT 4 CHECK (pin_length BETWEEN 4 AND 10),
+    reuse_cooldown_days INTEGER NOT NULL DEFAULT 30 CHECK (reuse_cooldown_days >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_lock_pin_policies_updated_at BEFORE UPDATE ON lock_pin_policies
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Speed up PIN collision checks (same code on the same device)
+CREATE INDEX IF NOT EXISTS idx_keys_device_id_key_code ON keys(device_id, key_code);
@@ -0,0 +1,7 @@
+-- Keys are marked EXPIRED by the key-service scheduler once valid_until has passed
+ALTER TABLE keys
+    ADD COLUMN expired_at TIMESTAMP;
+
+-- Speed up the scheduler's scans for expired keys and finished stays
+CREATE INDEX IF NOT EXISTS idx_keys_active_valid_until ON keys(valid_until) WHERE status = 'ACTIVE';
+CREATE INDEX IF NOT EXISTS idx_reservations_confirmed_end_date ON reservations(end_date) WHERE status = 'CONFIRMED';
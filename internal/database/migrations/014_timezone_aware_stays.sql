@@ -0,0 +1,39 @@
+-- Property-local check-in and check-out times (e.g., 15:00 / 10:00 in Asia/Tokyo)
+ALTER TABLE properties
+    ADD COLUMN check_in_time TIME NOT NULL DEFAULT '15:00',
+    ADD COLUMN check_out_time TIME NOT NULL DEFAULT '10:00';
+
+-- Exact check-in and check-out instants of each stay.
+-- start_date / end_date keep the booked calendar dates (midnight UTC).
+ALTER TABLE reservations
+    ADD COLUMN check_in_at TIMESTAMPTZ,
+    ADD COLUMN check_out_at TIMESTAMPTZ;
+
+UPDATE reservations r
+SET check_in_at = (r.start_date::date + p.check_in_time) AT TIME ZONE p.timezone,
+    check_out_at = (r.end_date::date + p.check_out_time) AT TIME ZONE p.timezone
+FROM rooms rm
+JOIN properties p ON p.id = rm.property_id
+WHERE rm.id = r.room_id;
+
+-- Reservations made before the catalog existed use the default times in Asia/Tokyo
+UPDATE reservations
+SET check_in_at = (start_date::date + TIME '15:00') AT TIME ZONE 'Asia/Tokyo',
+    check_out_at = (end_date::date + TIME '10:00') AT TIME ZONE 'Asia/Tokyo'
+WHERE check_in_at IS NULL;
+
+ALTER TABLE reservations
+    ALTER COLUMN check_in_at SET NOT NULL,
+    ALTER COLUMN check_out_at SET NOT NULL;
+
+-- Stays are completed at check-out rather than at midnight of the end date
+DROP INDEX IF EXISTS idx_reservations_confirmed_end_date;
+CREATE INDEX IF NOT EXISTS idx_reservations_confirmed_check_out_at ON reservations(check_out_at) WHERE status = 'CONFIRMED';
+
+-- Key timestamps are exact instants; existing values were written in UTC
+ALTER TABLE keys
+    ALTER COLUMN valid_from TYPE TIMESTAMPTZ USING valid_from AT TIME ZONE 'UTC',
+    ALTER COLUMN valid_until TYPE TIMESTAMPTZ USING valid_until AT TIME ZONE 'UTC',
+    ALTER COLUMN revoked_at TYPE TIMESTAMPTZ USING revoked_at AT TIME ZONE 'UTC',
+    ALTER COLUMN expired_at TYPE TIMESTAMPTZ USING expired_at AT TIME ZONE 'UTC',
+    ALTER COLUMN provider_synced_at TYPE TIMESTAMPTZ USING provider_synced_at AT TIME ZONE 'UTC';
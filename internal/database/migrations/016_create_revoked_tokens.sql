@@ -0,0 +1,13 @@
+-- Create revoked_tokens table (access tokens revoked before they expire, by jti claim)
+-- Rows are only needed until the token would have expired anyway and are purged after that.
+-- Tokens of revoked sessions are rejected through sessions.revoked_at instead.
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Speed up purging expired rows
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
@@ -0,0 +1,15 @@
+-- Create signing_keys table (asymmetric JWT signing keys, identified by the kid header)
+-- A key signs tokens from not_before on. When it is rotated out, not_after is set to the end
+-- of the overlap period; until then it still verifies tokens and is published in the JWKS.
+-- private_key is PKCS #8 DER encrypted with AES-GCM (key derived from JWT_SECRET).
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(16) NOT NULL CHECK (algorithm IN ('EdDSA', 'RS256')),
+    private_key BYTEA NOT NULL,
+    not_before TIMESTAMPTZ NOT NULL,
+    not_after TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Speed up loading the keys that still verify tokens
+CREATE INDEX IF NOT EXISTS idx_signing_keys_not_after ON signing_keys(not_after);
@@ -0,0 +1,3 @@
+-- Speed up listing recent revocations (polled by the API Gateway, see ListRevocations)
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_revoked_at ON revoked_tokens(revoked_at);
+CREATE INDEX IF NOT EXISTS idx_sessions_revoked_at ON sessions(revoked_at) WHERE revoked_at IS NOT NULL;
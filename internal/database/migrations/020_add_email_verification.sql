@@ -0,0 +1,22 @@
+-- Add email verification to users
+-- Accounts created before verification existed are treated as verified
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+-- Create email_verification_tokens table (one-time links sent at signup)
+-- Only the SHA-256 hash of a token is stored. A token is consumed by setting used_at;
+-- sending a new link consumes every other open token of the user.
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Speed up consuming the open tokens of a user
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens(user_id);
+
+-- Speed up purging expired tokens
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_expires_at ON email_verification_tokens(expires_at);
@@ -0,0 +1,38 @@
+-- Create user_mfa table (TOTP multi-factor authentication)
+-- The secret is encrypted with a key derived from JWT_SECRET. enabled_at is NULL until
+-- the user confirms enrollment with a first code. last_used_step is the TOTP time step
+-- of the last accepted code; codes from that step or earlier are rejected as replays.
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    encrypted_secret BYTEA NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create mfa_recovery_codes table (single-use codes for a lost authenticator)
+-- Only the SHA-256 hash of a code is stored.
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash BYTEA NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
+
+-- Create mfa_challenges table (second step of a login after the password was checked)
+-- Only the SHA-256 hash of the challenge token is stored. A challenge is consumed by
+-- completing the login or by too many wrong codes.
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Speed up purging expired challenges
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_expires_at ON mfa_challenges(expires_at);
@@ -0,0 +1,45 @@
+-- Create login_attempts table (failed logins per email address and per client IP)
+-- key is "email:<address>" or "ip:<address>". failures counts the failures since the
+-- count last restarted; a login is refused until blocked_until.
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key TEXT PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    blocked_until TIMESTAMPTZ
+);
+
+-- Speed up purging stale rows
+CREATE INDEX IF NOT EXISTS idx_login_attempts_last_failure_at ON login_attempts(last_failure_at);
+
+-- Create account_unlock_tokens table (one-time links sent when an account is locked out)
+-- Only the SHA-256 hash of a token is stored. A token is consumed by setting used_at.
+CREATE TABLE IF NOT EXISTS account_unlock_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Speed up consuming the open tokens of a user
+CREATE INDEX IF NOT EXISTS idx_account_unlock_tokens_user_id ON account_unlock_tokens(user_id);
+
+-- Speed up purging expired tokens
+CREATE INDEX IF NOT EXISTS idx_account_unlock_tokens_expires_at ON account_unlock_tokens(expires_at);
+
+-- Create audit_events table (security-relevant events such as lockouts)
+-- user_id is NULL for events that are not tied to an account (e.g., an IP lockout).
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(100) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    subject TEXT NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Speed up listing the events of a user and of a type
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events(user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_event_type_created_at ON audit_events(event_type, created_at);
@@ -0,0 +1,12 @@
+-- Create rate_limit_buckets table (token buckets of the API Gateway rate limiter, shared by all instances)
+-- key is "<route group>:user:<id>" or "<route group>:ip:<address>". tokens is the bucket level at
+-- updated_at; allowed records whether the latest request took a token.
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Speed up purging idle buckets
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
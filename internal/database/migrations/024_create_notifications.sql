@@ -0,0 +1,18 @@
+-- Create notifications table (messages shown to a user, e.g. when a reservation is confirmed)
+-- Notifications are written by the Reservation Service in the same transaction as the change
+-- they report. A notification is read once read_at is set.
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reservation_id UUID REFERENCES reservations(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Speed up listing the notifications of a user, newest first
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_created_at ON notifications(user_id, created_at DESC);
+
+-- Speed up counting unread notifications
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,8 @@
+-- Add profile fields to users
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT 'ja';
+
+-- Email changes: a verification token with an email is for a new address, which replaces
+-- users.email when the token is used. Tokens without one verify the current address (signup).
+ALTER TABLE email_verification_tokens ADD COLUMN IF NOT EXISTS email VARCHAR(255);
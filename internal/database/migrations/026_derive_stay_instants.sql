@@ -0,0 +1,21 @@
+-- A reservation keeps both its booked calendar dates and the exact instants of the stay:
+-- availability, pricing (per night) and the overlap constraint work on start_date / end_date,
+-- while keys, cancellation deadlines and stay completion need check_in_at / check_out_at.
+-- The dates are the source of truth. The instants are always derived from them here, at the
+-- property's local check-in/check-out times (as in 014), so the two can never disagree.
+-- Later changes to the property's times do not move existing reservations.
+CREATE OR REPLACE FUNCTION set_reservation_stay_instants()
+RETURNS TRIGGER AS $$
+BEGIN
+    SELECT (NEW.start_date::date + p.check_in_time) AT TIME ZONE p.timezone,
+           (NEW.end_date::date + p.check_out_time) AT TIME ZONE p.timezone
+    INTO NEW.check_in_at, NEW.check_out_at
+    FROM rooms rm
+    JOIN properties p ON p.id = rm.property_id
+    WHERE rm.id = NEW.room_id;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER set_reservations_stay_instants BEFORE INSERT OR UPDATE OF start_date, end_date, room_id ON reservations
+    FOR EACH ROW EXECUTE FUNCTION set_reservation_stay_instants();
@@ -8,36 +8,237 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccountUnlockToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type AuditEvent struct {
+	ID        pgtype.UUID        `json:"id"`
+	EventType string             `json:"event_type"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Subject   string             `json:"subject"`
+	IpAddress string             `json:"ip_address"`
+	Details   []byte             `json:"details"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type EmailVerificationToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Email     pgtype.Text        `json:"email"`
+}
+
 type Key struct {
+	ID               pgtype.UUID        `json:"id"`
+	ReservationID    pgtype.UUID        `json:"reservation_id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	KeyCode          string             `json:"key_code"`
+	DeviceID         string             `json:"device_id"`
+	ValidFrom        pgtype.Timestamptz `json:"valid_from"`
+	ValidUntil       pgtype.Timestamptz `json:"valid_until"`
+	CreatedAt        pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt        pgtype.Timestamp   `json:"updated_at"`
+	Status           string             `json:"status"`
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
+	RevokeReason     pgtype.Text        `json:"revoke_reason"`
+	ProviderSyncedAt pgtype.Timestamptz `json:"provider_synced_at"`
+	ProviderError    pgtype.Text        `json:"provider_error"`
+	ExpiredAt        pgtype.Timestamptz `json:"expired_at"`
+}
+
+type LockPinPolicy struct {
+	DeviceID          string           `json:"device_id"`
+	PinLength         int32            `json:"pin_length"`
+	ReuseCooldownDays int32            `json:"reuse_cooldown_days"`
+	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	UpdatedAt         pgtype.Timestamp `json:"updated_at"`
+}
+
+type LoginAttempt struct {
+	Key           string             `json:"key"`
+	Failures      int32              `json:"failures"`
+	LastFailureAt pgtype.Timestamptz `json:"last_failure_at"`
+	BlockedUntil  pgtype.Timestamptz `json:"blocked_until"`
+}
+
+type MfaChallenge struct {
+	ID             pgtype.UUID        `json:"id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	TokenHash      []byte             `json:"token_hash"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	FailedAttempts int32              `json:"failed_attempts"`
+	UsedAt         pgtype.Timestamptz `json:"used_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type MfaRecoveryCode struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CodeHash  []byte             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Notification struct {
+	ID            pgtype.UUID        `json:"id"`
+	UserID        pgtype.UUID        `json:"user_id"`
+	ReservationID pgtype.UUID        `json:"reservation_id"`
+	Type          string             `json:"type"`
+	Message       string             `json:"message"`
+	ReadAt        pgtype.Timestamptz `json:"read_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type Outbox struct {
 	ID            pgtype.UUID      `json:"id"`
-	ReservationID pgtype.UUID      `json:"reservation_id"`
-	UserID        pgtype.UUID      `json:"user_id"`
-	KeyCode       string           `json:"key_code"`
-	DeviceID      string           `json:"device_id"`
-	ValidFrom     pgtype.Timestamp `json:"valid_from"`
-	ValidUntil    pgtype.Timestamp `json:"valid_until"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	Attempts      int32            `json:"attempts"`
+	LastError     pgtype.Text      `json:"last_error"`
+	NextAttemptAt pgtype.Timestamp `json:"next_attempt_at"`
 	CreatedAt     pgtype.Timestamp `json:"created_at"`
-	UpdatedAt     pgtype.Timestamp `json:"updated_at"`
+	SentAt        pgtype.Timestamp `json:"sent_at"`
+}
+
+type PasswordResetToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ProcessedEvent struct {
+	EventID     string           `json:"event_id"`
+	EventType   string           `json:"event_type"`
+	ProcessedAt pgtype.Timestamp `json:"processed_at"`
+}
+
+type Property struct {
+	ID           int64            `json:"id"`
+	OwnerID      pgtype.UUID      `json:"owner_id"`
+	Name         string           `json:"name"`
+	Address      string           `json:"address"`
+	Timezone     string           `json:"timezone"`
+	IsActive     bool             `json:"is_active"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	CheckInTime  pgtype.Time      `json:"check_in_time"`
+	CheckOutTime pgtype.Time      `json:"check_out_time"`
+}
+
+type RateLimitBucket struct {
+	Key       string             `json:"key"`
+	Tokens    float64            `json:"tokens"`
+	Allowed   bool               `json:"allowed"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type RefreshToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	SessionID pgtype.UUID        `json:"session_id"`
+	TokenHash []byte             `json:"token_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type Reservation struct {
-	ID         pgtype.UUID      `json:"id"`
-	UserID     pgtype.UUID      `json:"user_id"`
-	RoomID     int64            `json:"room_id"`
-	StartDate  pgtype.Timestamp `json:"start_date"`
-	EndDate    pgtype.Timestamp `json:"end_date"`
-	TotalPrice int64            `json:"total_price"`
-	Status     string           `json:"status"`
-	CreatedAt  pgtype.Timestamp `json:"created_at"`
-	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+	ID              pgtype.UUID        `json:"id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	RoomID          int64              `json:"room_id"`
+	StartDate       pgtype.Timestamp   `json:"start_date"`
+	EndDate         pgtype.Timestamp   `json:"end_date"`
+	TotalPrice      int64              `json:"total_price"`
+	Status          string             `json:"status"`
+	CreatedAt       pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp   `json:"updated_at"`
+	PriceBreakdown  []byte             `json:"price_breakdown"`
+	CancellationFee int64              `json:"cancellation_fee"`
+	CancelledAt     pgtype.Timestamp   `json:"cancelled_at"`
+	CheckInAt       pgtype.Timestamptz `json:"check_in_at"`
+	CheckOutAt      pgtype.Timestamptz `json:"check_out_at"`
+}
+
+type RevokedToken struct {
+	Jti       string             `json:"jti"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	Reason    string             `json:"reason"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type Room struct {
+	ID            int64            `json:"id"`
+	PropertyID    int64            `json:"property_id"`
+	Name          string           `json:"name"`
+	Capacity      int32            `json:"capacity"`
+	Amenities     []string         `json:"amenities"`
+	LockDeviceID  pgtype.Text      `json:"lock_device_id"`
+	IsActive      bool             `json:"is_active"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	UpdatedAt     pgtype.Timestamp `json:"updated_at"`
+	BaseRate      int64            `json:"base_rate"`
+	MinStayNights int32            `json:"min_stay_nights"`
+}
+
+type SeasonalRate struct {
+	ID          int64            `json:"id"`
+	RoomID      int64            `json:"room_id"`
+	Name        string           `json:"name"`
+	StartDate   pgtype.Date      `json:"start_date"`
+	EndDate     pgtype.Date      `json:"end_date"`
+	NightlyRate int64            `json:"nightly_rate"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type Session struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	UserAgent    string             `json:"user_agent"`
+	IpAddress    string             `json:"ip_address"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt    pgtype.Timestamptz `json:"revoked_at"`
+	RevokeReason pgtype.Text        `json:"revoke_reason"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type SigningKey struct {
+	Kid        string             `json:"kid"`
+	Algorithm  string             `json:"algorithm"`
+	PrivateKey []byte             `json:"private_key"`
+	NotBefore  pgtype.Timestamptz `json:"not_before"`
+	NotAfter   pgtype.Timestamptz `json:"not_after"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
 type User struct {
-	ID             pgtype.UUID      `json:"id"`
-	Email          string           `json:"email"`
-	HashedPassword []byte           `json:"hashed_password"`
-	Name           string           `json:"name"`
-	Role           string           `json:"role"`
-	CreatedAt      pgtype.Timestamp `json:"created_at"`
-	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	ID              pgtype.UUID        `json:"id"`
+	Email           string             `json:"email"`
+	HashedPassword  []byte             `json:"hashed_password"`
+	Name            string             `json:"name"`
+	Role            string             `json:"role"`
+	CreatedAt       pgtype.Timestamp   `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp   `json:"updated_at"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+	Phone           string             `json:"phone"`
+	Locale          string             `json:"locale"`
+}
+
+type UserMfa struct {
+	UserID          pgtype.UUID        `json:"user_id"`
+	EncryptedSecret []byte             `json:"encrypted_secret"`
+	EnabledAt       pgtype.Timestamptz `json:"enabled_at"`
+	LastUsedStep    int64              `json:"last_used_step"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 }
@@ -0,0 +1,130 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: notifications.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countUnreadNotifications = `-- name: CountUnreadNotifications :one
+SELECT COUNT(*)
+FROM notifications
+WHERE user_id = $1
+  AND read_at IS NULL
+`
+
+func (q *Queries) CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countUnreadNotifications, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createNotification = `-- name: CreateNotification :one
+INSERT INTO notifications (user_id, reservation_id, type, message)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, reservation_id, type, message, read_at, created_at
+`
+
+type CreateNotificationParams struct {
+	UserID        pgtype.UUID `json:"user_id"`
+	ReservationID pgtype.UUID `json:"reservation_id"`
+	Type          string      `json:"type"`
+	Message       string      `json:"message"`
+}
+
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
+	row := q.db.QueryRow(ctx, createNotification,
+		arg.UserID,
+		arg.ReservationID,
+		arg.Type,
+		arg.Message,
+	)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ReservationID,
+		&i.Type,
+		&i.Message,
+		&i.ReadAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listNotificationsByUserID = `-- name: ListNotificationsByUserID :many
+SELECT id, user_id, reservation_id, type, message, read_at, created_at
+FROM notifications
+WHERE user_id = $1
+  AND (NOT $2::BOOLEAN OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type ListNotificationsByUserIDParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	UnreadOnly bool        `json:"unread_only"`
+	MaxResults int32       `json:"max_results"`
+}
+
+func (q *Queries) ListNotificationsByUserID(ctx context.Context, arg ListNotificationsByUserIDParams) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, listNotificationsByUserID, arg.UserID, arg.UnreadOnly, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ReservationID,
+			&i.Type,
+			&i.Message,
+			&i.ReadAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markNotificationRead = `-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1
+  AND user_id = $2
+RETURNING id, user_id, reservation_id, type, message, read_at, created_at
+`
+
+type MarkNotificationReadParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error) {
+	row := q.db.QueryRow(ctx, markNotificationRead, arg.ID, arg.UserID)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ReservationID,
+		&i.Type,
+		&i.Message,
+		&i.ReadAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
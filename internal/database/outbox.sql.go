@@ -0,0 +1,108 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: outbox.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :one
+INSERT INTO outbox (event_type, payload)
+VALUES ($1, $2)
+RETURNING id, event_type, payload, attempts, last_error, next_attempt_at, created_at, sent_at
+`
+
+type CreateOutboxEventParams struct {
+	EventType string `json:"event_type"`
+	Payload   []byte `json:"payload"`
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (Outbox, error) {
+	row := q.db.QueryRow(ctx, createOutboxEvent, arg.EventType, arg.Payload)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.CreatedAt,
+		&i.SentAt,
+	)
+	return i, err
+}
+
+const listPendingOutboxEvents = `-- name: ListPendingOutboxEvents :many
+SELECT id, event_type, payload, attempts, last_error, next_attempt_at, created_at, sent_at
+FROM outbox
+WHERE sent_at IS NULL
+  AND next_attempt_at <= NOW()
+ORDER BY created_at
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+func (q *Queries) ListPendingOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, listPendingOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Outbox
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = $1,
+    next_attempt_at = NOW() + ($2::INTEGER * INTERVAL '1 second')
+WHERE id = $3
+`
+
+type MarkOutboxEventFailedParams struct {
+	LastError      pgtype.Text `json:"last_error"`
+	BackoffSeconds int32       `json:"backoff_seconds"`
+	ID             pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error {
+	_, err := q.db.Exec(ctx, markOutboxEventFailed, arg.LastError, arg.BackoffSeconds, arg.ID)
+	return err
+}
+
+const markOutboxEventSent = `-- name: MarkOutboxEventSent :exec
+UPDATE outbox
+SET sent_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventSent(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markOutboxEventSent, id)
+	return err
+}
@@ -0,0 +1,167 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: properties.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createProperty = `-- name: CreateProperty :one
+INSERT INTO properties (owner_id, name, address, timezone, check_in_time, check_out_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time
+`
+
+type CreatePropertyParams struct {
+	OwnerID      pgtype.UUID `json:"owner_id"`
+	Name         string      `json:"name"`
+	Address      string      `json:"address"`
+	Timezone     string      `json:"timezone"`
+	CheckInTime  pgtype.Time `json:"check_in_time"`
+	CheckOutTime pgtype.Time `json:"check_out_time"`
+}
+
+func (q *Queries) CreateProperty(ctx context.Context, arg CreatePropertyParams) (Property, error) {
+	row := q.db.QueryRow(ctx, createProperty,
+		arg.OwnerID,
+		arg.Name,
+		arg.Address,
+		arg.Timezone,
+		arg.CheckInTime,
+		arg.CheckOutTime,
+	)
+	var i Property
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Address,
+		&i.Timezone,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CheckInTime,
+		&i.CheckOutTime,
+	)
+	return i, err
+}
+
+const deleteProperty = `-- name: DeleteProperty :exec
+DELETE FROM properties
+WHERE id = $1
+`
+
+func (q *Queries) DeleteProperty(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteProperty, id)
+	return err
+}
+
+const getProperty = `-- name: GetProperty :one
+SELECT id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time
+FROM properties
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetProperty(ctx context.Context, id int64) (Property, error) {
+	row := q.db.QueryRow(ctx, getProperty, id)
+	var i Property
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Address,
+		&i.Timezone,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CheckInTime,
+		&i.CheckOutTime,
+	)
+	return i, err
+}
+
+const listPropertiesByOwnerID = `-- name: ListPropertiesByOwnerID :many
+SELECT id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time
+FROM properties
+WHERE owner_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListPropertiesByOwnerID(ctx context.Context, ownerID pgtype.UUID) ([]Property, error) {
+	rows, err := q.db.Query(ctx, listPropertiesByOwnerID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Property
+	for rows.Next() {
+		var i Property
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.Address,
+			&i.Timezone,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CheckInTime,
+			&i.CheckOutTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProperty = `-- name: UpdateProperty :one
+UPDATE properties
+SET name = $2, address = $3, timezone = $4, is_active = $5, check_in_time = $6, check_out_time = $7, updated_at = NOW()
+WHERE id = $1
+RETURNING id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time
+`
+
+type UpdatePropertyParams struct {
+	ID           int64       `json:"id"`
+	Name         string      `json:"name"`
+	Address      string      `json:"address"`
+	Timezone     string      `json:"timezone"`
+	IsActive     bool        `json:"is_active"`
+	CheckInTime  pgtype.Time `json:"check_in_time"`
+	CheckOutTime pgtype.Time `json:"check_out_time"`
+}
+
+func (q *Queries) UpdateProperty(ctx context.Context, arg UpdatePropertyParams) (Property, error) {
+	row := q.db.QueryRow(ctx, updateProperty,
+		arg.ID,
+		arg.Name,
+		arg.Address,
+		arg.Timezone,
+		arg.IsActive,
+		arg.CheckInTime,
+		arg.CheckOutTime,
+	)
+	var i Property
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Address,
+		&i.Timezone,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CheckInTime,
+		&i.CheckOutTime,
+	)
+	return i, err
+}
@@ -11,17 +11,118 @@ import (
 )
 
 type Querier interface {
+	AddLoginFailure(ctx context.Context, arg AddLoginFailureParams) (int32, error)
+	BlockLoginKey(ctx context.Context, arg BlockLoginKeyParams) error
+	CancelReservation(ctx context.Context, arg CancelReservationParams) (Reservation, error)
+	ChangeUserEmail(ctx context.Context, arg ChangeUserEmailParams) error
+	CompleteFinishedReservations(ctx context.Context, limit int32) ([]Reservation, error)
+	CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUnusedMFARecoveryCodes(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CreateAccountUnlockToken(ctx context.Context, arg CreateAccountUnlockTokenParams) (AccountUnlockToken, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error)
 	CreateKey(ctx context.Context, arg CreateKeyParams) (Key, error)
+	CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) (MfaChallenge, error)
+	CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) error
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (Outbox, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
+	CreateProperty(ctx context.Context, arg CreatePropertyParams) (Property, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
 	CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error)
+	CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error)
+	CreateSeasonalRate(ctx context.Context, arg CreateSeasonalRateParams) (SeasonalRate, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) (SigningKey, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteExpiredAccountUnlockTokens(ctx context.Context) (int64, error)
+	DeleteExpiredEmailVerificationTokens(ctx context.Context) (int64, error)
+	DeleteExpiredMFAChallenges(ctx context.Context) (int64, error)
+	DeleteExpiredPasswordResetTokens(ctx context.Context) (int64, error)
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+	DeleteExpiredSigningKeys(ctx context.Context) (int64, error)
+	DeleteIdleRateLimitBuckets(ctx context.Context, idleSince pgtype.Timestamptz) (int64, error)
+	DeleteLoginAttempt(ctx context.Context, key string) error
+	DeleteMFARecoveryCodes(ctx context.Context, userID pgtype.UUID) error
+	DeleteProperty(ctx context.Context, id int64) error
+	DeleteRoom(ctx context.Context, id int64) error
+	DeleteSeasonalRate(ctx context.Context, id int64) error
+	DeleteStaleLoginAttempts(ctx context.Context, arg DeleteStaleLoginAttemptsParams) (int64, error)
+	DeleteUserMFA(ctx context.Context, userID pgtype.UUID) error
+	EmailExists(ctx context.Context, email string) (bool, error)
+	EnableMFA(ctx context.Context, arg EnableMFAParams) (int64, error)
+	ExpireKeys(ctx context.Context) (int64, error)
+	ExtendSession(ctx context.Context, arg ExtendSessionParams) (Session, error)
 	GetKeyByReservationID(ctx context.Context, reservationID pgtype.UUID) (Key, error)
+	GetLockPinPolicy(ctx context.Context, deviceID string) (LockPinPolicy, error)
+	GetLoginAttempt(ctx context.Context, key string) (LoginAttempt, error)
+	GetMFAChallengeByHash(ctx context.Context, tokenHash []byte) (MfaChallenge, error)
+	GetPendingEmailChange(ctx context.Context, userID pgtype.UUID) (string, error)
+	GetProperty(ctx context.Context, id int64) (Property, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash []byte) (RefreshToken, error)
 	GetReservation(ctx context.Context, id pgtype.UUID) (Reservation, error)
+	GetRoom(ctx context.Context, id int64) (Room, error)
+	GetSeasonalRate(ctx context.Context, id int64) (SeasonalRate, error)
+	GetSession(ctx context.Context, id pgtype.UUID) (Session, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserMFA(ctx context.Context, userID pgtype.UUID) (UserMfa, error)
+	InvalidateAccountUnlockTokens(ctx context.Context, userID pgtype.UUID) error
+	InvalidateEmailChangeTokens(ctx context.Context, userID pgtype.UUID) error
+	InvalidateEmailVerificationTokens(ctx context.Context, userID pgtype.UUID) error
+	InvalidatePasswordResetTokens(ctx context.Context, userID pgtype.UUID) error
+	IsEventProcessed(ctx context.Context, eventID string) (bool, error)
+	IsKeyCodeInUse(ctx context.Context, arg IsKeyCodeInUseParams) (bool, error)
+	IsSessionActive(ctx context.Context, id pgtype.UUID) (bool, error)
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	IsUserEmailVerified(ctx context.Context, id pgtype.UUID) (bool, error)
 	ListActiveKeysByUserID(ctx context.Context, userID pgtype.UUID) ([]Key, error)
+	ListActiveSessionsByUserID(ctx context.Context, userID pgtype.UUID) ([]Session, error)
+	ListBookableRooms(ctx context.Context, guests int32) ([]Room, error)
+	ListBookedRoomIDs(ctx context.Context, arg ListBookedRoomIDsParams) ([]int64, error)
 	ListKeysByUserID(ctx context.Context, userID pgtype.UUID) ([]Key, error)
+	ListKeysPendingLockRemoval(ctx context.Context, limit int32) ([]Key, error)
+	ListNotificationsByUserID(ctx context.Context, arg ListNotificationsByUserIDParams) ([]Notification, error)
+	ListPendingOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error)
+	ListPropertiesByOwnerID(ctx context.Context, ownerID pgtype.UUID) ([]Property, error)
 	ListReservationsByUserID(ctx context.Context, userID pgtype.UUID) ([]Reservation, error)
+	ListRoomsByPropertyID(ctx context.Context, propertyID int64) ([]Room, error)
+	ListSeasonalRatesByRoomID(ctx context.Context, roomID int64) ([]SeasonalRate, error)
+	ListSeasonalRatesInRange(ctx context.Context, arg ListSeasonalRatesInRangeParams) ([]SeasonalRate, error)
+	ListSessionRevocationsSince(ctx context.Context, since pgtype.Timestamptz) ([]ListSessionRevocationsSinceRow, error)
+	ListSigningKeys(ctx context.Context) ([]SigningKey, error)
+	ListTokenRevocationsSince(ctx context.Context, since pgtype.Timestamptz) ([]ListTokenRevocationsSinceRow, error)
+	MarkEventProcessed(ctx context.Context, arg MarkEventProcessedParams) error
+	MarkKeyProviderSynced(ctx context.Context, id pgtype.UUID) (Key, error)
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error)
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	MarkOutboxEventSent(ctx context.Context, id pgtype.UUID) error
+	MarkRefreshTokenUsed(ctx context.Context, id pgtype.UUID) (int64, error)
+	MarkUserEmailVerified(ctx context.Context, id pgtype.UUID) error
+	RecordKeyProviderError(ctx context.Context, arg RecordKeyProviderErrorParams) error
+	RecordMFAChallengeFailure(ctx context.Context, arg RecordMFAChallengeFailureParams) (int32, error)
+	RemoveLoginFailure(ctx context.Context, key string) error
+	RetireSigningKeys(ctx context.Context, arg RetireSigningKeysParams) error
+	RevokeActiveKey(ctx context.Context, arg RevokeActiveKeyParams) (Key, error)
+	RevokeSession(ctx context.Context, arg RevokeSessionParams) (int64, error)
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	RevokeUserSessions(ctx context.Context, arg RevokeUserSessionsParams) ([]pgtype.UUID, error)
+	SetLoginFailures(ctx context.Context, arg SetLoginFailuresParams) error
+	TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (TakeRateLimitTokenRow, error)
+	UpdateKeyCode(ctx context.Context, arg UpdateKeyCodeParams) (Key, error)
+	UpdatePendingReservationStatus(ctx context.Context, arg UpdatePendingReservationStatusParams) (Reservation, error)
+	UpdateProperty(ctx context.Context, arg UpdatePropertyParams) (Property, error)
 	UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (Reservation, error)
+	UpdateRoom(ctx context.Context, arg UpdateRoomParams) (Room, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
+	UpsertPendingMFA(ctx context.Context, arg UpsertPendingMFAParams) (UserMfa, error)
+	UseAccountUnlockToken(ctx context.Context, tokenHash []byte) (pgtype.UUID, error)
+	UseEmailVerificationToken(ctx context.Context, tokenHash []byte) (UseEmailVerificationTokenRow, error)
+	UseMFAChallenge(ctx context.Context, id pgtype.UUID) (int64, error)
+	UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (int64, error)
+	UseMFAStep(ctx context.Context, arg UseMFAStepParams) (int64, error)
+	UsePasswordResetToken(ctx context.Context, tokenHash []byte) (pgtype.UUID, error)
 }
 
 var _ Querier = (*Queries)(nil)
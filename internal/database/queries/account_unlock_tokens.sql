@@ -0,0 +1,22 @@
+-- name: CreateAccountUnlockToken :one
+INSERT INTO account_unlock_tokens (user_id, token_hash, expires_at)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, token_hash, expires_at, used_at, created_at;
+
+-- name: UseAccountUnlockToken :one
+UPDATE account_unlock_tokens
+SET used_at = NOW()
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING user_id;
+
+-- name: InvalidateAccountUnlockTokens :exec
+UPDATE account_unlock_tokens
+SET used_at = NOW()
+WHERE user_id = $1
+  AND used_at IS NULL;
+
+-- name: DeleteExpiredAccountUnlockTokens :execrows
+DELETE FROM account_unlock_tokens
+WHERE expires_at <= NOW();
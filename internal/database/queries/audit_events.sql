@@ -0,0 +1,3 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (event_type, user_id, subject, ip_address, details)
+VALUES ($1, $2, $3, $4, $5);
@@ -0,0 +1,40 @@
+-- name: CreateEmailVerificationToken :one
+INSERT INTO email_verification_tokens (user_id, token_hash, expires_at, email)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, token_hash, expires_at, used_at, created_at, email;
+
+-- name: UseEmailVerificationToken :one
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING user_id, email;
+
+-- name: InvalidateEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE user_id = $1
+  AND email IS NULL
+  AND used_at IS NULL;
+
+-- name: InvalidateEmailChangeTokens :exec
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE user_id = $1
+  AND email IS NOT NULL
+  AND used_at IS NULL;
+
+-- name: GetPendingEmailChange :one
+SELECT email::VARCHAR
+FROM email_verification_tokens
+WHERE user_id = $1
+  AND email IS NOT NULL
+  AND used_at IS NULL
+  AND expires_at > NOW()
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: DeleteExpiredEmailVerificationTokens :execrows
+DELETE FROM email_verification_tokens
+WHERE expires_at <= NOW();
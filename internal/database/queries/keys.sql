@@ -1,24 +1,77 @@
 -- name: CreateKey :one
 INSERT INTO keys (reservation_id, user_id, key_code, device_id, valid_from, valid_until)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at;
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at;
 
 -- name: GetKeyByReservationID :one
-SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
 FROM keys
-WHERE reservation_id = $1 LIMIT 1;
+WHERE reservation_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
 
 -- name: ListKeysByUserID :many
-SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
 FROM keys
 WHERE user_id = $1
+  AND status <> 'REVOKED'
 ORDER BY valid_from DESC;
 
 -- name: ListActiveKeysByUserID :many
-SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
 FROM keys
 WHERE user_id = $1
-  AND DATE(valid_from) <= CURRENT_DATE
-  AND DATE(valid_until) >= CURRENT_DATE
+  AND status = 'ACTIVE'
+  AND valid_from <= NOW()
+  AND valid_until > NOW()
 ORDER BY valid_from DESC;
 
+-- name: RevokeActiveKey :one
+UPDATE keys
+SET status = 'REVOKED', revoked_at = NOW(), revoke_reason = $2, updated_at = NOW()
+WHERE reservation_id = $1
+  AND status = 'ACTIVE'
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at;
+
+-- name: MarkKeyProviderSynced :one
+UPDATE keys
+SET provider_synced_at = NOW(), provider_error = NULL, updated_at = NOW()
+WHERE id = $1
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at;
+
+-- name: RecordKeyProviderError :exec
+UPDATE keys
+SET provider_error = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: IsKeyCodeInUse :one
+SELECT EXISTS (
+    SELECT 1
+    FROM keys
+    WHERE device_id = @device_id
+      AND key_code = @key_code
+      AND (
+          (status = 'ACTIVE' AND valid_from < @valid_until AND valid_until > @valid_from)
+          OR (status = 'REVOKED' AND revoked_at > @revoked_after)
+      )
+) AS in_use;
+
+-- name: UpdateKeyCode :one
+UPDATE keys
+SET key_code = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at;
+
+-- name: ExpireKeys :execrows
+UPDATE keys
+SET status = 'EXPIRED', expired_at = NOW(), updated_at = NOW()
+WHERE status = 'ACTIVE'
+  AND valid_until <= NOW();
+
+-- name: ListKeysPendingLockRemoval :many
+SELECT id, reservation_id, user_id, key_code, device_id, valid_from, valid_until, created_at, updated_at, status, revoked_at, revoke_reason, provider_synced_at, provider_error, expired_at
+FROM keys
+WHERE status IN ('REVOKED', 'EXPIRED')
+  AND (provider_synced_at IS NULL OR provider_synced_at < COALESCE(revoked_at, expired_at))
+ORDER BY updated_at
+LIMIT $1;
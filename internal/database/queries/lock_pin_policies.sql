@@ -0,0 +1,4 @@
+-- name: GetLockPinPolicy :one
+SELECT device_id, pin_length, reuse_cooldown_days, created_at, updated_at
+FROM lock_pin_policies
+WHERE device_id = $1;
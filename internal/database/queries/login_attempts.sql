@@ -0,0 +1,39 @@
+-- name: GetLoginAttempt :one
+SELECT key, failures, last_failure_at, blocked_until FROM login_attempts
+WHERE key = $1;
+
+-- name: AddLoginFailure :one
+INSERT INTO login_attempts (key, failures, last_failure_at)
+VALUES (@key, 1, @failed_at)
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+        WHEN login_attempts.last_failure_at < @window_start::TIMESTAMPTZ THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = EXCLUDED.last_failure_at
+RETURNING failures;
+
+-- name: RemoveLoginFailure :exec
+UPDATE login_attempts
+SET failures = failures - 1
+WHERE key = $1
+  AND failures > 0;
+
+-- name: SetLoginFailures :exec
+UPDATE login_attempts
+SET failures = @failures
+WHERE key = @key;
+
+-- name: BlockLoginKey :exec
+UPDATE login_attempts
+SET blocked_until = GREATEST(COALESCE(blocked_until, @blocked_until::TIMESTAMPTZ), @blocked_until::TIMESTAMPTZ)
+WHERE key = @key;
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts
+WHERE key = $1;
+
+-- name: DeleteStaleLoginAttempts :execrows
+DELETE FROM login_attempts
+WHERE last_failure_at < @window_start::TIMESTAMPTZ
+  AND (blocked_until IS NULL OR blocked_until < @now::TIMESTAMPTZ);
@@ -0,0 +1,78 @@
+-- name: GetUserMFA :one
+SELECT user_id, encrypted_secret, enabled_at, last_used_step, created_at FROM user_mfa
+WHERE user_id = $1;
+
+-- name: UpsertPendingMFA :one
+INSERT INTO user_mfa (user_id, encrypted_secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET encrypted_secret = EXCLUDED.encrypted_secret,
+    last_used_step = 0,
+    created_at = NOW()
+WHERE user_mfa.enabled_at IS NULL
+RETURNING user_id, encrypted_secret, enabled_at, last_used_step, created_at;
+
+-- name: EnableMFA :execrows
+UPDATE user_mfa
+SET enabled_at = NOW(),
+    last_used_step = $2
+WHERE user_id = $1
+  AND enabled_at IS NULL;
+
+-- name: UseMFAStep :execrows
+UPDATE user_mfa
+SET last_used_step = $2
+WHERE user_id = $1
+  AND last_used_step < $2;
+
+-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa
+WHERE user_id = $1;
+
+-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (user_id, code_hash)
+VALUES ($1, $2);
+
+-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = $1;
+
+-- name: UseMFARecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL;
+
+-- name: CountUnusedMFARecoveryCodes :one
+SELECT COUNT(*) FROM mfa_recovery_codes
+WHERE user_id = $1
+  AND used_at IS NULL;
+
+-- name: CreateMFAChallenge :one
+INSERT INTO mfa_challenges (user_id, token_hash, expires_at)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, token_hash, expires_at, failed_attempts, used_at, created_at;
+
+-- name: GetMFAChallengeByHash :one
+SELECT id, user_id, token_hash, expires_at, failed_attempts, used_at, created_at FROM mfa_challenges
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW();
+
+-- name: RecordMFAChallengeFailure :one
+UPDATE mfa_challenges
+SET failed_attempts = failed_attempts + 1,
+    used_at = CASE WHEN failed_attempts + 1 >= @max_attempts::INTEGER THEN NOW() ELSE used_at END
+WHERE id = @id
+RETURNING failed_attempts;
+
+-- name: UseMFAChallenge :execrows
+UPDATE mfa_challenges
+SET used_at = NOW()
+WHERE id = $1
+  AND used_at IS NULL;
+
+-- name: DeleteExpiredMFAChallenges :execrows
+DELETE FROM mfa_challenges
+WHERE expires_at <= NOW();
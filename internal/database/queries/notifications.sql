@@ -0,0 +1,25 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id, reservation_id, type, message)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, reservation_id, type, message, read_at, created_at;
+
+-- name: ListNotificationsByUserID :many
+SELECT id, user_id, reservation_id, type, message, read_at, created_at
+FROM notifications
+WHERE user_id = @user_id
+  AND (NOT @unread_only::BOOLEAN OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT @max_results;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*)
+FROM notifications
+WHERE user_id = $1
+  AND read_at IS NULL;
+
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1
+  AND user_id = $2
+RETURNING id, user_id, reservation_id, type, message, read_at, created_at;
@@ -0,0 +1,25 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox (event_type, payload)
+VALUES ($1, $2)
+RETURNING id, event_type, payload, attempts, last_error, next_attempt_at, created_at, sent_at;
+
+-- name: ListPendingOutboxEvents :many
+SELECT id, event_type, payload, attempts, last_error, next_attempt_at, created_at, sent_at
+FROM outbox
+WHERE sent_at IS NULL
+  AND next_attempt_at <= NOW()
+ORDER BY created_at
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventSent :exec
+UPDATE outbox
+SET sent_at = NOW()
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = @last_error,
+    next_attempt_at = NOW() + (@backoff_seconds::INTEGER * INTERVAL '1 second')
+WHERE id = @id;
@@ -0,0 +1,25 @@
+-- name: CreateProperty :one
+INSERT INTO properties (owner_id, name, address, timezone, check_in_time, check_out_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time;
+
+-- name: GetProperty :one
+SELECT id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time
+FROM properties
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPropertiesByOwnerID :many
+SELECT id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time
+FROM properties
+WHERE owner_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateProperty :one
+UPDATE properties
+SET name = $2, address = $3, timezone = $4, is_active = $5, check_in_time = $6, check_out_time = $7, updated_at = NOW()
+WHERE id = $1
+RETURNING id, owner_id, name, address, timezone, is_active, created_at, updated_at, check_in_time, check_out_time;
+
+-- name: DeleteProperty :exec
+DELETE FROM properties
+WHERE id = $1;
@@ -0,0 +1,16 @@
+-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets (key, tokens, allowed, updated_at)
+VALUES (@key, @initial_tokens::FLOAT8, TRUE, @now::TIMESTAMPTZ)
+ON CONFLICT (key) DO UPDATE
+SET tokens = CASE
+        WHEN LEAST(@burst::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * @rate::FLOAT8) >= 1
+        THEN LEAST(@burst::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * @rate::FLOAT8) - 1
+        ELSE LEAST(@burst::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * @rate::FLOAT8)
+    END,
+    allowed = LEAST(@burst::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * @rate::FLOAT8) >= 1,
+    updated_at = GREATEST(rate_limit_buckets.updated_at, EXCLUDED.updated_at)
+RETURNING tokens, allowed;
+
+-- name: DeleteIdleRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE updated_at < @idle_since::TIMESTAMPTZ;
@@ -0,0 +1,14 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (session_id, token_hash)
+VALUES ($1, $2)
+RETURNING id, session_id, token_hash, used_at, created_at;
+
+-- name: GetRefreshTokenByHash :one
+SELECT id, session_id, token_hash, used_at, created_at FROM refresh_tokens
+WHERE token_hash = $1 LIMIT 1;
+
+-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = NOW()
+WHERE id = $1
+  AND used_at IS NULL;
@@ -1,15 +1,15 @@
 -- name: CreateReservation :one
-INSERT INTO reservations (user_id, room_id, start_date, end_date, total_price, status)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at;
+INSERT INTO reservations (user_id, room_id, start_date, end_date, total_price, status, price_breakdown)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at;
 
 -- name: GetReservation :one
-SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at
+SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
 FROM reservations
 WHERE id = $1 LIMIT 1;
 
 -- name: ListReservationsByUserID :many
-SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at
+SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
 FROM reservations
 WHERE user_id = $1
 ORDER BY created_at DESC;
@@ -18,5 +18,40 @@ ORDER BY created_at DESC;
 UPDATE reservations
 SET status = $2, updated_at = NOW()
 WHERE id = $1
-RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at;
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at;
 
+-- name: UpdatePendingReservationStatus :one
+UPDATE reservations
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+  AND status = 'PENDING'
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at;
+
+-- name: CancelReservation :one
+UPDATE reservations
+SET status = 'CANCELLED', cancellation_fee = $2, cancelled_at = NOW(), updated_at = NOW()
+WHERE id = $1
+  AND status IN ('PENDING', 'CONFIRMED')
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at;
+
+-- name: ListBookedRoomIDs :many
+SELECT DISTINCT room_id
+FROM reservations
+WHERE status <> 'CANCELLED'
+  AND end_date > @start_date
+  AND start_date < @end_date
+ORDER BY room_id;
+
+-- name: CompleteFinishedReservations :many
+UPDATE reservations
+SET status = 'COMPLETED', updated_at = NOW()
+WHERE id IN (
+    SELECT id
+    FROM reservations
+    WHERE status = 'CONFIRMED'
+      AND check_out_at <= NOW()
+    ORDER BY check_out_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at;
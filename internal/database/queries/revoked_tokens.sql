@@ -0,0 +1,18 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (jti, user_id, expires_at, reason)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsTokenRevoked :one
+SELECT EXISTS (
+    SELECT 1 FROM revoked_tokens WHERE jti = $1
+) AS revoked;
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at <= NOW();
+
+-- name: ListTokenRevocationsSince :many
+SELECT jti, expires_at FROM revoked_tokens
+WHERE revoked_at > @since AND expires_at > NOW()
+ORDER BY revoked_at;
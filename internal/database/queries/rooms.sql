@@ -0,0 +1,34 @@
+-- name: CreateRoom :one
+INSERT INTO rooms (property_id, name, capacity, amenities, lock_device_id, base_rate, min_stay_nights)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights;
+
+-- name: GetRoom :one
+SELECT id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+FROM rooms
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRoomsByPropertyID :many
+SELECT id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+FROM rooms
+WHERE property_id = $1
+ORDER BY id;
+
+-- name: ListBookableRooms :many
+SELECT id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+FROM rooms
+WHERE is_active = TRUE
+  AND capacity >= @guests
+  AND property_id IN (SELECT id FROM properties WHERE is_active = TRUE)
+ORDER BY id;
+
+-- name: UpdateRoom :one
+UPDATE rooms
+SET name = $2, capacity = $3, amenities = $4, lock_device_id = $5, is_active = $6,
+    base_rate = $7, min_stay_nights = $8, updated_at = NOW()
+WHERE id = $1
+RETURNING id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights;
+
+-- name: DeleteRoom :exec
+DELETE FROM rooms
+WHERE id = $1;
@@ -0,0 +1,26 @@
+-- name: CreateSeasonalRate :one
+INSERT INTO seasonal_rates (room_id, name, start_date, end_date, nightly_rate)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, room_id, name, start_date, end_date, nightly_rate, created_at;
+
+-- name: GetSeasonalRate :one
+SELECT id, room_id, name, start_date, end_date, nightly_rate, created_at
+FROM seasonal_rates
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSeasonalRatesByRoomID :many
+SELECT id, room_id, name, start_date, end_date, nightly_rate, created_at
+FROM seasonal_rates
+WHERE room_id = $1
+ORDER BY start_date;
+
+-- name: ListSeasonalRatesInRange :many
+SELECT id, room_id, name, start_date, end_date, nightly_rate, created_at
+FROM seasonal_rates
+WHERE end_date > @start_date
+  AND start_date < @end_date
+ORDER BY room_id, start_date;
+
+-- name: DeleteSeasonalRate :exec
+DELETE FROM seasonal_rates
+WHERE id = $1;
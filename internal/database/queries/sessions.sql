@@ -0,0 +1,47 @@
+-- name: CreateSession :one
+INSERT INTO sessions (user_id, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at;
+
+-- name: GetSession :one
+SELECT id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at FROM sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListActiveSessionsByUserID :many
+SELECT id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at FROM sessions
+WHERE user_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+ORDER BY last_used_at DESC;
+
+-- name: ExtendSession :one
+UPDATE sessions
+SET last_used_at = NOW(), expires_at = $2
+WHERE id = $1
+  AND revoked_at IS NULL
+RETURNING id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+SET revoked_at = NOW(), revoke_reason = $3
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :many
+UPDATE sessions
+SET revoked_at = NOW(), revoke_reason = @revoke_reason
+WHERE user_id = @user_id
+  AND revoked_at IS NULL
+  AND id IS DISTINCT FROM @except_session_id
+RETURNING id;
+
+-- name: IsSessionActive :one
+SELECT EXISTS (
+    SELECT 1 FROM sessions WHERE id = $1 AND revoked_at IS NULL
+) AS active;
+
+-- name: ListSessionRevocationsSince :many
+SELECT id, revoked_at FROM sessions
+WHERE revoked_at > @since
+ORDER BY revoked_at;
@@ -0,0 +1,19 @@
+-- name: CreateSigningKey :one
+INSERT INTO signing_keys (kid, algorithm, private_key, not_before)
+VALUES ($1, $2, $3, $4)
+RETURNING kid, algorithm, private_key, not_before, not_after, created_at;
+
+-- name: ListSigningKeys :many
+SELECT kid, algorithm, private_key, not_before, not_after, created_at FROM signing_keys
+WHERE not_after IS NULL OR not_after > NOW()
+ORDER BY not_before;
+
+-- name: RetireSigningKeys :exec
+UPDATE signing_keys
+SET not_after = @not_after
+WHERE not_after IS NULL
+  AND kid <> @kid;
+
+-- name: DeleteExpiredSigningKeys :execrows
+DELETE FROM signing_keys
+WHERE not_after <= NOW();
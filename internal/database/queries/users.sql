@@ -1,13 +1,47 @@
 -- name: CreateUser :one
 INSERT INTO users (email, hashed_password, name, role)
 VALUES ($1, $2, $3, $4)
-RETURNING id, email, hashed_password, name, role, created_at, updated_at;
+RETURNING id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale;
 
 -- name: GetUserByEmail :one
-SELECT id, email, hashed_password, name, role, created_at, updated_at FROM users
+SELECT id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale FROM users
 WHERE email = $1 LIMIT 1;
 
 -- name: GetUserByID :one
-SELECT id, email, hashed_password, name, role, created_at, updated_at FROM users
+SELECT id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale FROM users
 WHERE id = $1 LIMIT 1;
 
+-- name: UpdateUserProfile :one
+UPDATE users
+SET name = COALESCE(sqlc.narg(name), name),
+    phone = COALESCE(sqlc.narg(phone), phone),
+    locale = COALESCE(sqlc.narg(locale), locale)
+WHERE id = @id
+RETURNING id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale;
+
+-- name: ChangeUserEmail :exec
+UPDATE users
+SET email = $2, email_verified_at = NOW()
+WHERE id = $1;
+
+-- name: EmailExists :one
+SELECT EXISTS (
+    SELECT 1 FROM users WHERE email = $1
+) AS registered;
+
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET hashed_password = $2
+WHERE id = $1;
+
+-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = NOW()
+WHERE id = $1
+  AND email_verified_at IS NULL;
+
+-- name: IsUserEmailVerified :one
+SELECT EXISTS (
+    SELECT 1 FROM users WHERE id = $1 AND email_verified_at IS NOT NULL
+) AS verified;
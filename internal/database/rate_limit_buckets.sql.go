@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: rate_limit_buckets.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteIdleRateLimitBuckets = `-- name: DeleteIdleRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE updated_at < $1::TIMESTAMPTZ
+`
+
+func (q *Queries) DeleteIdleRateLimitBuckets(ctx context.Context, idleSince pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteIdleRateLimitBuckets, idleSince)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const takeRateLimitToken = `-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets (key, tokens, allowed, updated_at)
+VALUES ($1, $2::FLOAT8, TRUE, $3::TIMESTAMPTZ)
+ON CONFLICT (key) DO UPDATE
+SET tokens = CASE
+        WHEN LEAST($4::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * $5::FLOAT8) >= 1
+        THEN LEAST($4::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * $5::FLOAT8) - 1
+        ELSE LEAST($4::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * $5::FLOAT8)
+    END,
+    allowed = LEAST($4::FLOAT8, rate_limit_buckets.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limit_buckets.updated_at)::FLOAT8, 0) * $5::FLOAT8) >= 1,
+    updated_at = GREATEST(rate_limit_buckets.updated_at, EXCLUDED.updated_at)
+RETURNING tokens, allowed
+`
+
+type TakeRateLimitTokenParams struct {
+	Key           string             `json:"key"`
+	InitialTokens float64            `json:"initial_tokens"`
+	Now           pgtype.Timestamptz `json:"now"`
+	Burst         float64            `json:"burst"`
+	Rate          float64            `json:"rate"`
+}
+
+type TakeRateLimitTokenRow struct {
+	Tokens  float64 `json:"tokens"`
+	Allowed bool    `json:"allowed"`
+}
+
+func (q *Queries) TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (TakeRateLimitTokenRow, error) {
+	row := q.db.QueryRow(ctx, takeRateLimitToken,
+		arg.Key,
+		arg.InitialTokens,
+		arg.Now,
+		arg.Burst,
+		arg.Rate,
+	)
+	var i TakeRateLimitTokenRow
+	err := row.Scan(&i.Tokens, &i.Allowed)
+	return i, err
+}
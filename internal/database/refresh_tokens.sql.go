@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: refresh_tokens.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createRefreshToken = `-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (session_id, token_hash)
+VALUES ($1, $2)
+RETURNING id, session_id, token_hash, used_at, created_at
+`
+
+type CreateRefreshTokenParams struct {
+	SessionID pgtype.UUID `json:"session_id"`
+	TokenHash []byte      `json:"token_hash"`
+}
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, createRefreshToken, arg.SessionID, arg.TokenHash)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.TokenHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
+SELECT id, session_id, token_hash, used_at, created_at FROM refresh_tokens
+WHERE token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash []byte) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenByHash, tokenHash)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.TokenHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const markRefreshTokenUsed = `-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = NOW()
+WHERE id = $1
+  AND used_at IS NULL
+`
+
+func (q *Queries) MarkRefreshTokenUsed(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, markRefreshTokenUsed, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -11,19 +11,105 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelReservation = `-- name: CancelReservation :one
+UPDATE reservations
+SET status = 'CANCELLED', cancellation_fee = $2, cancelled_at = NOW(), updated_at = NOW()
+WHERE id = $1
+  AND status IN ('PENDING', 'CONFIRMED')
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
+`
+
+type CancelReservationParams struct {
+	ID              pgtype.UUID `json:"id"`
+	CancellationFee int64       `json:"cancellation_fee"`
+}
+
+func (q *Queries) CancelReservation(ctx context.Context, arg CancelReservationParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, cancelReservation, arg.ID, arg.CancellationFee)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RoomID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.TotalPrice,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PriceBreakdown,
+		&i.CancellationFee,
+		&i.CancelledAt,
+		&i.CheckInAt,
+		&i.CheckOutAt,
+	)
+	return i, err
+}
+
+const completeFinishedReservations = `-- name: CompleteFinishedReservations :many
+UPDATE reservations
+SET status = 'COMPLETED', updated_at = NOW()
+WHERE id IN (
+    SELECT id
+    FROM reservations
+    WHERE status = 'CONFIRMED'
+      AND check_out_at <= NOW()
+    ORDER BY check_out_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
+`
+
+func (q *Queries) CompleteFinishedReservations(ctx context.Context, limit int32) ([]Reservation, error) {
+	rows, err := q.db.Query(ctx, completeFinishedReservations, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Reservation
+	for rows.Next() {
+		var i Reservation
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.RoomID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.TotalPrice,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PriceBreakdown,
+			&i.CancellationFee,
+			&i.CancelledAt,
+			&i.CheckInAt,
+			&i.CheckOutAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createReservation = `-- name: CreateReservation :one
-INSERT INTO reservations (user_id, room_id, start_date, end_date, total_price, status)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at
+INSERT INTO reservations (user_id, room_id, start_date, end_date, total_price, status, price_breakdown)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
 `
 
 type CreateReservationParams struct {
-	UserID     pgtype.UUID      `json:"user_id"`
-	RoomID     int64            `json:"room_id"`
-	StartDate  pgtype.Timestamp `json:"start_date"`
-	EndDate    pgtype.Timestamp `json:"end_date"`
-	TotalPrice int64            `json:"total_price"`
-	Status     string           `json:"status"`
+	UserID         pgtype.UUID      `json:"user_id"`
+	RoomID         int64            `json:"room_id"`
+	StartDate      pgtype.Timestamp `json:"start_date"`
+	EndDate        pgtype.Timestamp `json:"end_date"`
+	TotalPrice     int64            `json:"total_price"`
+	Status         string           `json:"status"`
+	PriceBreakdown []byte           `json:"price_breakdown"`
 }
 
 func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error) {
@@ -34,6 +120,7 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		arg.EndDate,
 		arg.TotalPrice,
 		arg.Status,
+		arg.PriceBreakdown,
 	)
 	var i Reservation
 	err := row.Scan(
@@ -46,12 +133,17 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PriceBreakdown,
+		&i.CancellationFee,
+		&i.CancelledAt,
+		&i.CheckInAt,
+		&i.CheckOutAt,
 	)
 	return i, err
 }
 
 const getReservation = `-- name: GetReservation :one
-SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at
+SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
 FROM reservations
 WHERE id = $1 LIMIT 1
 `
@@ -69,12 +161,51 @@ func (q *Queries) GetReservation(ctx context.Context, id pgtype.UUID) (Reservati
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PriceBreakdown,
+		&i.CancellationFee,
+		&i.CancelledAt,
+		&i.CheckInAt,
+		&i.CheckOutAt,
 	)
 	return i, err
 }
 
+const listBookedRoomIDs = `-- name: ListBookedRoomIDs :many
+SELECT DISTINCT room_id
+FROM reservations
+WHERE status <> 'CANCELLED'
+  AND end_date > $1
+  AND start_date < $2
+ORDER BY room_id
+`
+
+type ListBookedRoomIDsParams struct {
+	StartDate pgtype.Timestamp `json:"start_date"`
+	EndDate   pgtype.Timestamp `json:"end_date"`
+}
+
+func (q *Queries) ListBookedRoomIDs(ctx context.Context, arg ListBookedRoomIDsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listBookedRoomIDs, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var room_id int64
+		if err := rows.Scan(&room_id); err != nil {
+			return nil, err
+		}
+		items = append(items, room_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReservationsByUserID = `-- name: ListReservationsByUserID :many
-SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at
+SELECT id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
 FROM reservations
 WHERE user_id = $1
 ORDER BY created_at DESC
@@ -99,6 +230,11 @@ func (q *Queries) ListReservationsByUserID(ctx context.Context, userID pgtype.UU
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PriceBreakdown,
+			&i.CancellationFee,
+			&i.CancelledAt,
+			&i.CheckInAt,
+			&i.CheckOutAt,
 		); err != nil {
 			return nil, err
 		}
@@ -110,11 +246,46 @@ func (q *Queries) ListReservationsByUserID(ctx context.Context, userID pgtype.UU
 	return items, nil
 }
 
+const updatePendingReservationStatus = `-- name: UpdatePendingReservationStatus :one
+UPDATE reservations
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+  AND status = 'PENDING'
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
+`
+
+type UpdatePendingReservationStatusParams struct {
+	ID     pgtype.UUID `json:"id"`
+	Status string      `json:"status"`
+}
+
+func (q *Queries) UpdatePendingReservationStatus(ctx context.Context, arg UpdatePendingReservationStatusParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, updatePendingReservationStatus, arg.ID, arg.Status)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RoomID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.TotalPrice,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PriceBreakdown,
+		&i.CancellationFee,
+		&i.CancelledAt,
+		&i.CheckInAt,
+		&i.CheckOutAt,
+	)
+	return i, err
+}
+
 const updateReservationStatus = `-- name: UpdateReservationStatus :one
 UPDATE reservations
 SET status = $2, updated_at = NOW()
 WHERE id = $1
-RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at
+RETURNING id, user_id, room_id, start_date, end_date, total_price, status, created_at, updated_at, price_breakdown, cancellation_fee, cancelled_at, check_in_at, check_out_at
 `
 
 type UpdateReservationStatusParams struct {
@@ -135,6 +306,11 @@ func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReserva
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PriceBreakdown,
+		&i.CancellationFee,
+		&i.CancelledAt,
+		&i.CheckInAt,
+		&i.CheckOutAt,
 	)
 	return i, err
 }
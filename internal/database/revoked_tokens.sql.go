@@ -0,0 +1,92 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: revoked_tokens.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteExpiredRevokedTokens = `-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredRevokedTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const isTokenRevoked = `-- name: IsTokenRevoked :one
+SELECT EXISTS (
+    SELECT 1 FROM revoked_tokens WHERE jti = $1
+) AS revoked
+`
+
+func (q *Queries) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	row := q.db.QueryRow(ctx, isTokenRevoked, jti)
+	var revoked bool
+	err := row.Scan(&revoked)
+	return revoked, err
+}
+
+const listTokenRevocationsSince = `-- name: ListTokenRevocationsSince :many
+SELECT jti, expires_at FROM revoked_tokens
+WHERE revoked_at > $1 AND expires_at > NOW()
+ORDER BY revoked_at
+`
+
+type ListTokenRevocationsSinceRow struct {
+	Jti       string             `json:"jti"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) ListTokenRevocationsSince(ctx context.Context, since pgtype.Timestamptz) ([]ListTokenRevocationsSinceRow, error) {
+	rows, err := q.db.Query(ctx, listTokenRevocationsSince, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTokenRevocationsSinceRow
+	for rows.Next() {
+		var i ListTokenRevocationsSinceRow
+		if err := rows.Scan(&i.Jti, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeToken = `-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (jti, user_id, expires_at, reason)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (jti) DO NOTHING
+`
+
+type RevokeTokenParams struct {
+	Jti       string             `json:"jti"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	Reason    string             `json:"reason"`
+}
+
+func (q *Queries) RevokeToken(ctx context.Context, arg RevokeTokenParams) error {
+	_, err := q.db.Exec(ctx, revokeToken,
+		arg.Jti,
+		arg.UserID,
+		arg.ExpiresAt,
+		arg.Reason,
+	)
+	return err
+}
@@ -0,0 +1,217 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: rooms.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createRoom = `-- name: CreateRoom :one
+INSERT INTO rooms (property_id, name, capacity, amenities, lock_device_id, base_rate, min_stay_nights)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+`
+
+type CreateRoomParams struct {
+	PropertyID    int64       `json:"property_id"`
+	Name          string      `json:"name"`
+	Capacity      int32       `json:"capacity"`
+	Amenities     []string    `json:"amenities"`
+	LockDeviceID  pgtype.Text `json:"lock_device_id"`
+	BaseRate      int64       `json:"base_rate"`
+	MinStayNights int32       `json:"min_stay_nights"`
+}
+
+func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error) {
+	row := q.db.QueryRow(ctx, createRoom,
+		arg.PropertyID,
+		arg.Name,
+		arg.Capacity,
+		arg.Amenities,
+		arg.LockDeviceID,
+		arg.BaseRate,
+		arg.MinStayNights,
+	)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.PropertyID,
+		&i.Name,
+		&i.Capacity,
+		&i.Amenities,
+		&i.LockDeviceID,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BaseRate,
+		&i.MinStayNights,
+	)
+	return i, err
+}
+
+const deleteRoom = `-- name: DeleteRoom :exec
+DELETE FROM rooms
+WHERE id = $1
+`
+
+func (q *Queries) DeleteRoom(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteRoom, id)
+	return err
+}
+
+const getRoom = `-- name: GetRoom :one
+SELECT id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+FROM rooms
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetRoom(ctx context.Context, id int64) (Room, error) {
+	row := q.db.QueryRow(ctx, getRoom, id)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.PropertyID,
+		&i.Name,
+		&i.Capacity,
+		&i.Amenities,
+		&i.LockDeviceID,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BaseRate,
+		&i.MinStayNights,
+	)
+	return i, err
+}
+
+const listBookableRooms = `-- name: ListBookableRooms :many
+SELECT id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+FROM rooms
+WHERE is_active = TRUE
+  AND capacity >= $1
+  AND property_id IN (SELECT id FROM properties WHERE is_active = TRUE)
+ORDER BY id
+`
+
+func (q *Queries) ListBookableRooms(ctx context.Context, guests int32) ([]Room, error) {
+	rows, err := q.db.Query(ctx, listBookableRooms, guests)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Room
+	for rows.Next() {
+		var i Room
+		if err := rows.Scan(
+			&i.ID,
+			&i.PropertyID,
+			&i.Name,
+			&i.Capacity,
+			&i.Amenities,
+			&i.LockDeviceID,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BaseRate,
+			&i.MinStayNights,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomsByPropertyID = `-- name: ListRoomsByPropertyID :many
+SELECT id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+FROM rooms
+WHERE property_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListRoomsByPropertyID(ctx context.Context, propertyID int64) ([]Room, error) {
+	rows, err := q.db.Query(ctx, listRoomsByPropertyID, propertyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Room
+	for rows.Next() {
+		var i Room
+		if err := rows.Scan(
+			&i.ID,
+			&i.PropertyID,
+			&i.Name,
+			&i.Capacity,
+			&i.Amenities,
+			&i.LockDeviceID,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BaseRate,
+			&i.MinStayNights,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateRoom = `-- name: UpdateRoom :one
+UPDATE rooms
+SET name = $2, capacity = $3, amenities = $4, lock_device_id = $5, is_active = $6,
+    base_rate = $7, min_stay_nights = $8, updated_at = NOW()
+WHERE id = $1
+RETURNING id, property_id, name, capacity, amenities, lock_device_id, is_active, created_at, updated_at, base_rate, min_stay_nights
+`
+
+type UpdateRoomParams struct {
+	ID            int64       `json:"id"`
+	Name          string      `json:"name"`
+	Capacity      int32       `json:"capacity"`
+	Amenities     []string    `json:"amenities"`
+	LockDeviceID  pgtype.Text `json:"lock_device_id"`
+	IsActive      bool        `json:"is_active"`
+	BaseRate      int64       `json:"base_rate"`
+	MinStayNights int32       `json:"min_stay_nights"`
+}
+
+func (q *Queries) UpdateRoom(ctx context.Context, arg UpdateRoomParams) (Room, error) {
+	row := q.db.QueryRow(ctx, updateRoom,
+		arg.ID,
+		arg.Name,
+		arg.Capacity,
+		arg.Amenities,
+		arg.LockDeviceID,
+		arg.IsActive,
+		arg.BaseRate,
+		arg.MinStayNights,
+	)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.PropertyID,
+		&i.Name,
+		&i.Capacity,
+		&i.Amenities,
+		&i.LockDeviceID,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BaseRate,
+		&i.MinStayNights,
+	)
+	return i, err
+}
@@ -0,0 +1,154 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: seasonal_rates.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSeasonalRate = `-- name: CreateSeasonalRate :one
+INSERT INTO seasonal_rates (room_id, name, start_date, end_date, nightly_rate)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, room_id, name, start_date, end_date, nightly_rate, created_at
+`
+
+type CreateSeasonalRateParams struct {
+	RoomID      int64       `json:"room_id"`
+	Name        string      `json:"name"`
+	StartDate   pgtype.Date `json:"start_date"`
+	EndDate     pgtype.Date `json:"end_date"`
+	NightlyRate int64       `json:"nightly_rate"`
+}
+
+func (q *Queries) CreateSeasonalRate(ctx context.Context, arg CreateSeasonalRateParams) (SeasonalRate, error) {
+	row := q.db.QueryRow(ctx, createSeasonalRate,
+		arg.RoomID,
+		arg.Name,
+		arg.StartDate,
+		arg.EndDate,
+		arg.NightlyRate,
+	)
+	var i SeasonalRate
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+		&i.NightlyRate,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteSeasonalRate = `-- name: DeleteSeasonalRate :exec
+DELETE FROM seasonal_rates
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSeasonalRate(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteSeasonalRate, id)
+	return err
+}
+
+const getSeasonalRate = `-- name: GetSeasonalRate :one
+SELECT id, room_id, name, start_date, end_date, nightly_rate, created_at
+FROM seasonal_rates
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetSeasonalRate(ctx context.Context, id int64) (SeasonalRate, error) {
+	row := q.db.QueryRow(ctx, getSeasonalRate, id)
+	var i SeasonalRate
+	err := row.Scan(
+		&i.ID,
+		&i.RoomID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+		&i.NightlyRate,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listSeasonalRatesByRoomID = `-- name: ListSeasonalRatesByRoomID :many
+SELECT id, room_id, name, start_date, end_date, nightly_rate, created_at
+FROM seasonal_rates
+WHERE room_id = $1
+ORDER BY start_date
+`
+
+func (q *Queries) ListSeasonalRatesByRoomID(ctx context.Context, roomID int64) ([]SeasonalRate, error) {
+	rows, err := q.db.Query(ctx, listSeasonalRatesByRoomID, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SeasonalRate
+	for rows.Next() {
+		var i SeasonalRate
+		if err := rows.Scan(
+			&i.ID,
+			&i.RoomID,
+			&i.Name,
+			&i.StartDate,
+			&i.EndDate,
+			&i.NightlyRate,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeasonalRatesInRange = `-- name: ListSeasonalRatesInRange :many
+SELECT id, room_id, name, start_date, end_date, nightly_rate, created_at
+FROM seasonal_rates
+WHERE end_date > $1
+  AND start_date < $2
+ORDER BY room_id, start_date
+`
+
+type ListSeasonalRatesInRangeParams struct {
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) ListSeasonalRatesInRange(ctx context.Context, arg ListSeasonalRatesInRangeParams) ([]SeasonalRate, error) {
+	rows, err := q.db.Query(ctx, listSeasonalRatesInRange, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SeasonalRate
+	for rows.Next() {
+		var i SeasonalRate
+		if err := rows.Scan(
+			&i.ID,
+			&i.RoomID,
+			&i.Name,
+			&i.StartDate,
+			&i.EndDate,
+			&i.NightlyRate,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,238 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: sessions.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSession = `-- name: CreateSession :one
+INSERT INTO sessions (user_id, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at
+`
+
+type CreateSessionParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	UserAgent string             `json:"user_agent"`
+	IpAddress string             `json:"ip_address"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
+	row := q.db.QueryRow(ctx, createSession,
+		arg.UserID,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.ExpiresAt,
+	)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const extendSession = `-- name: ExtendSession :one
+UPDATE sessions
+SET last_used_at = NOW(), expires_at = $2
+WHERE id = $1
+  AND revoked_at IS NULL
+RETURNING id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at
+`
+
+type ExtendSessionParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) ExtendSession(ctx context.Context, arg ExtendSessionParams) (Session, error) {
+	row := q.db.QueryRow(ctx, extendSession, arg.ID, arg.ExpiresAt)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSession = `-- name: GetSession :one
+SELECT id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at FROM sessions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetSession(ctx context.Context, id pgtype.UUID) (Session, error) {
+	row := q.db.QueryRow(ctx, getSession, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const isSessionActive = `-- name: IsSessionActive :one
+SELECT EXISTS (
+    SELECT 1 FROM sessions WHERE id = $1 AND revoked_at IS NULL
+) AS active
+`
+
+func (q *Queries) IsSessionActive(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isSessionActive, id)
+	var active bool
+	err := row.Scan(&active)
+	return active, err
+}
+
+const listActiveSessionsByUserID = `-- name: ListActiveSessionsByUserID :many
+SELECT id, user_id, user_agent, ip_address, expires_at, last_used_at, revoked_at, revoke_reason, created_at FROM sessions
+WHERE user_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+ORDER BY last_used_at DESC
+`
+
+func (q *Queries) ListActiveSessionsByUserID(ctx context.Context, userID pgtype.UUID) ([]Session, error) {
+	rows, err := q.db.Query(ctx, listActiveSessionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.RevokeReason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionRevocationsSince = `-- name: ListSessionRevocationsSince :many
+SELECT id, revoked_at FROM sessions
+WHERE revoked_at > $1
+ORDER BY revoked_at
+`
+
+type ListSessionRevocationsSinceRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+func (q *Queries) ListSessionRevocationsSince(ctx context.Context, since pgtype.Timestamptz) ([]ListSessionRevocationsSinceRow, error) {
+	rows, err := q.db.Query(ctx, listSessionRevocationsSince, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSessionRevocationsSinceRow
+	for rows.Next() {
+		var i ListSessionRevocationsSinceRow
+		if err := rows.Scan(&i.ID, &i.RevokedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeSession = `-- name: RevokeSession :execrows
+UPDATE sessions
+SET revoked_at = NOW(), revoke_reason = $3
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL
+`
+
+type RevokeSessionParams struct {
+	ID           pgtype.UUID `json:"id"`
+	UserID       pgtype.UUID `json:"user_id"`
+	RevokeReason pgtype.Text `json:"revoke_reason"`
+}
+
+func (q *Queries) RevokeSession(ctx context.Context, arg RevokeSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeSession, arg.ID, arg.UserID, arg.RevokeReason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeUserSessions = `-- name: RevokeUserSessions :many
+UPDATE sessions
+SET revoked_at = NOW(), revoke_reason = $1
+WHERE user_id = $2
+  AND revoked_at IS NULL
+  AND id IS DISTINCT FROM $3
+RETURNING id
+`
+
+type RevokeUserSessionsParams struct {
+	RevokeReason    pgtype.Text `json:"revoke_reason"`
+	UserID          pgtype.UUID `json:"user_id"`
+	ExceptSessionID pgtype.UUID `json:"except_session_id"`
+}
+
+func (q *Queries) RevokeUserSessions(ctx context.Context, arg RevokeUserSessionsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, revokeUserSessions, arg.RevokeReason, arg.UserID, arg.ExceptSessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,107 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: signing_keys.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSigningKey = `-- name: CreateSigningKey :one
+INSERT INTO signing_keys (kid, algorithm, private_key, not_before)
+VALUES ($1, $2, $3, $4)
+RETURNING kid, algorithm, private_key, not_before, not_after, created_at
+`
+
+type CreateSigningKeyParams struct {
+	Kid        string             `json:"kid"`
+	Algorithm  string             `json:"algorithm"`
+	PrivateKey []byte             `json:"private_key"`
+	NotBefore  pgtype.Timestamptz `json:"not_before"`
+}
+
+func (q *Queries) CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) (SigningKey, error) {
+	row := q.db.QueryRow(ctx, createSigningKey,
+		arg.Kid,
+		arg.Algorithm,
+		arg.PrivateKey,
+		arg.NotBefore,
+	)
+	var i SigningKey
+	err := row.Scan(
+		&i.Kid,
+		&i.Algorithm,
+		&i.PrivateKey,
+		&i.NotBefore,
+		&i.NotAfter,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteExpiredSigningKeys = `-- name: DeleteExpiredSigningKeys :execrows
+DELETE FROM signing_keys
+WHERE not_after <= NOW()
+`
+
+func (q *Queries) DeleteExpiredSigningKeys(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredSigningKeys)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listSigningKeys = `-- name: ListSigningKeys :many
+SELECT kid, algorithm, private_key, not_before, not_after, created_at FROM signing_keys
+WHERE not_after IS NULL OR not_after > NOW()
+ORDER BY not_before
+`
+
+func (q *Queries) ListSigningKeys(ctx context.Context) ([]SigningKey, error) {
+	rows, err := q.db.Query(ctx, listSigningKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SigningKey
+	for rows.Next() {
+		var i SigningKey
+		if err := rows.Scan(
+			&i.Kid,
+			&i.Algorithm,
+			&i.PrivateKey,
+			&i.NotBefore,
+			&i.NotAfter,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retireSigningKeys = `-- name: RetireSigningKeys :exec
+UPDATE signing_keys
+SET not_after = $1
+WHERE not_after IS NULL
+  AND kid <> $2
+`
+
+type RetireSigningKeysParams struct {
+	NotAfter pgtype.Timestamptz `json:"not_after"`
+	Kid      string             `json:"kid"`
+}
+
+func (q *Queries) RetireSigningKeys(ctx context.Context, arg RetireSigningKeysParams) error {
+	_, err := q.db.Exec(ctx, retireSigningKeys, arg.NotAfter, arg.Kid)
+	return err
+}
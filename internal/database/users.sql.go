@@ -11,10 +11,26 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const changeUserEmail = `-- name: ChangeUserEmail :exec
+UPDATE users
+SET email = $2, email_verified_at = NOW()
+WHERE id = $1
+`
+
+type ChangeUserEmailParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Email string      `json:"email"`
+}
+
+func (q *Queries) ChangeUserEmail(ctx context.Context, arg ChangeUserEmailParams) error {
+	_, err := q.db.Exec(ctx, changeUserEmail, arg.ID, arg.Email)
+	return err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, hashed_password, name, role)
 VALUES ($1, $2, $3, $4)
-RETURNING id, email, hashed_password, name, role, created_at, updated_at
+RETURNING id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale
 `
 
 type CreateUserParams struct {
@@ -40,12 +56,28 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.Phone,
+		&i.Locale,
 	)
 	return i, err
 }
 
+const emailExists = `-- name: EmailExists :one
+SELECT EXISTS (
+    SELECT 1 FROM users WHERE email = $1
+) AS registered
+`
+
+func (q *Queries) EmailExists(ctx context.Context, email string) (bool, error) {
+	row := q.db.QueryRow(ctx, emailExists, email)
+	var registered bool
+	err := row.Scan(&registered)
+	return registered, err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, hashed_password, name, role, created_at, updated_at FROM users
+SELECT id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -60,12 +92,15 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.Phone,
+		&i.Locale,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, hashed_password, name, role, created_at, updated_at FROM users
+SELECT id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -80,6 +115,89 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.Phone,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const isUserEmailVerified = `-- name: IsUserEmailVerified :one
+SELECT EXISTS (
+    SELECT 1 FROM users WHERE id = $1 AND email_verified_at IS NOT NULL
+) AS verified
+`
+
+func (q *Queries) IsUserEmailVerified(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isUserEmailVerified, id)
+	var verified bool
+	err := row.Scan(&verified)
+	return verified, err
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = NOW()
+WHERE id = $1
+  AND email_verified_at IS NULL
+`
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markUserEmailVerified, id)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET hashed_password = $2
+WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID             pgtype.UUID `json:"id"`
+	HashedPassword []byte      `json:"hashed_password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.HashedPassword)
+	return err
+}
+
+const updateUserProfile = `-- name: UpdateUserProfile :one
+UPDATE users
+SET name = COALESCE($1, name),
+    phone = COALESCE($2, phone),
+    locale = COALESCE($3, locale)
+WHERE id = $4
+RETURNING id, email, hashed_password, name, role, created_at, updated_at, email_verified_at, phone, locale
+`
+
+type UpdateUserProfileParams struct {
+	Name   pgtype.Text `json:"name"`
+	Phone  pgtype.Text `json:"phone"`
+	Locale pgtype.Text `json:"locale"`
+	ID     pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserProfile,
+		arg.Name,
+		arg.Phone,
+		arg.Locale,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.Name,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.Phone,
+		&i.Locale,
 	)
 	return i, err
 }
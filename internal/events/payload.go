@@ -7,13 +7,22 @@ import "time"
 type EventPayload struct {
 	EventType     string    `json:"event_type"`
 	ReservationID string    `json:"reservation_id"`
-	UserID        string    `json:"user_id"` // UUID
-	StartDate     time.Time `json:"start_date"`
-	EndDate       time.Time `json:"end_date"`
+	UserID        string    `json:"user_id"`          // UUID
+	StartDate     time.Time `json:"start_date"`       // Booked check-in date
+	EndDate       time.Time `json:"end_date"`         // Booked check-out date
+	CheckInAt     time.Time `json:"check_in_at"`      // Check-in instant in the property's time zone
+	CheckOutAt    time.Time `json:"check_out_at"`     // Check-out instant in the property's time zone
+	Reason        string    `json:"reason,omitempty"` // Set on failure events
 }
 
 // EventType constants for type safety
 const (
-	EventTypeReservationCreated = "ReservationCreated"
-)
+	// Published by the Reservation Service (reservation-events topic)
+	EventTypeReservationCreated   = "ReservationCreated"
+	EventTypeReservationCancelled = "ReservationCancelled"
+	EventTypeStayCompleted        = "StayCompleted" // The stay has ended (published by the scheduler)
 
+	// Published by the Key Service (key-events topic)
+	EventTypeKeyIssued      = "KeyIssued"
+	EventTypeKeyIssueFailed = "KeyIssueFailed"
+)
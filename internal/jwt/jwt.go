@@ -0,0 +1,164 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Issuer is the iss claim of every token issued by the Auth Service
+const Issuer = "smart-stay-platform"
+
+// ErrUnknownKey is returned by ValidateToken when the token names a key the keyring does not hold.
+// Verifiers that load keys from the JWKS can refresh the keys and try again.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Claims represents the JWT claims
+type Claims struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+	Email  string `json:"email,omitempty"`
+	// SessionID is the session the token was issued for (see sessions table)
+	SessionID string `json:"sid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Keyring holds the keys tokens are signed and verified with.
+// Verifiers that only know the public keys (from the JWKS) can validate tokens but not sign them.
+type Keyring struct {
+	mu   sync.RWMutex
+	keys map[string]Key
+}
+
+// NewKeyring creates an empty keyring
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]Key)}
+}
+
+// Replace swaps the keys of the keyring
+func (r *Keyring) Replace(keys []Key) {
+	byID := make(map[string]Key, len(keys))
+	for _, key := range keys {
+		byID[key.ID] = key
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = byID
+}
+
+// Keys returns the keys that can currently verify tokens, including a key published ahead of use
+func (r *Keyring) Keys() []Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	keys := make([]Key, 0, len(r.keys))
+	for _, key := range r.keys {
+		if key.verifies(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// GenerateToken generates a JWT token with user information, signed with the current signing key
+func (r *Keyring) GenerateToken(userID, role, email, sessionID string, expiresIn time.Duration) (string, error) {
+	key, err := r.signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	expirationTime := time.Now().Add(expiresIn)
+
+	claims := &Claims{
+		UserID:    userID,
+		Role:      role,
+		Email:     email,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    Issuer,
+			Subject:   userID,
+			// jti identifies the token so that it can be revoked before it expires
+			ID: uuid.NewString(),
+		},
+	}
+
+	token := jwt.NewWithClaims(key.signingMethod(), claims)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// ValidateToken validates a JWT token and returns the claims.
+// The token must name the key it was signed with (kid) and use that key's algorithm.
+func (r *Keyring) ValidateToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := r.verificationKey(kid)
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+		}
+		// Validate signing method
+		if token.Method.Alg() != key.Algorithm {
+			return nil, errors.New("invalid signing method")
+		}
+		return key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{AlgorithmEdDSA, AlgorithmRS256}), jwt.WithIssuer(Issuer))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// signingKey returns the newest key whose signing period has started
+func (r *Keyring) signingKey() (Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var current Key
+	for _, key := range r.keys {
+		if key.PrivateKey == nil || !key.verifies(now) || key.NotBefore.After(now) {
+			continue
+		}
+		if current.ID == "" || key.NotBefore.After(current.NotBefore) {
+			current = key
+		}
+	}
+	if current.ID == "" {
+		return Key{}, errors.New("no signing key available")
+	}
+	return current, nil
+}
+
+// verificationKey returns the key with the given ID if it can still verify tokens
+func (r *Keyring) verificationKey(kid string) (Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[kid]
+	if !ok || !key.verifies(time.Now()) {
+		return Key{}, false
+	}
+	return key, true
+}
@@ -0,0 +1,288 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newTestKey generates a key that started signing an hour ago
+func newTestKey(t *testing.T, algorithm string) Key {
+	t.Helper()
+	key, err := GenerateKey(algorithm, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateKey(%s): %v", algorithm, err)
+	}
+	return key
+}
+
+// keyringOf creates a keyring holding the keys
+func keyringOf(keys ...Key) *Keyring {
+	keyring := NewKeyring()
+	keyring.Replace(keys)
+	return keyring
+}
+
+// throughJWKS serves the public keys of a keyring as a JWKS document and parses it back,
+// as a verifier loading the JWKS would
+func throughJWKS(t *testing.T, keyring *Keyring) []Key {
+	t.Helper()
+	var jwks struct {
+		Keys []JWK `json:"keys"`
+	}
+	for _, key := range keyring.Keys() {
+		jwks.Keys = append(jwks.Keys, key.JWK())
+	}
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	jwks.Keys = nil
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	var keys []Key
+	for _, jwk := range jwks.Keys {
+		key, err := ParseJWK(jwk)
+		if err != nil {
+			t.Fatalf("ParseJWK(%+v): %v", jwk, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestJWKSRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmEdDSA, AlgorithmRS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			key := newTestKey(t, algorithm)
+			signer := keyringOf(key)
+
+			keys := throughJWKS(t, signer)
+			if len(keys) != 1 {
+				t.Fatalf("%d keys in the JWKS, want 1", len(keys))
+			}
+			parsed := keys[0]
+			if parsed.ID != key.ID || parsed.Algorithm != algorithm || parsed.PrivateKey != nil {
+				t.Errorf("parsed key = %s %s (private: %t)", parsed.ID, parsed.Algorithm, parsed.PrivateKey != nil)
+			}
+			if !parsed.PublicKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(key.PublicKey) {
+				t.Error("parsed public key differs from the original")
+			}
+
+			token, err := signer.GenerateToken("user-1", "guest", "guest@example.com", "session-1", time.Minute)
+			if err != nil {
+				t.Fatalf("GenerateToken: %v", err)
+			}
+			claims, err := keyringOf(keys...).ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken with the JWKS keys: %v", err)
+			}
+			if claims.UserID != "user-1" || claims.Role != "guest" || claims.SessionID != "session-1" || claims.Issuer != Issuer {
+				t.Errorf("claims = %+v", claims)
+			}
+
+			// A verifier cannot sign
+			if _, err := keyringOf(keys...).GenerateToken("user-1", "guest", "", "", time.Minute); err == nil {
+				t.Error("GenerateToken succeeded with verification-only keys")
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	old := newTestKey(t, AlgorithmEdDSA)
+	token, err := keyringOf(old).GenerateToken("user-1", "guest", "", "", time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	// The old key is retired but still verifies until NotAfter
+	current := newTestKey(t, AlgorithmRS256)
+	current.NotBefore = time.Now().Add(-time.Minute)
+	old.NotAfter = time.Now().Add(time.Hour)
+	keyring := keyringOf(old, current)
+
+	if _, err := keyring.ValidateToken(token); err != nil {
+		t.Errorf("token signed with a retired key: %v", err)
+	}
+	newToken, err := keyring.GenerateToken("user-1", "guest", "", "", time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified: %v", err)
+	}
+	if kid := parsed.Header["kid"]; kid != current.ID || parsed.Method.Alg() != AlgorithmRS256 {
+		t.Errorf("new token signed with kid %v (%s), want %s (RS256)", kid, parsed.Method.Alg(), current.ID)
+	}
+	if n := len(throughJWKS(t, keyring)); n != 2 {
+		t.Errorf("%d keys in the JWKS, want 2", n)
+	}
+
+	// Past NotAfter the key is dropped from the JWKS and its tokens are rejected
+	old.NotAfter = time.Now().Add(-time.Second)
+	keyring = keyringOf(old, current)
+	if _, err := keyring.ValidateToken(token); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("token signed with an expired key: err = %v, want ErrUnknownKey", err)
+	}
+	if n := len(throughJWKS(t, keyring)); n != 1 {
+		t.Errorf("%d keys in the JWKS, want 1", n)
+	}
+}
+
+func TestValidateTokenUnknownKey(t *testing.T) {
+	token, err := keyringOf(newTestKey(t, AlgorithmEdDSA)).GenerateToken("user-1", "guest", "", "", time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	_, err = keyringOf(newTestKey(t, AlgorithmEdDSA)).ValidateToken(token)
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("err = %v, want ErrUnknownKey", err)
+	}
+}
+
+// signToken signs claims with the given method and kid, bypassing the keyring
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, &Claims{
+		UserID: "user-1",
+		Role:   "admin",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			Issuer:    Issuer,
+		},
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString(%s): %v", method.Alg(), err)
+	}
+	return signed
+}
+
+func TestValidateTokenRejectsAlgorithmMismatch(t *testing.T) {
+	edKey := newTestKey(t, AlgorithmEdDSA)
+	rsaKey := newTestKey(t, AlgorithmRS256)
+	keyring := keyringOf(edKey, rsaKey)
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(rsaKey.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		// Signed correctly, but naming a key of the other type
+		{"RS256 with an EdDSA kid", signToken(t, jwt.SigningMethodRS256, edKey.ID, rsaKey.PrivateKey)},
+		{"EdDSA with an RS256 kid", signToken(t, jwt.SigningMethodEdDSA, rsaKey.ID, edKey.PrivateKey)},
+		// The public key used as an HMAC secret
+		{"HS256 with the public key", signToken(t, jwt.SigningMethodHS256, rsaKey.ID, rsaPublicDER)},
+		{"none", signToken(t, jwt.SigningMethodNone, edKey.ID, jwt.UnsafeAllowNoneSignatureType)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if claims, err := keyring.ValidateToken(tt.token); err == nil {
+				t.Errorf("token accepted: %+v", claims)
+			}
+		})
+	}
+
+	// The same claims signed properly are accepted
+	if _, err := keyring.ValidateToken(signToken(t, jwt.SigningMethodEdDSA, edKey.ID, edKey.PrivateKey)); err != nil {
+		t.Errorf("properly signed token: %v", err)
+	}
+}
+
+func TestValidateTokenRejectsOtherIssuers(t *testing.T) {
+	key := newTestKey(t, AlgorithmEdDSA)
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, &Claims{
+		UserID: "user-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			Issuer:    "someone-else",
+		},
+	})
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	if _, err := keyringOf(key).ValidateToken(signed); err == nil {
+		t.Error("token of another issuer accepted")
+	}
+}
+
+func TestParseJWKRejectsInvalidKeys(t *testing.T) {
+	ed := newTestKey(t, AlgorithmEdDSA).JWK()
+	rsaJWK := newTestKey(t, AlgorithmRS256).JWK()
+
+	tests := []struct {
+		name   string
+		modify func(jwk JWK) JWK
+		base   JWK
+	}{
+		{"OKP with the RS256 algorithm", func(jwk JWK) JWK { jwk.Alg = AlgorithmRS256; return jwk }, ed},
+		{"RSA with the EdDSA algorithm", func(jwk JWK) JWK { jwk.Alg = AlgorithmEdDSA; return jwk }, rsaJWK},
+		{"other curve", func(jwk JWK) JWK { jwk.Crv = "X25519"; return jwk }, ed},
+		{"short Ed25519 key", func(jwk JWK) JWK { jwk.X = jwk.X[:10]; return jwk }, ed},
+		{"bad base64", func(jwk JWK) JWK { jwk.X = "***"; return jwk }, ed},
+		{"missing exponent", func(jwk JWK) JWK { jwk.E = ""; return jwk }, rsaJWK},
+		{"unsupported key type", func(jwk JWK) JWK { jwk.Kty = "EC"; return jwk }, ed},
+		{"none", func(jwk JWK) JWK { jwk.Alg = "none"; return jwk }, ed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if key, err := ParseJWK(tt.modify(tt.base)); err == nil {
+				t.Errorf("ParseJWK accepted %+v as %s", tt.modify(tt.base), key.Algorithm)
+			}
+		})
+	}
+}
+
+func TestPrivateKeyRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmEdDSA, AlgorithmRS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			key := newTestKey(t, algorithm)
+			der, err := key.MarshalPrivateKey()
+			if err != nil {
+				t.Fatalf("MarshalPrivateKey: %v", err)
+			}
+			parsed, err := ParsePrivateKey(key.ID, algorithm, der, key.NotBefore, time.Time{})
+			if err != nil {
+				t.Fatalf("ParsePrivateKey: %v", err)
+			}
+			switch private := parsed.PrivateKey.(type) {
+			case ed25519.PrivateKey:
+				if !private.Equal(key.PrivateKey) {
+					t.Error("parsed Ed25519 key differs")
+				}
+			case *rsa.PrivateKey:
+				if !private.Equal(key.PrivateKey) {
+					t.Error("parsed RSA key differs")
+				}
+			default:
+				t.Fatalf("parsed private key is %T", private)
+			}
+
+			// A key stored under the wrong algorithm is refused
+			other := AlgorithmRS256
+			if algorithm == AlgorithmRS256 {
+				other = AlgorithmEdDSA
+			}
+			if _, err := ParsePrivateKey(key.ID, other, der, key.NotBefore, time.Time{}); err == nil {
+				t.Errorf("ParsePrivateKey accepted a %s key as %s", algorithm, other)
+			}
+		})
+	}
+}
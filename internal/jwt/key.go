@@ -0,0 +1,182 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+)
+
+// rsaKeyBits is the size of generated RSA keys
+const rsaKeyBits = 2048
+
+// Key is a signing key identified by its kid.
+// It signs tokens from NotBefore on and verifies them until NotAfter (zero while it is current).
+type Key struct {
+	ID         string
+	Algorithm  string
+	PublicKey  crypto.PublicKey
+	PrivateKey crypto.Signer // nil for keys read from a JWKS
+	NotBefore  time.Time
+	NotAfter   time.Time
+}
+
+// GenerateKey creates a new key pair for the algorithm that starts signing at notBefore
+func GenerateKey(algorithm string, notBefore time.Time) (Key, error) {
+	var private crypto.Signer
+	switch algorithm {
+	case AlgorithmEdDSA:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return Key{}, err
+		}
+		private = priv
+	case AlgorithmRS256:
+		priv, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return Key{}, err
+		}
+		private = priv
+	default:
+		return Key{}, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	return Key{
+		ID:         uuid.NewString(),
+		Algorithm:  algorithm,
+		PublicKey:  private.Public(),
+		PrivateKey: private,
+		NotBefore:  notBefore,
+	}, nil
+}
+
+// MarshalPrivateKey encodes the private key as PKCS #8 DER
+func (k Key) MarshalPrivateKey() ([]byte, error) {
+	if k.PrivateKey == nil {
+		return nil, errors.New("key has no private part")
+	}
+	return x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+}
+
+// ParsePrivateKey decodes a PKCS #8 DER private key stored with MarshalPrivateKey
+func ParsePrivateKey(id, algorithm string, der []byte, notBefore, notAfter time.Time) (Key, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return Key{}, err
+	}
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return Key{}, errors.New("private key cannot sign")
+	}
+	key := Key{
+		ID:         id,
+		Algorithm:  algorithm,
+		PublicKey:  private.Public(),
+		PrivateKey: private,
+		NotBefore:  notBefore,
+		NotAfter:   notAfter,
+	}
+	if err := key.checkAlgorithm(); err != nil {
+		return Key{}, err
+	}
+	return key, nil
+}
+
+// verifies reports whether the key can verify tokens at the given time
+func (k Key) verifies(now time.Time) bool {
+	return k.NotAfter.IsZero() || now.Before(k.NotAfter)
+}
+
+// signingMethod returns the JWT signing method of the key
+func (k Key) signingMethod() jwt.SigningMethod {
+	if k.Algorithm == AlgorithmRS256 {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodEdDSA
+}
+
+// checkAlgorithm checks that the key type matches the algorithm
+func (k Key) checkAlgorithm() error {
+	switch k.PublicKey.(type) {
+	case ed25519.PublicKey:
+		if k.Algorithm == AlgorithmEdDSA {
+			return nil
+		}
+	case *rsa.PublicKey:
+		if k.Algorithm == AlgorithmRS256 {
+			return nil
+		}
+	}
+	return fmt.Errorf("key %s does not match algorithm %q", k.ID, k.Algorithm)
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// OKP (Ed25519) keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// JWK returns the public part of the key as a JWK
+func (k Key) JWK() JWK {
+	jwk := JWK{Use: "sig", Alg: k.Algorithm, Kid: k.ID}
+	switch pub := k.PublicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	}
+	return jwk
+}
+
+// ParseJWK reads a verification-only key from a JWK
+func ParseJWK(jwk JWK) (Key, error) {
+	key := Key{ID: jwk.Kid, Algorithm: jwk.Alg}
+	switch jwk.Kty {
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || jwk.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return Key{}, fmt.Errorf("invalid Ed25519 key %s", jwk.Kid)
+		}
+		key.PublicKey = ed25519.PublicKey(x)
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			return Key{}, fmt.Errorf("invalid RSA key %s", jwk.Kid)
+		}
+		key.PublicKey = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+	if err := key.checkAlgorithm(); err != nil {
+		return Key{}, err
+	}
+	return key, nil
+}
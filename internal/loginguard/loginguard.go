@@ -0,0 +1,258 @@
+// Package loginguard slows down password guessing.
+//
+// Failed logins are counted per key, where a key is an email address or a client IP.
+// Wrong MFA codes (the second step of a login, or disabling MFA) are counted per user the same way.
+// After each failure the key has to wait before the next attempt, and the wait doubles
+// with every further failure (exponential backoff). After Policy.MaxFailures failures
+// the key is locked out for Policy.LockoutDuration. Failures are forgotten once a key
+// has had none for Policy.Window, or when the key is reset (successful login, unlock link).
+//
+// An attempt is counted as a failure before it is made (Guard.Begin) and given back if it
+// succeeds (Attempt.Release), so concurrent attempts cannot all pass the check before any of
+// them is counted: a burst never gets more than Policy.MaxFailures tries. A lockout restarts
+// the count just below the limit, so a key that has served it gets one more try.
+//
+// The counters are kept in a Store: PostgresStore shares them between instances,
+// MemoryStore keeps them in the process.
+package loginguard
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Kind is what a key counts failures for
+type Kind string
+
+const (
+	// KindEmail counts failures for an account, whichever client they come from
+	KindEmail Kind = "email"
+	// KindIP counts failures from a client, whichever accounts they target
+	KindIP Kind = "ip"
+	// KindMFA counts wrong MFA codes of a user, at login or when changing their MFA settings
+	KindMFA Kind = "mfa"
+)
+
+// Key identifies a counter
+type Key struct {
+	Kind  Kind
+	Value string
+}
+
+// EmailKey returns the key of an email address. Addresses are compared case-insensitively.
+func EmailKey(email string) Key {
+	return Key{Kind: KindEmail, Value: strings.ToLower(strings.TrimSpace(email))}
+}
+
+// IPKey returns the key of a client IP address
+func IPKey(ip string) Key {
+	return Key{Kind: KindIP, Value: ip}
+}
+
+// MFAKey returns the key of the MFA codes of a user
+func MFAKey(userID string) Key {
+	return Key{Kind: KindMFA, Value: userID}
+}
+
+// String returns the key as stored, e.g. "email:guest@example.com"
+func (k Key) String() string {
+	return string(k.Kind) + ":" + k.Value
+}
+
+// State is the stored state of a key
+type State struct {
+	// Failures is the number of failures since the count last restarted
+	Failures int
+	// LastFailureAt is the time of the latest failure
+	LastFailureAt time.Time
+	// BlockedUntil is when the key may try again; zero if it was never blocked
+	BlockedUntil time.Time
+}
+
+// Store keeps the failure counters
+type Store interface {
+	// Get returns the state of a key; the zero State for unknown keys
+	Get(ctx context.Context, key string) (State, error)
+	// AddFailure records a failure at now and returns the new failure count.
+	// The count restarts at 1 if the previous failure is older than window.
+	AddFailure(ctx context.Context, key string, now time.Time, window time.Duration) (int, error)
+	// RemoveFailure takes back a failure counted by AddFailure
+	RemoveFailure(ctx context.Context, key string) error
+	// SetFailures sets the failure count of a key
+	SetFailures(ctx context.Context, key string, failures int) error
+	// Block refuses attempts for a key until the given time. An existing later block is kept.
+	Block(ctx context.Context, key string, until time.Time) error
+	// Reset forgets the failures of a key and lifts its block
+	Reset(ctx context.Context, key string) error
+	// Purge deletes keys without failures in the window before now that are no longer blocked
+	Purge(ctx context.Context, now time.Time, window time.Duration) (int64, error)
+}
+
+// Policy sets the limits for one kind of key
+type Policy struct {
+	// MaxFailures is the number of failures that locks a key out
+	MaxFailures int
+	// BaseDelay is the wait after the first failure; it doubles with every further failure.
+	// Zero disables backoff, leaving only the lockout.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff wait
+	MaxDelay time.Duration
+	// LockoutDuration is how long a key is locked out
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered
+	Window time.Duration
+}
+
+// delay returns how long a key has to wait after its nth failure, and whether that is a lockout
+func (p Policy) delay(failures int) (time.Duration, bool) {
+	if failures >= p.MaxFailures {
+		return p.LockoutDuration, true
+	}
+	if p.BaseDelay <= 0 || failures <= 0 {
+		return 0, false
+	}
+	delay := p.BaseDelay
+	for i := 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay), false
+}
+
+// busyRetryDelay is the wait for an attempt refused because attempts still in progress
+// already use up the remaining failures of a key
+const busyRetryDelay = time.Second
+
+// Lockout reports a key that has just been locked out
+type Lockout struct {
+	Key      Key
+	Failures int
+	Until    time.Time
+}
+
+// Guard applies a policy per kind of key to the counters in a store
+type Guard struct {
+	store    Store
+	policies map[Kind]Policy
+	now      func() time.Time
+}
+
+// New creates a guard. Keys of a kind without a policy are not limited.
+func New(store Store, policies map[Kind]Policy) *Guard {
+	return &Guard{store: store, policies: policies, now: time.Now}
+}
+
+// Check returns how long the caller has to wait before an attempt for the keys is allowed;
+// zero if it is allowed now. Attempts that are refused are not counted as failures.
+func (g *Guard) Check(ctx context.Context, keys ...Key) (time.Duration, error) {
+	now := g.now()
+	var wait time.Duration
+	for _, key := range keys {
+		if _, ok := g.policies[key.Kind]; !ok {
+			continue
+		}
+		state, err := g.store.Get(ctx, key.String())
+		if err != nil {
+			return 0, err
+		}
+		if remaining := state.BlockedUntil.Sub(now); remaining > wait {
+			wait = remaining
+		}
+	}
+	return wait, nil
+}
+
+// Begin counts an attempt for the keys as a failure before it is made. It returns how long
+// the caller has to wait if the attempt is refused, either because a key is blocked or because
+// attempts in progress already use up its remaining failures; refused attempts are not counted.
+// An attempt that is not refused must be ended with Fail or Release.
+func (g *Guard) Begin(ctx context.Context, keys ...Key) (*Attempt, time.Duration, error) {
+	wait, err := g.Check(ctx, keys...)
+	if err != nil || wait > 0 {
+		return nil, wait, err
+	}
+
+	attempt := &Attempt{guard: g}
+	for _, key := range keys {
+		policy, ok := g.policies[key.Kind]
+		if !ok {
+			continue
+		}
+		failures, err := g.store.AddFailure(ctx, key.String(), g.now(), policy.Window)
+		if err != nil {
+			// Best effort: the store is failing, so the first error is the one reported
+			_ = attempt.Release(ctx)
+			return nil, 0, err
+		}
+		attempt.counted = append(attempt.counted, countedFailure{key: key, failures: failures})
+		if failures > policy.MaxFailures {
+			if err := attempt.Release(ctx); err != nil {
+				return nil, 0, err
+			}
+			return nil, busyRetryDelay, nil
+		}
+	}
+	return attempt, 0, nil
+}
+
+// Attempt is an attempt that has been counted as a failure in advance
+type Attempt struct {
+	guard   *Guard
+	counted []countedFailure
+}
+
+// countedFailure is the failure count of a key including the attempt
+type countedFailure struct {
+	key      Key
+	failures int
+}
+
+// Fail ends the attempt as a failure: each key has to wait before its next attempt, or is
+// locked out if the attempt was its last allowed one. It returns the keys it locked out.
+func (a *Attempt) Fail(ctx context.Context) ([]Lockout, error) {
+	now := a.guard.now()
+	var lockouts []Lockout
+	for _, counted := range a.counted {
+		policy := a.guard.policies[counted.key.Kind]
+		delay, locked := policy.delay(counted.failures)
+		if delay <= 0 {
+			continue
+		}
+		until := now.Add(delay)
+		if err := a.guard.store.Block(ctx, counted.key.String(), until); err != nil {
+			return lockouts, err
+		}
+		if locked {
+			if err := a.guard.store.SetFailures(ctx, counted.key.String(), policy.MaxFailures-1); err != nil {
+				return lockouts, err
+			}
+			lockouts = append(lockouts, Lockout{Key: counted.key, Failures: counted.failures, Until: until})
+		}
+	}
+	return lockouts, nil
+}
+
+// Release ends the attempt without counting it, e.g. because the password was right or
+// could not be checked
+func (a *Attempt) Release(ctx context.Context) error {
+	for _, counted := range a.counted {
+		if err := a.guard.store.RemoveFailure(ctx, counted.key.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset forgets the failures of a key, e.g. after a successful login or an unlock
+func (g *Guard) Reset(ctx context.Context, key Key) error {
+	return g.store.Reset(ctx, key.String())
+}
+
+// Purge deletes counters that no longer affect any attempt
+func (g *Guard) Purge(ctx context.Context) (int64, error) {
+	var window time.Duration
+	for _, policy := range g.policies {
+		window = max(window, policy.Window, policy.LockoutDuration)
+	}
+	return g.store.Purge(ctx, g.now(), window)
+}
@@ -0,0 +1,348 @@
+package loginguard
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testPolicy backs off 1s, 2s, 4s, 8s and locks out for 15 minutes at the 5th failure
+var testPolicy = Policy{
+	MaxFailures:     5,
+	BaseDelay:       time.Second,
+	MaxDelay:        8 * time.Second,
+	LockoutDuration: 15 * time.Minute,
+	Window:          time.Hour,
+}
+
+// clock is a settable time source for a guard
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestGuard creates a guard over a MemoryStore with testPolicy for emails
+// and the given policy for IPs
+func newTestGuard(ipPolicy Policy) (*Guard, *MemoryStore, *clock) {
+	store := NewMemoryStore()
+	c := &clock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	guard := New(store, map[Kind]Policy{KindEmail: testPolicy, KindIP: ipPolicy})
+	guard.now = c.Now
+	return guard, store, c
+}
+
+// fail makes an attempt that fails and returns its lockouts
+func fail(t *testing.T, guard *Guard, keys ...Key) []Lockout {
+	t.Helper()
+	ctx := context.Background()
+	attempt, wait, err := guard.Begin(ctx, keys...)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if wait > 0 {
+		t.Fatalf("Begin refused the attempt (wait %s)", wait)
+	}
+	lockouts, err := attempt.Fail(ctx)
+	if err != nil {
+		t.Fatalf("Fail: %v", err)
+	}
+	return lockouts
+}
+
+// wait returns how long the keys have to wait
+func wait(t *testing.T, guard *Guard, keys ...Key) time.Duration {
+	t.Helper()
+	wait, err := guard.Check(context.Background(), keys...)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	return wait
+}
+
+func TestPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   Policy
+		failures int
+		want     time.Duration
+		locked   bool
+	}{
+		{"no failures", testPolicy, 0, 0, false},
+		{"first failure", testPolicy, 1, time.Second, false},
+		{"second failure", testPolicy, 2, 2 * time.Second, false},
+		{"third failure", testPolicy, 3, 4 * time.Second, false},
+		{"fourth failure", testPolicy, 4, 8 * time.Second, false},
+		{"lockout", testPolicy, 5, 15 * time.Minute, true},
+		{"past the lockout", testPolicy, 7, 15 * time.Minute, true},
+		{"capped", Policy{MaxFailures: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}, 4, 5 * time.Second, false},
+		{"no backoff", Policy{MaxFailures: 3, LockoutDuration: time.Minute}, 2, 0, false},
+		{"no backoff lockout", Policy{MaxFailures: 3, LockoutDuration: time.Minute}, 3, time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, locked := tt.policy.delay(tt.failures)
+			if got != tt.want || locked != tt.locked {
+				t.Errorf("delay(%d) = %s, %t; want %s, %t", tt.failures, got, locked, tt.want, tt.locked)
+			}
+		})
+	}
+}
+
+func TestGuardBackoff(t *testing.T) {
+	guard, _, c := newTestGuard(Policy{})
+	key := EmailKey("guest@example.com")
+
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		if lockouts := fail(t, guard, key); len(lockouts) != 0 {
+			t.Fatalf("failure %d: unexpected lockout %+v", i+1, lockouts)
+		}
+		if got := wait(t, guard, key); got != want {
+			t.Fatalf("failure %d: wait = %s, want %s", i+1, got, want)
+		}
+
+		// Refused attempts are not counted
+		attempt, got, err := guard.Begin(context.Background(), key)
+		if err != nil || attempt != nil || got != want {
+			t.Fatalf("failure %d: Begin while backing off = %v, %s, %v; want refusal with wait %s", i+1, attempt, got, err, want)
+		}
+		c.Advance(want)
+	}
+}
+
+func TestGuardLockout(t *testing.T) {
+	guard, store, c := newTestGuard(Policy{})
+	key := EmailKey("Guest@Example.com ")
+	ctx := context.Background()
+
+	for i := 1; i < testPolicy.MaxFailures; i++ {
+		fail(t, guard, key)
+		c.Advance(testPolicy.MaxDelay)
+	}
+	lockouts := fail(t, guard, key)
+	if len(lockouts) != 1 {
+		t.Fatalf("lockouts = %+v, want one", lockouts)
+	}
+	if lockouts[0].Key != EmailKey("guest@example.com") || lockouts[0].Failures != testPolicy.MaxFailures {
+		t.Errorf("lockout = %+v", lockouts[0])
+	}
+	if want := c.Now().Add(testPolicy.LockoutDuration); !lockouts[0].Until.Equal(want) {
+		t.Errorf("locked until %s, want %s", lockouts[0].Until, want)
+	}
+	if got := wait(t, guard, key); got != testPolicy.LockoutDuration {
+		t.Errorf("wait = %s, want %s", got, testPolicy.LockoutDuration)
+	}
+
+	// After the lockout exactly one more attempt is allowed before the next lockout
+	c.Advance(testPolicy.LockoutDuration)
+	if got := wait(t, guard, key); got != 0 {
+		t.Fatalf("wait after the lockout = %s, want 0", got)
+	}
+	if lockouts := fail(t, guard, key); len(lockouts) != 1 {
+		t.Fatalf("lockouts after one more failure = %+v, want one", lockouts)
+	}
+
+	// A successful attempt after the lockout clears the count
+	c.Advance(testPolicy.LockoutDuration)
+	attempt, _, err := guard.Begin(ctx, key)
+	if err != nil || attempt == nil {
+		t.Fatalf("Begin = %v, %v", attempt, err)
+	}
+	if err := attempt.Release(ctx); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if state, _ := store.Get(ctx, key.String()); state.Failures != testPolicy.MaxFailures-1 {
+		t.Errorf("failures after release = %d, want %d", state.Failures, testPolicy.MaxFailures-1)
+	}
+}
+
+func TestGuardWindowExpiry(t *testing.T) {
+	guard, store, c := newTestGuard(Policy{})
+	key := EmailKey("guest@example.com")
+
+	for range 3 {
+		fail(t, guard, key)
+		c.Advance(testPolicy.MaxDelay)
+	}
+
+	// Failures older than the window are forgotten: the next one counts as the first
+	c.Advance(testPolicy.Window)
+	fail(t, guard, key)
+	if state, _ := store.Get(context.Background(), key.String()); state.Failures != 1 {
+		t.Errorf("failures = %d, want 1", state.Failures)
+	}
+	if got := wait(t, guard, key); got != testPolicy.BaseDelay {
+		t.Errorf("wait = %s, want %s", got, testPolicy.BaseDelay)
+	}
+}
+
+func TestGuardSeveralKeys(t *testing.T) {
+	ipPolicy := Policy{MaxFailures: 20, LockoutDuration: time.Hour, Window: time.Hour}
+	guard, store, _ := newTestGuard(ipPolicy)
+	email, ip := EmailKey("guest@example.com"), IPKey("203.0.113.7")
+	ctx := context.Background()
+
+	fail(t, guard, email, ip, MFAKey("user"))
+
+	// The longest wait of the keys applies; the IP policy has no backoff
+	if got := wait(t, guard, email, ip); got != time.Second {
+		t.Errorf("wait = %s, want 1s", got)
+	}
+	if got := wait(t, guard, ip); got != 0 {
+		t.Errorf("IP wait = %s, want 0", got)
+	}
+	if state, _ := store.Get(ctx, ip.String()); state.Failures != 1 {
+		t.Errorf("IP failures = %d, want 1", state.Failures)
+	}
+	// Kinds without a policy are not counted
+	if state, _ := store.Get(ctx, MFAKey("user").String()); state.Failures != 0 {
+		t.Errorf("MFA failures = %d, want 0", state.Failures)
+	}
+}
+
+func TestGuardConcurrentBegin(t *testing.T) {
+	// No backoff, so that only the pre-counting can stop the burst
+	policy := Policy{MaxFailures: 5, LockoutDuration: time.Hour, Window: time.Hour}
+	guard, store, _ := newTestGuard(policy)
+	key := IPKey("203.0.113.7")
+	ctx := context.Background()
+
+	const callers = 50
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		attempts []*Attempt
+	)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempt, wait, err := guard.Begin(ctx, key)
+			if err != nil {
+				t.Errorf("Begin: %v", err)
+				return
+			}
+			if attempt == nil {
+				if wait != busyRetryDelay {
+					t.Errorf("refused with wait %s, want %s", wait, busyRetryDelay)
+				}
+				return
+			}
+			mu.Lock()
+			attempts = append(attempts, attempt)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(attempts) != policy.MaxFailures {
+		t.Fatalf("%d attempts allowed, want %d", len(attempts), policy.MaxFailures)
+	}
+	if state, _ := store.Get(ctx, key.String()); state.Failures != policy.MaxFailures {
+		t.Errorf("failures = %d, want %d", state.Failures, policy.MaxFailures)
+	}
+
+	// Failing all of them locks the key out once
+	var lockouts int
+	for _, attempt := range attempts {
+		locked, err := attempt.Fail(ctx)
+		if err != nil {
+			t.Fatalf("Fail: %v", err)
+		}
+		lockouts += len(locked)
+	}
+	if lockouts != 1 {
+		t.Errorf("%d lockouts, want 1", lockouts)
+	}
+	if got := wait(t, guard, key); got != policy.LockoutDuration {
+		t.Errorf("wait = %s, want %s", got, policy.LockoutDuration)
+	}
+}
+
+func TestAttemptRelease(t *testing.T) {
+	guard, store, _ := newTestGuard(Policy{})
+	key := EmailKey("guest@example.com")
+	ctx := context.Background()
+
+	for range 3 {
+		attempt, _, err := guard.Begin(ctx, key)
+		if err != nil || attempt == nil {
+			t.Fatalf("Begin = %v, %v", attempt, err)
+		}
+		if state, _ := store.Get(ctx, key.String()); state.Failures != 1 {
+			t.Fatalf("failures during the attempt = %d, want 1", state.Failures)
+		}
+		if err := attempt.Release(ctx); err != nil {
+			t.Fatalf("Release: %v", err)
+		}
+		if state, _ := store.Get(ctx, key.String()); state.Failures != 0 {
+			t.Fatalf("failures after release = %d, want 0", state.Failures)
+		}
+	}
+	if got := wait(t, guard, key); got != 0 {
+		t.Errorf("wait = %s, want 0", got)
+	}
+}
+
+func TestGuardReset(t *testing.T) {
+	guard, store, _ := newTestGuard(Policy{})
+	key := EmailKey("guest@example.com")
+	ctx := context.Background()
+
+	fail(t, guard, key)
+	if err := guard.Reset(ctx, key); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if state, _ := store.Get(ctx, key.String()); state != (State{}) {
+		t.Errorf("state after reset = %+v, want zero", state)
+	}
+	if got := wait(t, guard, key); got != 0 {
+		t.Errorf("wait after reset = %s, want 0", got)
+	}
+}
+
+func TestGuardPurge(t *testing.T) {
+	guard, store, c := newTestGuard(Policy{})
+	old, locked, recent := EmailKey("old@example.com"), EmailKey("locked@example.com"), EmailKey("recent@example.com")
+	ctx := context.Background()
+
+	fail(t, guard, old)
+	for range testPolicy.MaxFailures {
+		fail(t, guard, locked)
+		c.Advance(testPolicy.MaxDelay)
+	}
+	if err := store.Block(ctx, locked.String(), c.Now().Add(2*testPolicy.Window)); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+	c.Advance(testPolicy.Window + time.Minute)
+	fail(t, guard, recent)
+
+	deleted, err := guard.Purge(ctx)
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+	if state, _ := store.Get(ctx, old.String()); state != (State{}) {
+		t.Errorf("old key was kept: %+v", state)
+	}
+	if state, _ := store.Get(ctx, locked.String()); state.Failures == 0 {
+		t.Error("blocked key was purged")
+	}
+	if state, _ := store.Get(ctx, recent.String()); state.Failures != 1 {
+		t.Error("recent key was purged")
+	}
+}
@@ -0,0 +1,103 @@
+package loginguard
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the counters in memory. They are lost on restart and not shared
+// between instances, so it suits a single instance, development and tests.
+type MemoryStore struct {
+	mu    sync.Mutex
+	state map[string]State
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: make(map[string]State)}
+}
+
+// Get returns the state of a key
+func (s *MemoryStore) Get(_ context.Context, key string) (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state[key], nil
+}
+
+// AddFailure records a failure and returns the new failure count
+func (s *MemoryStore) AddFailure(_ context.Context, key string, now time.Time, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.state[key]
+	if state.LastFailureAt.Before(now.Add(-window)) {
+		state.Failures = 0
+	}
+	state.Failures++
+	state.LastFailureAt = now
+	s.state[key] = state
+	return state.Failures, nil
+}
+
+// RemoveFailure takes back a failure counted by AddFailure
+func (s *MemoryStore) RemoveFailure(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.state[key]
+	if !ok || state.Failures == 0 {
+		return nil
+	}
+	state.Failures--
+	s.state[key] = state
+	return nil
+}
+
+// SetFailures sets the failure count of a key
+func (s *MemoryStore) SetFailures(_ context.Context, key string, failures int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.state[key]
+	if !ok {
+		return nil
+	}
+	state.Failures = failures
+	s.state[key] = state
+	return nil
+}
+
+// Block refuses attempts for a key until the given time
+func (s *MemoryStore) Block(_ context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.state[key]
+	if !ok {
+		return nil
+	}
+	if until.After(state.BlockedUntil) {
+		state.BlockedUntil = until
+		s.state[key] = state
+	}
+	return nil
+}
+
+// Reset forgets a key
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.state, key)
+	return nil
+}
+
+// Purge deletes keys that no longer affect any attempt
+func (s *MemoryStore) Purge(_ context.Context, now time.Time, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for key, state := range s.state {
+		if state.LastFailureAt.Before(now.Add(-window)) && state.BlockedUntil.Before(now) {
+			delete(s.state, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,87 @@
+package loginguard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/karimiku/smart-stay-platform/internal/database"
+)
+
+// PostgresStore keeps the counters in the login_attempts table, shared by all instances
+type PostgresStore struct {
+	queries *database.Queries
+}
+
+// NewPostgresStore creates a store on the login_attempts table
+func NewPostgresStore(queries *database.Queries) *PostgresStore {
+	return &PostgresStore{queries: queries}
+}
+
+// Get returns the state of a key
+func (s *PostgresStore) Get(ctx context.Context, key string) (State, error) {
+	row, err := s.queries.GetLoginAttempt(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return State{}, nil
+		}
+		return State{}, err
+	}
+	return State{
+		Failures:      int(row.Failures),
+		LastFailureAt: row.LastFailureAt.Time,
+		BlockedUntil:  row.BlockedUntil.Time,
+	}, nil
+}
+
+// AddFailure records a failure and returns the new failure count.
+// The upsert is one statement, so concurrent failures are all counted.
+func (s *PostgresStore) AddFailure(ctx context.Context, key string, now time.Time, window time.Duration) (int, error) {
+	failures, err := s.queries.AddLoginFailure(ctx, database.AddLoginFailureParams{
+		Key:         key,
+		FailedAt:    timestamptz(now),
+		WindowStart: timestamptz(now.Add(-window)),
+	})
+	return int(failures), err
+}
+
+// RemoveFailure takes back a failure counted by AddFailure
+func (s *PostgresStore) RemoveFailure(ctx context.Context, key string) error {
+	return s.queries.RemoveLoginFailure(ctx, key)
+}
+
+// SetFailures sets the failure count of a key
+func (s *PostgresStore) SetFailures(ctx context.Context, key string, failures int) error {
+	return s.queries.SetLoginFailures(ctx, database.SetLoginFailuresParams{
+		Key:      key,
+		Failures: int32(failures),
+	})
+}
+
+// Block refuses attempts for a key until the given time
+func (s *PostgresStore) Block(ctx context.Context, key string, until time.Time) error {
+	return s.queries.BlockLoginKey(ctx, database.BlockLoginKeyParams{
+		Key:          key,
+		BlockedUntil: timestamptz(until),
+	})
+}
+
+// Reset forgets a key
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	return s.queries.DeleteLoginAttempt(ctx, key)
+}
+
+// Purge deletes keys that no longer affect any attempt
+func (s *PostgresStore) Purge(ctx context.Context, now time.Time, window time.Duration) (int64, error) {
+	return s.queries.DeleteStaleLoginAttempts(ctx, database.DeleteStaleLoginAttemptsParams{
+		WindowStart: timestamptz(now.Add(-window)),
+		Now:         timestamptz(now),
+	})
+}
+
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
@@ -0,0 +1,37 @@
+package pricing
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAlreadyCheckedIn is returned when a stay is cancelled after check-in
+var ErrAlreadyCheckedIn = errors.New("reservation can no longer be cancelled after check-in")
+
+// CancellationPolicy decides how much of the total price is retained on cancellation.
+// Cancelling at least Cutoff before check-in is free; later cancellations are
+// charged FeePercent of the total. Stays cannot be cancelled once check-in has passed.
+type CancellationPolicy struct {
+	Cutoff     time.Duration
+	FeePercent int64
+}
+
+// DefaultCancellationPolicy returns the policy used when nothing is configured.
+func DefaultCancellationPolicy() CancellationPolicy {
+	return CancellationPolicy{
+		Cutoff:     72 * time.Hour,
+		FeePercent: 50,
+	}
+}
+
+// Fee returns the cancellation fee for a stay of the given total price
+// starting at checkIn, cancelled at now.
+func (p CancellationPolicy) Fee(total int64, checkIn, now time.Time) (int64, error) {
+	if !now.Before(checkIn) {
+		return 0, ErrAlreadyCheckedIn
+	}
+	if checkIn.Sub(now) >= p.Cutoff {
+		return 0, nil
+	}
+	return percentOf(total, p.FeePercent), nil
+}
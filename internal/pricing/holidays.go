@@ -0,0 +1,142 @@
+package pricing
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// holidayCache memoizes the holidays of each year (map[int]map[string]string)
+var holidayCache sync.Map
+
+// HolidayName returns the name of the Japanese national holiday on the given date.
+// The second return value is false if the date is not a national holiday.
+// Only the calendar date (year, month, day) of t is considered.
+func HolidayName(t time.Time) (string, bool) {
+	year := t.Year()
+	cached, ok := holidayCache.Load(year)
+	if !ok {
+		cached, _ = holidayCache.LoadOrStore(year, holidaysOf(year))
+	}
+	name, ok := cached.(map[string]string)[dateKey(t)]
+	return name, ok
+}
+
+// IsHoliday reports whether the given date is a Japanese national holiday.
+func IsHoliday(t time.Time) bool {
+	_, ok := HolidayName(t)
+	return ok
+}
+
+// holidaysOf computes all national holidays of a year under the
+// Act on National Holidays (国民の祝日に関する法律), including substitute
+// holidays (振替休日) and citizens' holidays (国民の休日).
+// Rules are implemented for 2000 onwards, which covers every bookable date.
+func holidaysOf(year int) map[string]string {
+	holidays := make(map[string]string)
+	add := func(month time.Month, day int, name string) {
+		holidays[dateKey(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))] = name
+	}
+
+	add(time.January, 1, "元日")
+	add(time.January, nthMonday(year, time.January, 2), "成人の日")
+	add(time.February, 11, "建国記念の日")
+	if year >= 2020 {
+		add(time.February, 23, "天皇誕生日")
+	}
+	add(time.March, vernalEquinoxDay(year), "春分の日")
+	add(time.April, 29, "昭和の日")
+	add(time.May, 3, "憲法記念日")
+	add(time.May, 4, "みどりの日")
+	add(time.May, 5, "こどもの日")
+
+	// Marine Day, Sports Day and Mountain Day were moved for the Tokyo Olympics
+	switch year {
+	case 2020:
+		add(time.July, 23, "海の日")
+		add(time.July, 24, "スポーツの日")
+		add(time.August, 10, "山の日")
+	case 2021:
+		add(time.July, 22, "海の日")
+		add(time.July, 23, "スポーツの日")
+		add(time.August, 8, "山の日")
+	default:
+		add(time.July, nthMonday(year, time.July, 3), "海の日")
+		if year >= 2016 {
+			add(time.August, 11, "山の日")
+		}
+		name := "体育の日"
+		if year >= 2020 {
+			name = "スポーツの日"
+		}
+		add(time.October, nthMonday(year, time.October, 2), name)
+	}
+
+	add(time.September, nthMonday(year, time.September, 3), "敬老の日")
+	add(time.September, autumnalEquinoxDay(year), "秋分の日")
+	add(time.November, 3, "文化の日")
+	add(time.November, 23, "勤労感謝の日")
+	if year <= 2018 {
+		add(time.December, 23, "天皇誕生日")
+	}
+
+	// One-off holidays for the 2019 imperial succession
+	if year == 2019 {
+		add(time.May, 1, "即位の日")
+		add(time.October, 22, "即位礼正殿の儀の行われる日")
+	}
+
+	// Citizens' holiday: a weekday sandwiched between two holidays becomes a holiday
+	for day := time.Date(year, time.January, 2, 0, 0, 0, 0, time.UTC); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		key := dateKey(day)
+		if _, ok := holidays[key]; ok || day.Weekday() == time.Sunday {
+			continue
+		}
+		_, before := holidays[dateKey(day.AddDate(0, 0, -1))]
+		_, after := holidays[dateKey(day.AddDate(0, 0, 1))]
+		if before && after {
+			holidays[key] = "国民の休日"
+		}
+	}
+
+	// Substitute holiday: a holiday on Sunday moves to the next non-holiday day
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if _, ok := holidays[dateKey(day)]; !ok || day.Weekday() != time.Sunday {
+			continue
+		}
+		substitute := day.AddDate(0, 0, 1)
+		for {
+			if _, ok := holidays[dateKey(substitute)]; !ok {
+				break
+			}
+			substitute = substitute.AddDate(0, 0, 1)
+		}
+		if substitute.Year() == year {
+			holidays[dateKey(substitute)] = "振替休日"
+		}
+	}
+
+	return holidays
+}
+
+// nthMonday returns the day of month of the n-th Monday (Happy Monday system)
+func nthMonday(year int, month time.Month, n int) int {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+	return 1 + offset + (n-1)*7
+}
+
+// vernalEquinoxDay approximates the day of the vernal equinox in March (valid for 1980-2099)
+func vernalEquinoxDay(year int) int {
+	return int(math.Floor(20.8431+0.242194*float64(year-1980))) - (year-1980)/4
+}
+
+// autumnalEquinoxDay approximates the day of the autumnal equinox in September (valid for 1980-2099)
+func autumnalEquinoxDay(year int) int {
+	return int(math.Floor(23.2488+0.242194*float64(year-1980))) - (year-1980)/4
+}
+
+// dateKey formats the calendar date of t as YYYY-MM-DD
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
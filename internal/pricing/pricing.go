@@ -0,0 +1,228 @@
+// Package pricing computes the price of a stay from per-room rates and
+// platform-wide surcharge and discount rules.
+//
+// A stay is priced night by night: each night starts from the room's base rate
+// (or the seasonal rate covering that night), then weekend and holiday
+// surcharges are added. Length-of-stay discounts are applied to the subtotal.
+// All amounts are in yen.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Line item types
+const (
+	LineItemNight            = "NIGHT"
+	LineItemWeekendSurcharge = "WEEKEND_SURCHARGE"
+	LineItemHolidaySurcharge = "HOLIDAY_SURCHARGE"
+	LineItemStayDiscount     = "LENGTH_OF_STAY_DISCOUNT"
+)
+
+var (
+	// ErrInvalidStay is returned when check-out is not after check-in
+	ErrInvalidStay = errors.New("check-out must be after check-in")
+
+	// ErrMinimumStay is returned when the stay is shorter than the room's minimum
+	ErrMinimumStay = errors.New("stay is shorter than the minimum number of nights")
+)
+
+// Policy holds the platform-wide surcharge and discount rules.
+type Policy struct {
+	// WeekendSurchargePercent is added to Friday and Saturday nights.
+	WeekendSurchargePercent int64
+	// HolidaySurchargePercent is added to the night before a national holiday
+	// (休前日) unless the weekend surcharge already applies.
+	HolidaySurchargePercent int64
+	// StayDiscounts are length-of-stay discounts; the largest matching one applies.
+	StayDiscounts []StayDiscount
+}
+
+// StayDiscount is a percentage discount for stays of at least MinNights nights.
+type StayDiscount struct {
+	MinNights int
+	Percent   int64
+}
+
+// SeasonalRate overrides the base rate for nights in [Start, End).
+type SeasonalRate struct {
+	Name        string
+	Start       time.Time
+	End         time.Time
+	NightlyRate int64
+}
+
+// RoomRates holds the per-room pricing configuration.
+type RoomRates struct {
+	BaseRate      int64
+	MinStayNights int
+	Seasons       []SeasonalRate
+}
+
+// LineItem is one entry of an itemized price breakdown.
+// Amount is negative for discounts. Date is the zero time for whole-stay items.
+type LineItem struct {
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date,omitzero"`
+	Amount      int64     `json:"amount"`
+}
+
+// Night is the price of a single night including surcharges.
+type Night struct {
+	Date  time.Time
+	Price int64
+}
+
+// Quote is the computed price of a stay.
+type Quote struct {
+	Nights    []Night
+	LineItems []LineItem
+	Subtotal  int64 // Sum of all nights before discounts
+	Discount  int64 // Total discount (positive amount)
+	Total     int64
+}
+
+// DefaultPolicy returns the rules used when nothing is configured.
+func DefaultPolicy() Policy {
+	return Policy{
+		WeekendSurchargePercent: 20,
+		HolidaySurchargePercent: 20,
+		StayDiscounts: []StayDiscount{
+			{MinNights: 7, Percent: 10},
+			{MinNights: 28, Percent: 20},
+		},
+	}
+}
+
+// ParseStayDiscounts parses a comma-separated list of "minNights:percent" pairs,
+// e.g. "7:10,28:20".
+func ParseStayDiscounts(s string) ([]StayDiscount, error) {
+	var discounts []StayDiscount
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		nights, percent, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid stay discount %q (use minNights:percent)", part)
+		}
+		minNights, err := strconv.Atoi(nights)
+		if err != nil || minNights < 1 {
+			return nil, fmt.Errorf("invalid minimum nights in %q", part)
+		}
+		pct, err := strconv.ParseInt(percent, 10, 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return nil, fmt.Errorf("invalid percent in %q", part)
+		}
+		discounts = append(discounts, StayDiscount{MinNights: minNights, Percent: pct})
+	}
+	return discounts, nil
+}
+
+// Quote prices a stay from checkIn to checkOut (exclusive).
+// Only the calendar dates of checkIn and checkOut are used.
+func (p Policy) Quote(rates RoomRates, checkIn, checkOut time.Time) (Quote, error) {
+	start, end := truncateDate(checkIn), truncateDate(checkOut)
+	if !end.After(start) {
+		return Quote{}, ErrInvalidStay
+	}
+
+	var quote Quote
+	for night := start; night.Before(end); night = night.AddDate(0, 0, 1) {
+		rate, label := rates.rateFor(night)
+		items := []LineItem{{
+			Type:        LineItemNight,
+			Description: label,
+			Date:        night,
+			Amount:      rate,
+		}}
+
+		if isWeekendNight(night) && p.WeekendSurchargePercent > 0 {
+			items = append(items, LineItem{
+				Type:        LineItemWeekendSurcharge,
+				Description: fmt.Sprintf("Weekend surcharge (%d%%)", p.WeekendSurchargePercent),
+				Date:        night,
+				Amount:      percentOf(rate, p.WeekendSurchargePercent),
+			})
+		} else if name, ok := HolidayName(night.AddDate(0, 0, 1)); ok && p.HolidaySurchargePercent > 0 {
+			items = append(items, LineItem{
+				Type:        LineItemHolidaySurcharge,
+				Description: fmt.Sprintf("Holiday surcharge: eve of %s (%d%%)", name, p.HolidaySurchargePercent),
+				Date:        night,
+				Amount:      percentOf(rate, p.HolidaySurchargePercent),
+			})
+		}
+
+		var price int64
+		for _, item := range items {
+			price += item.Amount
+		}
+		quote.Nights = append(quote.Nights, Night{Date: night, Price: price})
+		quote.LineItems = append(quote.LineItems, items...)
+		quote.Subtotal += price
+	}
+
+	nights := len(quote.Nights)
+	if rates.MinStayNights > 0 && nights < rates.MinStayNights {
+		return Quote{}, fmt.Errorf("%w: %d nights required", ErrMinimumStay, rates.MinStayNights)
+	}
+
+	if discount, ok := p.stayDiscountFor(nights); ok && discount.Percent > 0 {
+		quote.Discount = percentOf(quote.Subtotal, discount.Percent)
+		quote.LineItems = append(quote.LineItems, LineItem{
+			Type:        LineItemStayDiscount,
+			Description: fmt.Sprintf("%d+ nights discount (%d%%)", discount.MinNights, discount.Percent),
+			Amount:      -quote.Discount,
+		})
+	}
+	quote.Total = quote.Subtotal - quote.Discount
+
+	return quote, nil
+}
+
+// rateFor returns the nightly rate for a night and a description of where it came from
+func (r RoomRates) rateFor(night time.Time) (int64, string) {
+	for _, season := range r.Seasons {
+		if !night.Before(truncateDate(season.Start)) && night.Before(truncateDate(season.End)) {
+			return season.NightlyRate, "Nightly rate: " + season.Name
+		}
+	}
+	return r.BaseRate, "Nightly rate"
+}
+
+// stayDiscountFor returns the largest discount whose minimum is met
+func (p Policy) stayDiscountFor(nights int) (StayDiscount, bool) {
+	discounts := append([]StayDiscount(nil), p.StayDiscounts...)
+	sort.Slice(discounts, func(i, j int) bool {
+		return discounts[i].MinNights > discounts[j].MinNights
+	})
+	for _, discount := range discounts {
+		if nights >= discount.MinNights {
+			return discount, true
+		}
+	}
+	return StayDiscount{}, false
+}
+
+// isWeekendNight reports whether the night is a Friday or Saturday night
+func isWeekendNight(night time.Time) bool {
+	return night.Weekday() == time.Friday || night.Weekday() == time.Saturday
+}
+
+// percentOf returns pct percent of amount, rounded down to the yen
+func percentOf(amount, pct int64) int64 {
+	return amount * pct / 100
+}
+
+// truncateDate returns midnight UTC of t's calendar date
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,252 @@
+package pricing
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// date returns midnight UTC of a calendar date
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestHolidayName(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string // empty for a day that is not a holiday
+	}{
+		// Fixed-date holidays
+		{date(2026, time.January, 1), "元日"},
+		{date(2026, time.February, 11), "建国記念の日"},
+		{date(2018, time.December, 23), "天皇誕生日"},
+		{date(2019, time.December, 23), ""},
+		{date(2020, time.February, 23), "天皇誕生日"},
+		{date(2026, time.November, 3), "文化の日"},
+
+		// Happy Monday holidays
+		{date(2026, time.January, 12), "成人の日"},
+		{date(2026, time.July, 20), "海の日"},
+		{date(2026, time.September, 21), "敬老の日"},
+		{date(2019, time.October, 14), "体育の日"},
+		{date(2026, time.October, 12), "スポーツの日"},
+
+		// Equinoxes
+		{date(2024, time.March, 20), "春分の日"},
+		{date(2026, time.March, 20), "春分の日"},
+		{date(2026, time.September, 23), "秋分の日"},
+
+		// Imperial succession (2019)
+		{date(2019, time.April, 30), "国民の休日"},
+		{date(2019, time.May, 1), "即位の日"},
+		{date(2019, time.May, 2), "国民の休日"},
+		{date(2019, time.May, 6), "振替休日"},
+		{date(2019, time.October, 22), "即位礼正殿の儀の行われる日"},
+
+		// Holidays moved for the Tokyo Olympics
+		{date(2020, time.July, 23), "海の日"},
+		{date(2020, time.July, 24), "スポーツの日"},
+		{date(2020, time.August, 10), "山の日"},
+		{date(2020, time.July, 20), ""},
+		{date(2020, time.August, 11), ""},
+		{date(2020, time.October, 12), ""},
+		{date(2021, time.July, 22), "海の日"},
+		{date(2021, time.July, 23), "スポーツの日"},
+		{date(2021, time.August, 8), "山の日"},
+		{date(2021, time.August, 9), "振替休日"},
+		{date(2021, time.July, 19), ""},
+
+		// Substitute holidays: the Monday after a Sunday holiday, or the next free day
+		{date(2023, time.January, 2), "振替休日"},
+		{date(2024, time.February, 12), "振替休日"},
+		{date(2024, time.September, 23), "振替休日"},
+		{date(2025, time.May, 6), "振替休日"},
+
+		// Citizens' holiday between 敬老の日 and 秋分の日
+		{date(2026, time.September, 22), "国民の休日"},
+		{date(2015, time.September, 22), "国民の休日"},
+
+		// Ordinary days
+		{date(2026, time.September, 24), ""},
+		{date(2026, time.May, 7), ""},
+	}
+	for _, tt := range tests {
+		t.Run(dateKey(tt.date), func(t *testing.T) {
+			got, ok := HolidayName(tt.date)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("HolidayName(%s) = %q, %t; want %q", dateKey(tt.date), got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestHolidayNameIgnoresTimeOfDay(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	if !IsHoliday(time.Date(2026, time.May, 5, 23, 30, 0, 0, jst)) {
+		t.Error("late evening of a holiday in JST is not a holiday")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	policy := Policy{WeekendSurchargePercent: 20, HolidaySurchargePercent: 10}
+	rates := RoomRates{
+		BaseRate: 10000,
+		Seasons: []SeasonalRate{{
+			Name:        "Silver Week",
+			Start:       date(2026, time.September, 21),
+			End:         date(2026, time.September, 23),
+			NightlyRate: 15000,
+		}},
+	}
+
+	// Friday to Thursday: a weekend, the eves of three holidays, and a season in the middle
+	checkIn := time.Date(2026, time.September, 18, 15, 0, 0, 0, time.UTC)
+	checkOut := time.Date(2026, time.September, 24, 10, 0, 0, 0, time.UTC)
+	quote, err := policy.Quote(rates, checkIn, checkOut)
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+
+	wantItems := []LineItem{
+		{LineItemNight, "Nightly rate", date(2026, time.September, 18), 10000},
+		{LineItemWeekendSurcharge, "Weekend surcharge (20%)", date(2026, time.September, 18), 2000},
+		{LineItemNight, "Nightly rate", date(2026, time.September, 19), 10000},
+		{LineItemWeekendSurcharge, "Weekend surcharge (20%)", date(2026, time.September, 19), 2000},
+		{LineItemNight, "Nightly rate", date(2026, time.September, 20), 10000},
+		{LineItemHolidaySurcharge, "Holiday surcharge: eve of 敬老の日 (10%)", date(2026, time.September, 20), 1000},
+		{LineItemNight, "Nightly rate: Silver Week", date(2026, time.September, 21), 15000},
+		{LineItemHolidaySurcharge, "Holiday surcharge: eve of 国民の休日 (10%)", date(2026, time.September, 21), 1500},
+		{LineItemNight, "Nightly rate: Silver Week", date(2026, time.September, 22), 15000},
+		{LineItemHolidaySurcharge, "Holiday surcharge: eve of 秋分の日 (10%)", date(2026, time.September, 22), 1500},
+		{LineItemNight, "Nightly rate", date(2026, time.September, 23), 10000},
+	}
+	if !reflect.DeepEqual(quote.LineItems, wantItems) {
+		t.Errorf("line items:\n got %+v\nwant %+v", quote.LineItems, wantItems)
+	}
+
+	wantNights := []int64{12000, 12000, 11000, 16500, 16500, 10000}
+	if len(quote.Nights) != len(wantNights) {
+		t.Fatalf("%d nights, want %d", len(quote.Nights), len(wantNights))
+	}
+	for i, night := range quote.Nights {
+		if night.Price != wantNights[i] || !night.Date.Equal(date(2026, time.September, 18+i)) {
+			t.Errorf("night %d = %s %d, want %s %d", i, dateKey(night.Date), night.Price,
+				dateKey(date(2026, time.September, 18+i)), wantNights[i])
+		}
+	}
+	if quote.Subtotal != 78000 || quote.Discount != 0 || quote.Total != 78000 {
+		t.Errorf("subtotal, discount, total = %d, %d, %d; want 78000, 0, 78000", quote.Subtotal, quote.Discount, quote.Total)
+	}
+}
+
+func TestQuoteStayDiscounts(t *testing.T) {
+	// Without surcharges every night costs the base rate; the tiers are deliberately unsorted
+	policy := Policy{StayDiscounts: []StayDiscount{{MinNights: 28, Percent: 20}, {MinNights: 7, Percent: 10}}}
+	rates := RoomRates{BaseRate: 10000}
+	checkIn := date(2026, time.January, 5)
+
+	tests := []struct {
+		nights       int
+		wantDiscount int64
+		wantItem     string // description of the discount line item; empty for none
+	}{
+		{1, 0, ""},
+		{6, 0, ""},
+		{7, 7000, "7+ nights discount (10%)"},
+		{27, 27000, "7+ nights discount (10%)"},
+		{28, 56000, "28+ nights discount (20%)"},
+		{40, 80000, "28+ nights discount (20%)"},
+	}
+	for _, tt := range tests {
+		quote, err := policy.Quote(rates, checkIn, checkIn.AddDate(0, 0, tt.nights))
+		if err != nil {
+			t.Fatalf("%d nights: Quote: %v", tt.nights, err)
+		}
+		subtotal := int64(tt.nights) * 10000
+		if quote.Subtotal != subtotal || quote.Discount != tt.wantDiscount || quote.Total != subtotal-tt.wantDiscount {
+			t.Errorf("%d nights: subtotal, discount, total = %d, %d, %d; want %d, %d, %d", tt.nights,
+				quote.Subtotal, quote.Discount, quote.Total, subtotal, tt.wantDiscount, subtotal-tt.wantDiscount)
+		}
+
+		last := quote.LineItems[len(quote.LineItems)-1]
+		if tt.wantItem == "" {
+			if last.Type == LineItemStayDiscount {
+				t.Errorf("%d nights: unexpected discount %+v", tt.nights, last)
+			}
+			continue
+		}
+		want := LineItem{Type: LineItemStayDiscount, Description: tt.wantItem, Amount: -tt.wantDiscount}
+		if last != want {
+			t.Errorf("%d nights: discount item = %+v, want %+v", tt.nights, last, want)
+		}
+	}
+}
+
+func TestQuoteErrors(t *testing.T) {
+	policy := DefaultPolicy()
+	checkIn := date(2026, time.March, 2)
+
+	tests := []struct {
+		name     string
+		rates    RoomRates
+		checkOut time.Time
+		want     error
+	}{
+		{"same day", RoomRates{BaseRate: 10000}, checkIn.Add(20 * time.Hour), ErrInvalidStay},
+		{"check-out before check-in", RoomRates{BaseRate: 10000}, checkIn.AddDate(0, 0, -1), ErrInvalidStay},
+		{"shorter than the minimum", RoomRates{BaseRate: 10000, MinStayNights: 3}, checkIn.AddDate(0, 0, 2), ErrMinimumStay},
+		{"minimum met", RoomRates{BaseRate: 10000, MinStayNights: 3}, checkIn.AddDate(0, 0, 3), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := policy.Quote(tt.rates, checkIn, tt.checkOut)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Quote error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStayDiscounts(t *testing.T) {
+	got, err := ParseStayDiscounts(" 7:10, 28:20 ,")
+	if err != nil {
+		t.Fatalf("ParseStayDiscounts: %v", err)
+	}
+	want := []StayDiscount{{MinNights: 7, Percent: 10}, {MinNights: 28, Percent: 20}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseStayDiscounts = %+v, want %+v", got, want)
+	}
+
+	for _, s := range []string{"7", "0:10", "x:10", "7:101", "7:-1"} {
+		if _, err := ParseStayDiscounts(s); err == nil {
+			t.Errorf("ParseStayDiscounts(%q) succeeded", s)
+		}
+	}
+}
+
+func TestCancellationFee(t *testing.T) {
+	policy := DefaultCancellationPolicy()
+	checkIn := time.Date(2026, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		want    int64
+		wantErr error
+	}{
+		{"well before the cutoff", checkIn.AddDate(0, 0, -10), 0, nil},
+		{"at the cutoff", checkIn.Add(-72 * time.Hour), 0, nil},
+		{"after the cutoff", checkIn.Add(-71 * time.Hour), 15000, nil},
+		{"at check-in", checkIn, 0, ErrAlreadyCheckedIn},
+		{"after check-in", checkIn.Add(time.Hour), 0, ErrAlreadyCheckedIn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.Fee(30000, checkIn, tt.now)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Fee = %d, %v; want %d, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
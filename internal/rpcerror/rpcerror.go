@@ -0,0 +1,61 @@
+// Package rpcerror builds the gRPC status errors returned by the services.
+//
+// Besides the status code, errors carry details from google.golang.org/genproto/googleapis/rpc/errdetails
+// that the API Gateway turns into its HTTP problem responses:
+//   - ErrorInfo: a machine-readable reason, e.g. EMAIL_ALREADY_REGISTERED
+//   - BadRequest: the request fields that are invalid
+//   - RetryInfo: how long the client should wait before retrying
+//
+// Messages are meant for clients. Internal errors use a generic message and the cause is logged instead.
+package rpcerror
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is the ErrorInfo domain of every error built by this package
+const Domain = "smart-stay"
+
+// New returns an error with a code and a reason (UPPER_SNAKE_CASE) for clients to act on
+func New(code codes.Code, reason, message string) error {
+	return withDetails(code, message, &errdetails.ErrorInfo{Reason: reason, Domain: Domain})
+}
+
+// InvalidField returns an InvalidArgument error for one request field
+func InvalidField(field, message string) error {
+	return withDetails(codes.InvalidArgument, message,
+		&errdetails.ErrorInfo{Reason: "INVALID_ARGUMENT", Domain: Domain},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: message},
+		}},
+	)
+}
+
+// Retryable returns an error with a reason that the client may retry after delay
+func Retryable(code codes.Code, reason, message string, delay time.Duration) error {
+	return withDetails(code, message,
+		&errdetails.ErrorInfo{Reason: reason, Domain: Domain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+	)
+}
+
+// Internal returns an Internal error. message should say what failed without revealing why.
+func Internal(message string) error {
+	return status.Error(codes.Internal, message)
+}
+
+// withDetails returns a status error with details. Should the details fail to encode,
+// the error is returned without them rather than losing the code.
+func withDetails(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st, err := status.New(code, message).WithDetails(details...)
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -0,0 +1,134 @@
+// Package scheduler runs periodic background jobs on one instance of a service at a time.
+//
+// Every instance campaigns for a session-level Postgres advisory lock named after the
+// scheduler. The instance holding the lock is the leader and runs the jobs; the others
+// retry periodically and take over when the leader's connection goes away.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// campaignInterval is how often a follower tries to become the leader
+	campaignInterval = 15 * time.Second
+	// healthCheckInterval is how often the leader checks that it still holds the lock
+	healthCheckInterval = 10 * time.Second
+)
+
+// Job is a task run periodically by the leader
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs jobs while holding the advisory lock for its name
+type Scheduler struct {
+	db   *pgxpool.Pool
+	name string
+	jobs []Job
+}
+
+// New creates a scheduler. Instances sharing a name elect a single leader.
+func New(db *pgxpool.Pool, name string) *Scheduler {
+	return &Scheduler{db: db, name: name}
+}
+
+// Every registers a job run every interval while this instance is the leader
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Run campaigns for leadership and runs the jobs while leading, until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	log.Printf("⏰ Scheduler %q started with %d job(s)", s.name, len(s.jobs))
+	for {
+		if err := s.lead(ctx); err != nil {
+			log.Printf("❌ Scheduler %q lost leadership: %v", s.name, err)
+		}
+		select {
+		case <-ctx.Done():
+			log.Printf("⏰ Scheduler %q stopped", s.name)
+			return
+		case <-time.After(campaignInterval):
+		}
+	}
+}
+
+// lead acquires the advisory lock and runs the jobs until ctx is cancelled or the lock is lost.
+// It returns nil without running anything if another instance is the leader.
+func (s *Scheduler) lead(ctx context.Context) error {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The lock belongs to this connection, so it must not go back to the pool still held
+	defer conn.Release()
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", s.name).Scan(&acquired); err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.Exec(unlockCtx, "SELECT pg_advisory_unlock(hashtext($1))", s.name); err != nil {
+			// Closing the connection releases the lock as well
+			conn.Conn().Close(unlockCtx)
+		}
+	}()
+	log.Printf("👑 Scheduler %q is now the leader", s.name)
+
+	jobCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	done := make(chan struct{}, len(s.jobs))
+	for _, job := range s.jobs {
+		go func() {
+			runJob(jobCtx, job)
+			done <- struct{}{}
+		}()
+	}
+	defer func() {
+		stopJobs()
+		for range s.jobs {
+			<-done
+		}
+	}()
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := conn.Ping(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// runJob runs a job immediately and then every interval until ctx is cancelled
+func runJob(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("❌ Scheduled job %q failed: %v", job.Name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
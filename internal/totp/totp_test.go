@@ -0,0 +1,143 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 secret of the RFC 4226 and RFC 6238 test vectors
+var rfcSecret = []byte("12345678901234567890")
+
+func TestCodeHOTPVectors(t *testing.T) {
+	// RFC 4226, Appendix D
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, code := range want {
+		if got := Code(rfcSecret, int64(counter)); got != code {
+			t.Errorf("Code(counter %d) = %s, want %s", counter, got, code)
+		}
+	}
+}
+
+func TestCodeTOTPVectors(t *testing.T) {
+	// RFC 6238, Appendix B (SHA-1); the 8-digit codes there end in these 6 digits
+	tests := []struct {
+		unix int64
+		step int64
+		code string
+	}{
+		{59, 0x1, "287082"},
+		{1111111109, 0x23523EC, "081804"},
+		{1111111111, 0x23523ED, "050471"},
+		{1234567890, 0x273EF07, "005924"},
+		{2000000000, 0x3F940AA, "279037"},
+		{20000000000, 0x27BC86AA, "353130"},
+	}
+	for _, tt := range tests {
+		at := time.Unix(tt.unix, 0)
+		if got := Step(at); got != tt.step {
+			t.Errorf("Step(%d) = %#x, want %#x", tt.unix, got, tt.step)
+		}
+		if got := Code(rfcSecret, Step(at)); got != tt.code {
+			t.Errorf("code at %d = %s, want %s", tt.unix, got, tt.code)
+		}
+		if step, ok := Validate(rfcSecret, tt.code, at); !ok || step != tt.step {
+			t.Errorf("Validate(%s at %d) = %#x, %t; want %#x, true", tt.code, tt.unix, step, ok, tt.step)
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+	current := Step(at)
+
+	tests := []struct {
+		name   string
+		offset int64
+		ok     bool
+	}{
+		{"two steps early", -Skew - 1, false},
+		{"one step early", -Skew, true},
+		{"current step", 0, true},
+		{"one step late", Skew, true},
+		{"two steps late", Skew + 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, Code(rfcSecret, current+tt.offset), at)
+			if ok != tt.ok {
+				t.Fatalf("Validate = %t, want %t", ok, tt.ok)
+			}
+			if ok && step != current+tt.offset {
+				t.Errorf("matched step %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	at := time.Unix(59, 0)
+	for _, code := range []string{" 287082 ", "287082\n"} {
+		if _, ok := Validate(rfcSecret, code, at); !ok {
+			t.Errorf("Validate(%q) rejected a code with surrounding space", code)
+		}
+	}
+	for _, code := range []string{"", "28708", "2870820", "94287082", "287083"} {
+		if _, ok := Validate(rfcSecret, code, at); ok {
+			t.Errorf("Validate(%q) accepted an invalid code", code)
+		}
+	}
+}
+
+func TestEncodeSecret(t *testing.T) {
+	if got, want := EncodeSecret(rfcSecret), "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"; got != want {
+		t.Errorf("EncodeSecret = %s, want %s", got, want)
+	}
+	// Secrets of other lengths are encoded without padding
+	if got := EncodeSecret([]byte("abc")); strings.Contains(got, "=") {
+		t.Errorf("EncodeSecret = %s, want no padding", got)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	b, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	if len(a) != SecretSize || string(a) == string(b) {
+		t.Errorf("GenerateSecret returned %d bytes, or the same secret twice", len(a))
+	}
+}
+
+func TestURI(t *testing.T) {
+	raw := URI("Smart Stay", "guest@example.com", rfcSecret)
+	if strings.Contains(raw, "+") {
+		t.Errorf("URI %s encodes spaces as +", raw)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("url.Parse(%s): %v", raw, err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Smart Stay:guest@example.com" {
+		t.Errorf("URI = %s", raw)
+	}
+	want := map[string]string{
+		"secret":    "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		"issuer":    "Smart Stay",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	}
+	query := u.Query()
+	for name, value := range want {
+		if got := query.Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+}
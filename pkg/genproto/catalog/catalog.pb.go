@@ -0,0 +1,2062 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: catalog.proto
+
+package catalog
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Property struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`                  // IANA time zone name (e.g., "Asia/Tokyo").
+	IsActive      bool                   `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"` // Inactive properties cannot be booked.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CheckInTime   string                 `protobuf:"bytes,8,opt,name=check_in_time,json=checkInTime,proto3" json:"check_in_time,omitempty"`    // Local check-in time "HH:MM" (e.g., "15:00").
+	CheckOutTime  string                 `protobuf:"bytes,9,opt,name=check_out_time,json=checkOutTime,proto3" json:"check_out_time,omitempty"` // Local check-out time "HH:MM" (e.g., "10:00").
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Property) Reset() {
+	*x = Property{}
+	mi := &file_catalog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Property) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Property) ProtoMessage() {}
+
+func (x *Property) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Property.ProtoReflect.Descriptor instead.
+func (*Property) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Property) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Property) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Property) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Property) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Property) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Property) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Property) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Property) GetCheckInTime() string {
+	if x != nil {
+		return x.CheckInTime
+	}
+	return ""
+}
+
+func (x *Property) GetCheckOutTime() string {
+	if x != nil {
+		return x.CheckOutTime
+	}
+	return ""
+}
+
+type Room struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // The room_id used by reservations.
+	PropertyId    int64                  `protobuf:"varint,2,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"` // Maximum number of guests.
+	Amenities     []string               `protobuf:"bytes,5,rep,name=amenities,proto3" json:"amenities,omitempty"`
+	LockDeviceId  string                 `protobuf:"bytes,6,opt,name=lock_device_id,json=lockDeviceId,proto3" json:"lock_device_id,omitempty"` // Smart lock installed in the room (empty if none).
+	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`              // Inactive rooms cannot be booked.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	BaseRate      int64                  `protobuf:"varint,9,opt,name=base_rate,json=baseRate,proto3" json:"base_rate,omitempty"`                   // Nightly rate in yen outside any seasonal rate.
+	MinStayNights int32                  `protobuf:"varint,10,opt,name=min_stay_nights,json=minStayNights,proto3" json:"min_stay_nights,omitempty"` // Minimum number of nights per booking.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Room) Reset() {
+	*x = Room{}
+	mi := &file_catalog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Room) ProtoMessage() {}
+
+func (x *Room) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Room.ProtoReflect.Descriptor instead.
+func (*Room) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Room) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Room) GetPropertyId() int64 {
+	if x != nil {
+		return x.PropertyId
+	}
+	return 0
+}
+
+func (x *Room) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Room) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Room) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+func (x *Room) GetLockDeviceId() string {
+	if x != nil {
+		return x.LockDeviceId
+	}
+	return ""
+}
+
+func (x *Room) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Room) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Room) GetBaseRate() int64 {
+	if x != nil {
+		return x.BaseRate
+	}
+	return 0
+}
+
+func (x *Room) GetMinStayNights() int32 {
+	if x != nil {
+		return x.MinStayNights
+	}
+	return 0
+}
+
+// Overrides a room's base rate for nights in [start_date, end_date).
+type SeasonalRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // e.g., "Golden Week", "Obon".
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"` // Exclusive.
+	NightlyRate   int64                  `protobuf:"varint,6,opt,name=nightly_rate,json=nightlyRate,proto3" json:"nightly_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeasonalRate) Reset() {
+	*x = SeasonalRate{}
+	mi := &file_catalog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeasonalRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeasonalRate) ProtoMessage() {}
+
+func (x *SeasonalRate) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeasonalRate.ProtoReflect.Descriptor instead.
+func (*SeasonalRate) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SeasonalRate) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SeasonalRate) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SeasonalRate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SeasonalRate) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *SeasonalRate) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *SeasonalRate) GetNightlyRate() int64 {
+	if x != nil {
+		return x.NightlyRate
+	}
+	return 0
+}
+
+type CreatePropertyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Timezone      string                 `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`                               // Defaults to "Asia/Tokyo" when empty.
+	CheckInTime   string                 `protobuf:"bytes,5,opt,name=check_in_time,json=checkInTime,proto3" json:"check_in_time,omitempty"`    // "HH:MM"; defaults to "15:00" when empty.
+	CheckOutTime  string                 `protobuf:"bytes,6,opt,name=check_out_time,json=checkOutTime,proto3" json:"check_out_time,omitempty"` // "HH:MM"; defaults to "10:00" when empty.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePropertyRequest) Reset() {
+	*x = CreatePropertyRequest{}
+	mi := &file_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePropertyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePropertyRequest) ProtoMessage() {}
+
+func (x *CreatePropertyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePropertyRequest.ProtoReflect.Descriptor instead.
+func (*CreatePropertyRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreatePropertyRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *CreatePropertyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePropertyRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreatePropertyRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreatePropertyRequest) GetCheckInTime() string {
+	if x != nil {
+		return x.CheckInTime
+	}
+	return ""
+}
+
+func (x *CreatePropertyRequest) GetCheckOutTime() string {
+	if x != nil {
+		return x.CheckOutTime
+	}
+	return ""
+}
+
+type CreatePropertyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Property      *Property              `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePropertyResponse) Reset() {
+	*x = CreatePropertyResponse{}
+	mi := &file_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePropertyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePropertyResponse) ProtoMessage() {}
+
+func (x *CreatePropertyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePropertyResponse.ProtoReflect.Descriptor instead.
+func (*CreatePropertyResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreatePropertyResponse) GetProperty() *Property {
+	if x != nil {
+		return x.Property
+	}
+	return nil
+}
+
+type GetPropertyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	PropertyId    int64                  `protobuf:"varint,2,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPropertyRequest) Reset() {
+	*x = GetPropertyRequest{}
+	mi := &file_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPropertyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPropertyRequest) ProtoMessage() {}
+
+func (x *GetPropertyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPropertyRequest.ProtoReflect.Descriptor instead.
+func (*GetPropertyRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPropertyRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *GetPropertyRequest) GetPropertyId() int64 {
+	if x != nil {
+		return x.PropertyId
+	}
+	return 0
+}
+
+type GetPropertyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Property      *Property              `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPropertyResponse) Reset() {
+	*x = GetPropertyResponse{}
+	mi := &file_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPropertyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPropertyResponse) ProtoMessage() {}
+
+func (x *GetPropertyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPropertyResponse.ProtoReflect.Descriptor instead.
+func (*GetPropertyResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPropertyResponse) GetProperty() *Property {
+	if x != nil {
+		return x.Property
+	}
+	return nil
+}
+
+type ListPropertiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPropertiesRequest) Reset() {
+	*x = ListPropertiesRequest{}
+	mi := &file_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPropertiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPropertiesRequest) ProtoMessage() {}
+
+func (x *ListPropertiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPropertiesRequest.ProtoReflect.Descriptor instead.
+func (*ListPropertiesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPropertiesRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type ListPropertiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Properties    []*Property            `protobuf:"bytes,1,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPropertiesResponse) Reset() {
+	*x = ListPropertiesResponse{}
+	mi := &file_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPropertiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPropertiesResponse) ProtoMessage() {}
+
+func (x *ListPropertiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPropertiesResponse.ProtoReflect.Descriptor instead.
+func (*ListPropertiesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListPropertiesResponse) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type UpdatePropertyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	PropertyId    int64                  `protobuf:"varint,2,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	IsActive      bool                   `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CheckInTime   string                 `protobuf:"bytes,7,opt,name=check_in_time,json=checkInTime,proto3" json:"check_in_time,omitempty"`    // "HH:MM"; defaults to "15:00" when empty.
+	CheckOutTime  string                 `protobuf:"bytes,8,opt,name=check_out_time,json=checkOutTime,proto3" json:"check_out_time,omitempty"` // "HH:MM"; defaults to "10:00" when empty.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePropertyRequest) Reset() {
+	*x = UpdatePropertyRequest{}
+	mi := &file_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePropertyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePropertyRequest) ProtoMessage() {}
+
+func (x *UpdatePropertyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePropertyRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePropertyRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdatePropertyRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *UpdatePropertyRequest) GetPropertyId() int64 {
+	if x != nil {
+		return x.PropertyId
+	}
+	return 0
+}
+
+func (x *UpdatePropertyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePropertyRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *UpdatePropertyRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdatePropertyRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UpdatePropertyRequest) GetCheckInTime() string {
+	if x != nil {
+		return x.CheckInTime
+	}
+	return ""
+}
+
+func (x *UpdatePropertyRequest) GetCheckOutTime() string {
+	if x != nil {
+		return x.CheckOutTime
+	}
+	return ""
+}
+
+type UpdatePropertyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Property      *Property              `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePropertyResponse) Reset() {
+	*x = UpdatePropertyResponse{}
+	mi := &file_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePropertyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePropertyResponse) ProtoMessage() {}
+
+func (x *UpdatePropertyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePropertyResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePropertyResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdatePropertyResponse) GetProperty() *Property {
+	if x != nil {
+		return x.Property
+	}
+	return nil
+}
+
+type DeletePropertyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	PropertyId    int64                  `protobuf:"varint,2,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePropertyRequest) Reset() {
+	*x = DeletePropertyRequest{}
+	mi := &file_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePropertyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePropertyRequest) ProtoMessage() {}
+
+func (x *DeletePropertyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePropertyRequest.ProtoReflect.Descriptor instead.
+func (*DeletePropertyRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeletePropertyRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *DeletePropertyRequest) GetPropertyId() int64 {
+	if x != nil {
+		return x.PropertyId
+	}
+	return 0
+}
+
+type DeletePropertyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePropertyResponse) Reset() {
+	*x = DeletePropertyResponse{}
+	mi := &file_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePropertyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePropertyResponse) ProtoMessage() {}
+
+func (x *DeletePropertyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePropertyResponse.ProtoReflect.Descriptor instead.
+func (*DeletePropertyResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeletePropertyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	PropertyId    int64                  `protobuf:"varint,2,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Amenities     []string               `protobuf:"bytes,5,rep,name=amenities,proto3" json:"amenities,omitempty"`
+	LockDeviceId  string                 `protobuf:"bytes,6,opt,name=lock_device_id,json=lockDeviceId,proto3" json:"lock_device_id,omitempty"`
+	BaseRate      int64                  `protobuf:"varint,7,opt,name=base_rate,json=baseRate,proto3" json:"base_rate,omitempty"`
+	MinStayNights int32                  `protobuf:"varint,8,opt,name=min_stay_nights,json=minStayNights,proto3" json:"min_stay_nights,omitempty"` // Defaults to 1 when unset.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
+	mi := &file_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomRequest) ProtoMessage() {}
+
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateRoomRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetPropertyId() int64 {
+	if x != nil {
+		return x.PropertyId
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+func (x *CreateRoomRequest) GetLockDeviceId() string {
+	if x != nil {
+		return x.LockDeviceId
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetBaseRate() int64 {
+	if x != nil {
+		return x.BaseRate
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetMinStayNights() int32 {
+	if x != nil {
+		return x.MinStayNights
+	}
+	return 0
+}
+
+type CreateRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Room          *Room                  `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomResponse) Reset() {
+	*x = CreateRoomResponse{}
+	mi := &file_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomResponse) ProtoMessage() {}
+
+func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoomResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateRoomResponse) GetRoom() *Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type GetRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomRequest) Reset() {
+	*x = GetRoomRequest{}
+	mi := &file_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomRequest) ProtoMessage() {}
+
+func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRoomRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *GetRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type GetRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Room          *Room                  `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomResponse) Reset() {
+	*x = GetRoomResponse{}
+	mi := &file_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomResponse) ProtoMessage() {}
+
+func (x *GetRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomResponse.ProtoReflect.Descriptor instead.
+func (*GetRoomResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRoomResponse) GetRoom() *Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type ListRoomsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	PropertyId    int64                  `protobuf:"varint,2,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsRequest) Reset() {
+	*x = ListRoomsRequest{}
+	mi := &file_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsRequest) ProtoMessage() {}
+
+func (x *ListRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListRoomsRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ListRoomsRequest) GetPropertyId() int64 {
+	if x != nil {
+		return x.PropertyId
+	}
+	return 0
+}
+
+type ListRoomsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsResponse) Reset() {
+	*x = ListRoomsResponse{}
+	mi := &file_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsResponse) ProtoMessage() {}
+
+func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRoomsResponse) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type UpdateRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Amenities     []string               `protobuf:"bytes,5,rep,name=amenities,proto3" json:"amenities,omitempty"`
+	LockDeviceId  string                 `protobuf:"bytes,6,opt,name=lock_device_id,json=lockDeviceId,proto3" json:"lock_device_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	BaseRate      int64                  `protobuf:"varint,8,opt,name=base_rate,json=baseRate,proto3" json:"base_rate,omitempty"`
+	MinStayNights int32                  `protobuf:"varint,9,opt,name=min_stay_nights,json=minStayNights,proto3" json:"min_stay_nights,omitempty"` // Defaults to 1 when unset.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoomRequest) Reset() {
+	*x = UpdateRoomRequest{}
+	mi := &file_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomRequest) ProtoMessage() {}
+
+func (x *UpdateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateRoomRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *UpdateRoomRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *UpdateRoomRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+func (x *UpdateRoomRequest) GetLockDeviceId() string {
+	if x != nil {
+		return x.LockDeviceId
+	}
+	return ""
+}
+
+func (x *UpdateRoomRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateRoomRequest) GetBaseRate() int64 {
+	if x != nil {
+		return x.BaseRate
+	}
+	return 0
+}
+
+func (x *UpdateRoomRequest) GetMinStayNights() int32 {
+	if x != nil {
+		return x.MinStayNights
+	}
+	return 0
+}
+
+type UpdateRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Room          *Room                  `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoomResponse) Reset() {
+	*x = UpdateRoomResponse{}
+	mi := &file_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomResponse) ProtoMessage() {}
+
+func (x *UpdateRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRoomResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateRoomResponse) GetRoom() *Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type DeleteRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoomRequest) Reset() {
+	*x = DeleteRoomRequest{}
+	mi := &file_catalog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoomRequest) ProtoMessage() {}
+
+func (x *DeleteRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoomRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoomRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteRoomRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *DeleteRoomRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type DeleteRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoomResponse) Reset() {
+	*x = DeleteRoomResponse{}
+	mi := &file_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoomResponse) ProtoMessage() {}
+
+func (x *DeleteRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoomResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRoomResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteRoomResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateSeasonalRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	NightlyRate   int64                  `protobuf:"varint,6,opt,name=nightly_rate,json=nightlyRate,proto3" json:"nightly_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSeasonalRateRequest) Reset() {
+	*x = CreateSeasonalRateRequest{}
+	mi := &file_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSeasonalRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSeasonalRateRequest) ProtoMessage() {}
+
+func (x *CreateSeasonalRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSeasonalRateRequest.ProtoReflect.Descriptor instead.
+func (*CreateSeasonalRateRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateSeasonalRateRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *CreateSeasonalRateRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *CreateSeasonalRateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSeasonalRateRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *CreateSeasonalRateRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *CreateSeasonalRateRequest) GetNightlyRate() int64 {
+	if x != nil {
+		return x.NightlyRate
+	}
+	return 0
+}
+
+type CreateSeasonalRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeasonalRate  *SeasonalRate          `protobuf:"bytes,1,opt,name=seasonal_rate,json=seasonalRate,proto3" json:"seasonal_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSeasonalRateResponse) Reset() {
+	*x = CreateSeasonalRateResponse{}
+	mi := &file_catalog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSeasonalRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSeasonalRateResponse) ProtoMessage() {}
+
+func (x *CreateSeasonalRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSeasonalRateResponse.ProtoReflect.Descriptor instead.
+func (*CreateSeasonalRateResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateSeasonalRateResponse) GetSeasonalRate() *SeasonalRate {
+	if x != nil {
+		return x.SeasonalRate
+	}
+	return nil
+}
+
+type ListSeasonalRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	RoomId        int64                  `protobuf:"varint,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeasonalRatesRequest) Reset() {
+	*x = ListSeasonalRatesRequest{}
+	mi := &file_catalog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeasonalRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeasonalRatesRequest) ProtoMessage() {}
+
+func (x *ListSeasonalRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeasonalRatesRequest.ProtoReflect.Descriptor instead.
+func (*ListSeasonalRatesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSeasonalRatesRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ListSeasonalRatesRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type ListSeasonalRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeasonalRates []*SeasonalRate        `protobuf:"bytes,1,rep,name=seasonal_rates,json=seasonalRates,proto3" json:"seasonal_rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeasonalRatesResponse) Reset() {
+	*x = ListSeasonalRatesResponse{}
+	mi := &file_catalog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeasonalRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeasonalRatesResponse) ProtoMessage() {}
+
+func (x *ListSeasonalRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeasonalRatesResponse.ProtoReflect.Descriptor instead.
+func (*ListSeasonalRatesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListSeasonalRatesResponse) GetSeasonalRates() []*SeasonalRate {
+	if x != nil {
+		return x.SeasonalRates
+	}
+	return nil
+}
+
+type DeleteSeasonalRateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId        string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // UUID
+	SeasonalRateId int64                  `protobuf:"varint,2,opt,name=seasonal_rate_id,json=seasonalRateId,proto3" json:"seasonal_rate_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteSeasonalRateRequest) Reset() {
+	*x = DeleteSeasonalRateRequest{}
+	mi := &file_catalog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSeasonalRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSeasonalRateRequest) ProtoMessage() {}
+
+func (x *DeleteSeasonalRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSeasonalRateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSeasonalRateRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteSeasonalRateRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *DeleteSeasonalRateRequest) GetSeasonalRateId() int64 {
+	if x != nil {
+		return x.SeasonalRateId
+	}
+	return 0
+}
+
+type DeleteSeasonalRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSeasonalRateResponse) Reset() {
+	*x = DeleteSeasonalRateResponse{}
+	mi := &file_catalog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSeasonalRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSeasonalRateResponse) ProtoMessage() {}
+
+func (x *DeleteSeasonalRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSeasonalRateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSeasonalRateResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteSeasonalRateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_catalog_proto protoreflect.FileDescriptor
+
+const file_catalog_proto_rawDesc = "" +
+	"\n" +
+	"\rcatalog.proto\x12\acatalog\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa1\x02\n" +
+	"\bProperty\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x1b\n" +
+	"\tis_active\x18\x06 \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\"\n" +
+	"\rcheck_in_time\x18\b \x01(\tR\vcheckInTime\x12$\n" +
+	"\x0echeck_out_time\x18\t \x01(\tR\fcheckOutTime\"\xc8\x02\n" +
+	"\x04Room\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vproperty_id\x18\x02 \x01(\x03R\n" +
+	"propertyId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12\x1c\n" +
+	"\tamenities\x18\x05 \x03(\tR\tamenities\x12$\n" +
+	"\x0elock_device_id\x18\x06 \x01(\tR\flockDeviceId\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1b\n" +
+	"\tbase_rate\x18\t \x01(\x03R\bbaseRate\x12&\n" +
+	"\x0fmin_stay_nights\x18\n" +
+	" \x01(\x05R\rminStayNights\"\xe0\x01\n" +
+	"\fSeasonalRate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12!\n" +
+	"\fnightly_rate\x18\x06 \x01(\x03R\vnightlyRate\"\xc6\x01\n" +
+	"\x15CreatePropertyRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12\"\n" +
+	"\rcheck_in_time\x18\x05 \x01(\tR\vcheckInTime\x12$\n" +
+	"\x0echeck_out_time\x18\x06 \x01(\tR\fcheckOutTime\"G\n" +
+	"\x16CreatePropertyResponse\x12-\n" +
+	"\bproperty\x18\x01 \x01(\v2\x11.catalog.PropertyR\bproperty\"P\n" +
+	"\x12GetPropertyRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x1f\n" +
+	"\vproperty_id\x18\x02 \x01(\x03R\n" +
+	"propertyId\"D\n" +
+	"\x13GetPropertyResponse\x12-\n" +
+	"\bproperty\x18\x01 \x01(\v2\x11.catalog.PropertyR\bproperty\"2\n" +
+	"\x15ListPropertiesRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\"K\n" +
+	"\x16ListPropertiesResponse\x121\n" +
+	"\n" +
+	"properties\x18\x01 \x03(\v2\x11.catalog.PropertyR\n" +
+	"properties\"\x84\x02\n" +
+	"\x15UpdatePropertyRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x1f\n" +
+	"\vproperty_id\x18\x02 \x01(\x03R\n" +
+	"propertyId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x1b\n" +
+	"\tis_active\x18\x06 \x01(\bR\bisActive\x12\"\n" +
+	"\rcheck_in_time\x18\a \x01(\tR\vcheckInTime\x12$\n" +
+	"\x0echeck_out_time\x18\b \x01(\tR\fcheckOutTime\"G\n" +
+	"\x16UpdatePropertyResponse\x12-\n" +
+	"\bproperty\x18\x01 \x01(\v2\x11.catalog.PropertyR\bproperty\"S\n" +
+	"\x15DeletePropertyRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x1f\n" +
+	"\vproperty_id\x18\x02 \x01(\x03R\n" +
+	"propertyId\"2\n" +
+	"\x16DeletePropertyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x88\x02\n" +
+	"\x11CreateRoomRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x1f\n" +
+	"\vproperty_id\x18\x02 \x01(\x03R\n" +
+	"propertyId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12\x1c\n" +
+	"\tamenities\x18\x05 \x03(\tR\tamenities\x12$\n" +
+	"\x0elock_device_id\x18\x06 \x01(\tR\flockDeviceId\x12\x1b\n" +
+	"\tbase_rate\x18\a \x01(\x03R\bbaseRate\x12&\n" +
+	"\x0fmin_stay_nights\x18\b \x01(\x05R\rminStayNights\"7\n" +
+	"\x12CreateRoomResponse\x12!\n" +
+	"\x04room\x18\x01 \x01(\v2\r.catalog.RoomR\x04room\"D\n" +
+	"\x0eGetRoomRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\"4\n" +
+	"\x0fGetRoomResponse\x12!\n" +
+	"\x04room\x18\x01 \x01(\v2\r.catalog.RoomR\x04room\"N\n" +
+	"\x10ListRoomsRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x1f\n" +
+	"\vproperty_id\x18\x02 \x01(\x03R\n" +
+	"propertyId\"8\n" +
+	"\x11ListRoomsResponse\x12#\n" +
+	"\x05rooms\x18\x01 \x03(\v2\r.catalog.RoomR\x05rooms\"\x9d\x02\n" +
+	"\x11UpdateRoomRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12\x1c\n" +
+	"\tamenities\x18\x05 \x03(\tR\tamenities\x12$\n" +
+	"\x0elock_device_id\x18\x06 \x01(\tR\flockDeviceId\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x1b\n" +
+	"\tbase_rate\x18\b \x01(\x03R\bbaseRate\x12&\n" +
+	"\x0fmin_stay_nights\x18\t \x01(\x05R\rminStayNights\"7\n" +
+	"\x12UpdateRoomResponse\x12!\n" +
+	"\x04room\x18\x01 \x01(\v2\r.catalog.RoomR\x04room\"G\n" +
+	"\x11DeleteRoomRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\".\n" +
+	"\x12DeleteRoomResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xf8\x01\n" +
+	"\x19CreateSeasonalRateRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12!\n" +
+	"\fnightly_rate\x18\x06 \x01(\x03R\vnightlyRate\"X\n" +
+	"\x1aCreateSeasonalRateResponse\x12:\n" +
+	"\rseasonal_rate\x18\x01 \x01(\v2\x15.catalog.SeasonalRateR\fseasonalRate\"N\n" +
+	"\x18ListSeasonalRatesRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\"Y\n" +
+	"\x19ListSeasonalRatesResponse\x12<\n" +
+	"\x0eseasonal_rates\x18\x01 \x03(\v2\x15.catalog.SeasonalRateR\rseasonalRates\"`\n" +
+	"\x19DeleteSeasonalRateRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12(\n" +
+	"\x10seasonal_rate_id\x18\x02 \x01(\x03R\x0eseasonalRateId\"6\n" +
+	"\x1aDeleteSeasonalRateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\x97\b\n" +
+	"\x0eCatalogService\x12Q\n" +
+	"\x0eCreateProperty\x12\x1e.catalog.CreatePropertyRequest\x1a\x1f.catalog.CreatePropertyResponse\x12H\n" +
+	"\vGetProperty\x12\x1b.catalog.GetPropertyRequest\x1a\x1c.catalog.GetPropertyResponse\x12Q\n" +
+	"\x0eListProperties\x12\x1e.catalog.ListPropertiesRequest\x1a\x1f.catalog.ListPropertiesResponse\x12Q\n" +
+	"\x0eUpdateProperty\x12\x1e.catalog.UpdatePropertyRequest\x1a\x1f.catalog.UpdatePropertyResponse\x12Q\n" +
+	"\x0eDeleteProperty\x12\x1e.catalog.DeletePropertyRequest\x1a\x1f.catalog.DeletePropertyResponse\x12E\n" +
+	"\n" +
+	"CreateRoom\x12\x1a.catalog.CreateRoomRequest\x1a\x1b.catalog.CreateRoomResponse\x12<\n" +
+	"\aGetRoom\x12\x17.catalog.GetRoomRequest\x1a\x18.catalog.GetRoomResponse\x12B\n" +
+	"\tListRooms\x12\x19.catalog.ListRoomsRequest\x1a\x1a.catalog.ListRoomsResponse\x12E\n" +
+	"\n" +
+	"UpdateRoom\x12\x1a.catalog.UpdateRoomRequest\x1a\x1b.catalog.UpdateRoomResponse\x12E\n" +
+	"\n" +
+	"DeleteRoom\x12\x1a.catalog.DeleteRoomRequest\x1a\x1b.catalog.DeleteRoomResponse\x12]\n" +
+	"\x12CreateSeasonalRate\x12\".catalog.CreateSeasonalRateRequest\x1a#.catalog.CreateSeasonalRateResponse\x12Z\n" +
+	"\x11ListSeasonalRates\x12!.catalog.ListSeasonalRatesRequest\x1a\".catalog.ListSeasonalRatesResponse\x12]\n" +
+	"\x12DeleteSeasonalRate\x12\".catalog.DeleteSeasonalRateRequest\x1a#.catalog.DeleteSeasonalRateResponseB>Z<github.com/karimiku/smart-stay-platform/pkg/genproto/catalogb\x06proto3"
+
+var (
+	file_catalog_proto_rawDescOnce sync.Once
+	file_catalog_proto_rawDescData []byte
+)
+
+func file_catalog_proto_rawDescGZIP() []byte {
+	file_catalog_proto_rawDescOnce.Do(func() {
+		file_catalog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)))
+	})
+	return file_catalog_proto_rawDescData
+}
+
+var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_catalog_proto_goTypes = []any{
+	(*Property)(nil),                   // 0: catalog.Property
+	(*Room)(nil),                       // 1: catalog.Room
+	(*SeasonalRate)(nil),               // 2: catalog.SeasonalRate
+	(*CreatePropertyRequest)(nil),      // 3: catalog.CreatePropertyRequest
+	(*CreatePropertyResponse)(nil),     // 4: catalog.CreatePropertyResponse
+	(*GetPropertyRequest)(nil),         // 5: catalog.GetPropertyRequest
+	(*GetPropertyResponse)(nil),        // 6: catalog.GetPropertyResponse
+	(*ListPropertiesRequest)(nil),      // 7: catalog.ListPropertiesRequest
+	(*ListPropertiesResponse)(nil),     // 8: catalog.ListPropertiesResponse
+	(*UpdatePropertyRequest)(nil),      // 9: catalog.UpdatePropertyRequest
+	(*UpdatePropertyResponse)(nil),     // 10: catalog.UpdatePropertyResponse
+	(*DeletePropertyRequest)(nil),      // 11: catalog.DeletePropertyRequest
+	(*DeletePropertyResponse)(nil),     // 12: catalog.DeletePropertyResponse
+	(*CreateRoomRequest)(nil),          // 13: catalog.CreateRoomRequest
+	(*CreateRoomResponse)(nil),         // 14: catalog.CreateRoomResponse
+	(*GetRoomRequest)(nil),             // 15: catalog.GetRoomRequest
+	(*GetRoomResponse)(nil),            // 16: catalog.GetRoomResponse
+	(*ListRoomsRequest)(nil),           // 17: catalog.ListRoomsRequest
+	(*ListRoomsResponse)(nil),          // 18: catalog.ListRoomsResponse
+	(*UpdateRoomRequest)(nil),          // 19: catalog.UpdateRoomRequest
+	(*UpdateRoomResponse)(nil),         // 20: catalog.UpdateRoomResponse
+	(*DeleteRoomRequest)(nil),          // 21: catalog.DeleteRoomRequest
+	(*DeleteRoomResponse)(nil),         // 22: catalog.DeleteRoomResponse
+	(*CreateSeasonalRateRequest)(nil),  // 23: catalog.CreateSeasonalRateRequest
+	(*CreateSeasonalRateResponse)(nil), // 24: catalog.CreateSeasonalRateResponse
+	(*ListSeasonalRatesRequest)(nil),   // 25: catalog.ListSeasonalRatesRequest
+	(*ListSeasonalRatesResponse)(nil),  // 26: catalog.ListSeasonalRatesResponse
+	(*DeleteSeasonalRateRequest)(nil),  // 27: catalog.DeleteSeasonalRateRequest
+	(*DeleteSeasonalRateResponse)(nil), // 28: catalog.DeleteSeasonalRateResponse
+	(*timestamppb.Timestamp)(nil),      // 29: google.protobuf.Timestamp
+}
+var file_catalog_proto_depIdxs = []int32{
+	29, // 0: catalog.Property.created_at:type_name -> google.protobuf.Timestamp
+	29, // 1: catalog.Room.created_at:type_name -> google.protobuf.Timestamp
+	29, // 2: catalog.SeasonalRate.start_date:type_name -> google.protobuf.Timestamp
+	29, // 3: catalog.SeasonalRate.end_date:type_name -> google.protobuf.Timestamp
+	0,  // 4: catalog.CreatePropertyResponse.property:type_name -> catalog.Property
+	0,  // 5: catalog.GetPropertyResponse.property:type_name -> catalog.Property
+	0,  // 6: catalog.ListPropertiesResponse.properties:type_name -> catalog.Property
+	0,  // 7: catalog.UpdatePropertyResponse.property:type_name -> catalog.Property
+	1,  // 8: catalog.CreateRoomResponse.room:type_name -> catalog.Room
+	1,  // 9: catalog.GetRoomResponse.room:type_name -> catalog.Room
+	1,  // 10: catalog.ListRoomsResponse.rooms:type_name -> catalog.Room
+	1,  // 11: catalog.UpdateRoomResponse.room:type_name -> catalog.Room
+	29, // 12: catalog.CreateSeasonalRateRequest.start_date:type_name -> google.protobuf.Timestamp
+	29, // 13: catalog.CreateSeasonalRateRequest.end_date:type_name -> google.protobuf.Timestamp
+	2,  // 14: catalog.CreateSeasonalRateResponse.seasonal_rate:type_name -> catalog.SeasonalRate
+	2,  // 15: catalog.ListSeasonalRatesResponse.seasonal_rates:type_name -> catalog.SeasonalRate
+	3,  // 16: catalog.CatalogService.CreateProperty:input_type -> catalog.CreatePropertyRequest
+	5,  // 17: catalog.CatalogService.GetProperty:input_type -> catalog.GetPropertyRequest
+	7,  // 18: catalog.CatalogService.ListProperties:input_type -> catalog.ListPropertiesRequest
+	9,  // 19: catalog.CatalogService.UpdateProperty:input_type -> catalog.UpdatePropertyRequest
+	11, // 20: catalog.CatalogService.DeleteProperty:input_type -> catalog.DeletePropertyRequest
+	13, // 21: catalog.CatalogService.CreateRoom:input_type -> catalog.CreateRoomRequest
+	15, // 22: catalog.CatalogService.GetRoom:input_type -> catalog.GetRoomRequest
+	17, // 23: catalog.CatalogService.ListRooms:input_type -> catalog.ListRoomsRequest
+	19, // 24: catalog.CatalogService.UpdateRoom:input_type -> catalog.UpdateRoomRequest
+	21, // 25: catalog.CatalogService.DeleteRoom:input_type -> catalog.DeleteRoomRequest
+	23, // 26: catalog.CatalogService.CreateSeasonalRate:input_type -> catalog.CreateSeasonalRateRequest
+	25, // 27: catalog.CatalogService.ListSeasonalRates:input_type -> catalog.ListSeasonalRatesRequest
+	27, // 28: catalog.CatalogService.DeleteSeasonalRate:input_type -> catalog.DeleteSeasonalRateRequest
+	4,  // 29: catalog.CatalogService.CreateProperty:output_type -> catalog.CreatePropertyResponse
+	6,  // 30: catalog.CatalogService.GetProperty:output_type -> catalog.GetPropertyResponse
+	8,  // 31: catalog.CatalogService.ListProperties:output_type -> catalog.ListPropertiesResponse
+	10, // 32: catalog.CatalogService.UpdateProperty:output_type -> catalog.UpdatePropertyResponse
+	12, // 33: catalog.CatalogService.DeleteProperty:output_type -> catalog.DeletePropertyResponse
+	14, // 34: catalog.CatalogService.CreateRoom:output_type -> catalog.CreateRoomResponse
+	16, // 35: catalog.CatalogService.GetRoom:output_type -> catalog.GetRoomResponse
+	18, // 36: catalog.CatalogService.ListRooms:output_type -> catalog.ListRoomsResponse
+	20, // 37: catalog.CatalogService.UpdateRoom:output_type -> catalog.UpdateRoomResponse
+	22, // 38: catalog.CatalogService.DeleteRoom:output_type -> catalog.DeleteRoomResponse
+	24, // 39: catalog.CatalogService.CreateSeasonalRate:output_type -> catalog.CreateSeasonalRateResponse
+	26, // 40: catalog.CatalogService.ListSeasonalRates:output_type -> catalog.ListSeasonalRatesResponse
+	28, // 41: catalog.CatalogService.DeleteSeasonalRate:output_type -> catalog.DeleteSeasonalRateResponse
+	29, // [29:42] is the sub-list for method output_type
+	16, // [16:29] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_catalog_proto_init() }
+func file_catalog_proto_init() {
+	if File_catalog_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catalog_proto_goTypes,
+		DependencyIndexes: file_catalog_proto_depIdxs,
+		MessageInfos:      file_catalog_proto_msgTypes,
+	}.Build()
+	File_catalog_proto = out.File
+	file_catalog_proto_goTypes = nil
+	file_catalog_proto_depIdxs = nil
+}
@@ -22,6 +22,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// KeyStatus represents the lifecycle of a key.
+type KeyStatus int32
+
+const (
+	// The key opens the door during its validity period.
+	KeyStatus_ACTIVE KeyStatus = 0
+	// The key was revoked before it expired (cancellation, check-out, owner action).
+	KeyStatus_REVOKED KeyStatus = 1
+	// The validity period has ended.
+	KeyStatus_EXPIRED KeyStatus = 2
+)
+
+// Enum value maps for KeyStatus.
+var (
+	KeyStatus_name = map[int32]string{
+		0: "ACTIVE",
+		1: "REVOKED",
+		2: "EXPIRED",
+	}
+	KeyStatus_value = map[string]int32{
+		"ACTIVE":  0,
+		"REVOKED": 1,
+		"EXPIRED": 2,
+	}
+)
+
+func (x KeyStatus) Enum() *KeyStatus {
+	p := new(KeyStatus)
+	*p = x
+	return p
+}
+
+func (x KeyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_key_proto_enumTypes[0].Descriptor()
+}
+
+func (KeyStatus) Type() protoreflect.EnumType {
+	return &file_key_proto_enumTypes[0]
+}
+
+func (x KeyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyStatus.Descriptor instead.
+func (KeyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_key_proto_rawDescGZIP(), []int{0}
+}
+
 // The request message for key generation.
 type GenerateKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -140,6 +193,9 @@ func (x *GenerateKeyResponse) GetDeviceId() string {
 type RevokeKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                                    // Recorded on the key (e.g., "CHECKED_OUT", "RESERVATION_CANCELLED").
+	RequesterId   string                 `protobuf:"bytes,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`       // UUID of the caller; empty for internal (system) revocations.
+	RequesterRole string                 `protobuf:"bytes,4,opt,name=requester_role,json=requesterRole,proto3" json:"requester_role,omitempty"` // Role of the caller ("guest" or "owner").
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -181,10 +237,32 @@ func (x *RevokeKeyRequest) GetReservationId() string {
 	return ""
 }
 
+func (x *RevokeKeyRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RevokeKeyRequest) GetRequesterId() string {
+	if x != nil {
+		return x.RequesterId
+	}
+	return ""
+}
+
+func (x *RevokeKeyRequest) GetRequesterRole() string {
+	if x != nil {
+		return x.RequesterRole
+	}
+	return ""
+}
+
 // The response message for key revocation.
 type RevokeKeyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Key           *Key                   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"` // The revoked key.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -226,6 +304,13 @@ func (x *RevokeKeyResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *RevokeKeyResponse) GetKey() *Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
 // The request message for listing keys.
 type ListKeysRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -324,6 +409,9 @@ type Key struct {
 	ReservationId string                 `protobuf:"bytes,3,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
 	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
 	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	Status        KeyStatus              `protobuf:"varint,6,opt,name=status,proto3,enum=key.KeyStatus" json:"status,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"` // Unset unless REVOKED.
+	RevokeReason  string                 `protobuf:"bytes,8,opt,name=revoke_reason,json=revokeReason,proto3" json:"revoke_reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -393,6 +481,27 @@ func (x *Key) GetValidUntil() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Key) GetStatus() KeyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return KeyStatus_ACTIVE
+}
+
+func (x *Key) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *Key) GetRevokeReason() string {
+	if x != nil {
+		return x.RevokeReason
+	}
+	return ""
+}
+
 var File_key_proto protoreflect.FileDescriptor
 
 const file_key_proto_rawDesc = "" +
@@ -406,15 +515,19 @@ const file_key_proto_rawDesc = "" +
 	"validUntil\"M\n" +
 	"\x13GenerateKeyResponse\x12\x19\n" +
 	"\bkey_code\x18\x01 \x01(\tR\akeyCode\x12\x1b\n" +
-	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\"9\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\"\x9b\x01\n" +
 	"\x10RevokeKeyRequest\x12%\n" +
-	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"-\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12!\n" +
+	"\frequester_id\x18\x03 \x01(\tR\vrequesterId\x12%\n" +
+	"\x0erequester_role\x18\x04 \x01(\tR\rrequesterRole\"I\n" +
 	"\x11RevokeKeyResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"*\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\x03key\x18\x02 \x01(\v2\b.key.KeyR\x03key\"*\n" +
 	"\x0fListKeysRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"0\n" +
 	"\x10ListKeysResponse\x12\x1c\n" +
-	"\x04keys\x18\x01 \x03(\v2\b.key.KeyR\x04keys\"\xdc\x01\n" +
+	"\x04keys\x18\x01 \x03(\v2\b.key.KeyR\x04keys\"\xe4\x02\n" +
 	"\x03Key\x12\x19\n" +
 	"\bkey_code\x18\x01 \x01(\tR\akeyCode\x12\x1b\n" +
 	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12%\n" +
@@ -422,7 +535,16 @@ const file_key_proto_rawDesc = "" +
 	"\n" +
 	"valid_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x12;\n" +
 	"\vvalid_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"validUntil2\xc3\x01\n" +
+	"validUntil\x12&\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x0e.key.KeyStatusR\x06status\x129\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12#\n" +
+	"\rrevoke_reason\x18\b \x01(\tR\frevokeReason*1\n" +
+	"\tKeyStatus\x12\n" +
+	"\n" +
+	"\x06ACTIVE\x10\x00\x12\v\n" +
+	"\aREVOKED\x10\x01\x12\v\n" +
+	"\aEXPIRED\x10\x022\xc3\x01\n" +
 	"\n" +
 	"KeyService\x12@\n" +
 	"\vGenerateKey\x12\x17.key.GenerateKeyRequest\x1a\x18.key.GenerateKeyResponse\x12:\n" +
@@ -441,34 +563,39 @@ func file_key_proto_rawDescGZIP() []byte {
 	return file_key_proto_rawDescData
 }
 
+var file_key_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_key_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_key_proto_goTypes = []any{
-	(*GenerateKeyRequest)(nil),    // 0: key.GenerateKeyRequest
-	(*GenerateKeyResponse)(nil),   // 1: key.GenerateKeyResponse
-	(*RevokeKeyRequest)(nil),      // 2: key.RevokeKeyRequest
-	(*RevokeKeyResponse)(nil),     // 3: key.RevokeKeyResponse
-	(*ListKeysRequest)(nil),       // 4: key.ListKeysRequest
-	(*ListKeysResponse)(nil),      // 5: key.ListKeysResponse
-	(*Key)(nil),                   // 6: key.Key
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(KeyStatus)(0),                // 0: key.KeyStatus
+	(*GenerateKeyRequest)(nil),    // 1: key.GenerateKeyRequest
+	(*GenerateKeyResponse)(nil),   // 2: key.GenerateKeyResponse
+	(*RevokeKeyRequest)(nil),      // 3: key.RevokeKeyRequest
+	(*RevokeKeyResponse)(nil),     // 4: key.RevokeKeyResponse
+	(*ListKeysRequest)(nil),       // 5: key.ListKeysRequest
+	(*ListKeysResponse)(nil),      // 6: key.ListKeysResponse
+	(*Key)(nil),                   // 7: key.Key
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
 }
 var file_key_proto_depIdxs = []int32{
-	7, // 0: key.GenerateKeyRequest.valid_from:type_name -> google.protobuf.Timestamp
-	7, // 1: key.GenerateKeyRequest.valid_until:type_name -> google.protobuf.Timestamp
-	6, // 2: key.ListKeysResponse.keys:type_name -> key.Key
-	7, // 3: key.Key.valid_from:type_name -> google.protobuf.Timestamp
-	7, // 4: key.Key.valid_until:type_name -> google.protobuf.Timestamp
-	0, // 5: key.KeyService.GenerateKey:input_type -> key.GenerateKeyRequest
-	2, // 6: key.KeyService.RevokeKey:input_type -> key.RevokeKeyRequest
-	4, // 7: key.KeyService.ListKeys:input_type -> key.ListKeysRequest
-	1, // 8: key.KeyService.GenerateKey:output_type -> key.GenerateKeyResponse
-	3, // 9: key.KeyService.RevokeKey:output_type -> key.RevokeKeyResponse
-	5, // 10: key.KeyService.ListKeys:output_type -> key.ListKeysResponse
-	8, // [8:11] is the sub-list for method output_type
-	5, // [5:8] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	8,  // 0: key.GenerateKeyRequest.valid_from:type_name -> google.protobuf.Timestamp
+	8,  // 1: key.GenerateKeyRequest.valid_until:type_name -> google.protobuf.Timestamp
+	7,  // 2: key.RevokeKeyResponse.key:type_name -> key.Key
+	7,  // 3: key.ListKeysResponse.keys:type_name -> key.Key
+	8,  // 4: key.Key.valid_from:type_name -> google.protobuf.Timestamp
+	8,  // 5: key.Key.valid_until:type_name -> google.protobuf.Timestamp
+	0,  // 6: key.Key.status:type_name -> key.KeyStatus
+	8,  // 7: key.Key.revoked_at:type_name -> google.protobuf.Timestamp
+	1,  // 8: key.KeyService.GenerateKey:input_type -> key.GenerateKeyRequest
+	3,  // 9: key.KeyService.RevokeKey:input_type -> key.RevokeKeyRequest
+	5,  // 10: key.KeyService.ListKeys:input_type -> key.ListKeysRequest
+	2,  // 11: key.KeyService.GenerateKey:output_type -> key.GenerateKeyResponse
+	4,  // 12: key.KeyService.RevokeKey:output_type -> key.RevokeKeyResponse
+	6,  // 13: key.KeyService.ListKeys:output_type -> key.ListKeysResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_key_proto_init() }
@@ -481,13 +608,14 @@ func file_key_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_key_proto_rawDesc), len(file_key_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_key_proto_goTypes,
 		DependencyIndexes: file_key_proto_depIdxs,
+		EnumInfos:         file_key_proto_enumTypes,
 		MessageInfos:      file_key_proto_msgTypes,
 	}.Build()
 	File_key_proto = out.File
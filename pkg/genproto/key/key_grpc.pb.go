@@ -33,9 +33,12 @@ const (
 type KeyServiceClient interface {
 	// Generates a digital key code for a specific reservation.
 	// The key will be valid only during the specified time window.
+	// Internal: keys are issued from ReservationCreated events, and the API Gateway does not expose it.
+	// Fails with ALREADY_EXISTS if the reservation already has a key; an existing code is never returned.
 	GenerateKey(ctx context.Context, in *GenerateKeyRequest, opts ...grpc.CallOption) (*GenerateKeyResponse, error)
 	// Immediately revokes a digital key.
 	// This is a synchronous operation used for security-critical actions like check-out.
+	// Revoking an already revoked key is a no-op that returns the revoked key.
 	RevokeKey(ctx context.Context, in *RevokeKeyRequest, opts ...grpc.CallOption) (*RevokeKeyResponse, error)
 	// Retrieves all keys for a specific user.
 	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
@@ -88,9 +91,12 @@ func (c *keyServiceClient) ListKeys(ctx context.Context, in *ListKeysRequest, op
 type KeyServiceServer interface {
 	// Generates a digital key code for a specific reservation.
 	// The key will be valid only during the specified time window.
+	// Internal: keys are issued from ReservationCreated events, and the API Gateway does not expose it.
+	// Fails with ALREADY_EXISTS if the reservation already has a key; an existing code is never returned.
 	GenerateKey(context.Context, *GenerateKeyRequest) (*GenerateKeyResponse, error)
 	// Immediately revokes a digital key.
 	// This is a synchronous operation used for security-critical actions like check-out.
+	// Revoking an already revoked key is a no-op that returns the revoked key.
 	RevokeKey(context.Context, *RevokeKeyRequest) (*RevokeKeyResponse, error)
 	// Retrieves all keys for a specific user.
 	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
@@ -80,16 +80,21 @@ func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
 }
 
 type Reservation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // UUID
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID
-	RoomId        int64                  `protobuf:"varint,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	StartDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	TotalPrice    int64                  `protobuf:"varint,6,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	Status        ReservationStatus      `protobuf:"varint,7,opt,name=status,proto3,enum=reservation.ReservationStatus" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // UUID
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID
+	RoomId          int64                  `protobuf:"varint,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	StartDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	TotalPrice      int64                  `protobuf:"varint,6,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Status          ReservationStatus      `protobuf:"varint,7,opt,name=status,proto3,enum=reservation.ReservationStatus" json:"status,omitempty"`
+	PriceBreakdown  []*PriceLineItem       `protobuf:"bytes,8,rep,name=price_breakdown,json=priceBreakdown,proto3" json:"price_breakdown,omitempty"`     // Itemized price computed at booking time.
+	CancellationFee int64                  `protobuf:"varint,9,opt,name=cancellation_fee,json=cancellationFee,proto3" json:"cancellation_fee,omitempty"` // Charged when the reservation was cancelled.
+	CancelledAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`             // Unset unless CANCELLED by the guest.
+	CheckInAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=check_in_at,json=checkInAt,proto3" json:"check_in_at,omitempty"`                 // Check-in instant (start_date at the property's local check-in time).
+	CheckOutAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=check_out_at,json=checkOutAt,proto3" json:"check_out_at,omitempty"`              // Check-out instant (end_date at the property's local check-out time).
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Reservation) Reset() {
@@ -171,6 +176,41 @@ func (x *Reservation) GetStatus() ReservationStatus {
 	return ReservationStatus_PENDING
 }
 
+func (x *Reservation) GetPriceBreakdown() []*PriceLineItem {
+	if x != nil {
+		return x.PriceBreakdown
+	}
+	return nil
+}
+
+func (x *Reservation) GetCancellationFee() int64 {
+	if x != nil {
+		return x.CancellationFee
+	}
+	return 0
+}
+
+func (x *Reservation) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCheckInAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckInAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCheckOutAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckOutAt
+	}
+	return nil
+}
+
 type CreateReservationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID
@@ -467,47 +507,976 @@ func (x *ListReservationsResponse) GetReservations() []*Reservation {
 	return nil
 }
 
-var File_reservation_proto protoreflect.FileDescriptor
+type SearchAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`   // Check-in date.
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`         // Check-out date (exclusive).
+	Guests        int32                  `protobuf:"varint,3,opt,name=guests,proto3" json:"guests,omitempty"`                         // Number of guests staying.
+	RoomIds       []int64                `protobuf:"varint,4,rep,packed,name=room_ids,json=roomIds,proto3" json:"room_ids,omitempty"` // Optional: restrict the search to these rooms.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_reservation_proto_rawDesc = "" +
-	"\n" +
-	"\x11reservation.proto\x12\vreservation\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9a\x02\n" +
-	"\vReservation\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
-	"\aroom_id\x18\x03 \x01(\x03R\x06roomId\x129\n" +
-	"\n" +
-	"start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1f\n" +
-	"\vtotal_price\x18\x06 \x01(\x03R\n" +
-	"totalPrice\x126\n" +
-	"\x06status\x18\a \x01(\x0e2\x1e.reservation.ReservationStatusR\x06status\"\xbe\x01\n" +
-	"\x18CreateReservationRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x129\n" +
-	"\n" +
-	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"z\n" +
-	"\x19CreateReservationResponse\x12%\n" +
-	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x126\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x1e.reservation.ReservationStatusR\x06status\">\n" +
-	"\x15GetReservationRequest\x12%\n" +
-	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"T\n" +
-	"\x16GetReservationResponse\x12:\n" +
-	"\vreservation\x18\x01 \x01(\v2\x18.reservation.ReservationR\vreservation\"2\n" +
-	"\x17ListReservationsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"X\n" +
-	"\x18ListReservationsResponse\x12<\n" +
-	"\freservations\x18\x01 \x03(\v2\x18.reservation.ReservationR\freservations*M\n" +
-	"\x11ReservationStatus\x12\v\n" +
-	"\aPENDING\x10\x00\x12\r\n" +
-	"\tCONFIRMED\x10\x01\x12\r\n" +
-	"\tCANCELLED\x10\x02\x12\r\n" +
-	"\tCOMPLETED\x10\x032\xb4\x02\n" +
-	"\x12ReservationService\x12b\n" +
-	"\x11CreateReservation\x12%.reservation.CreateReservationRequest\x1a&.reservation.CreateReservationResponse\x12Y\n" +
-	"\x0eGetReservation\x12\".reservation.GetReservationRequest\x1a#.reservation.GetReservationResponse\x12_\n" +
-	"\x10ListReservations\x12$.reservation.ListReservationsRequest\x1a%.reservation.ListReservationsResponseBBZ@github.com/karimiku/smart-stay-platform/pkg/genproto/reservationb\x06proto3"
+func (x *SearchAvailabilityRequest) Reset() {
+	*x = SearchAvailabilityRequest{}
+	mi := &file_reservation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAvailabilityRequest) ProtoMessage() {}
+
+func (x *SearchAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*SearchAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchAvailabilityRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *SearchAvailabilityRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *SearchAvailabilityRequest) GetGuests() int32 {
+	if x != nil {
+		return x.Guests
+	}
+	return 0
+}
+
+func (x *SearchAvailabilityRequest) GetRoomIds() []int64 {
+	if x != nil {
+		return x.RoomIds
+	}
+	return nil
+}
+
+type SearchAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*AvailableRoom       `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAvailabilityResponse) Reset() {
+	*x = SearchAvailabilityResponse{}
+	mi := &file_reservation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAvailabilityResponse) ProtoMessage() {}
+
+func (x *SearchAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*SearchAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchAvailabilityResponse) GetRooms() []*AvailableRoom {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+// A room that is free for the whole requested stay.
+type AvailableRoom struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Nights        []*NightlyPrice        `protobuf:"bytes,2,rep,name=nights,proto3" json:"nights,omitempty"`                            // One entry per night of the stay.
+	TotalPrice    int64                  `protobuf:"varint,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"` // Sum of all nightly prices.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailableRoom) Reset() {
+	*x = AvailableRoom{}
+	mi := &file_reservation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailableRoom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailableRoom) ProtoMessage() {}
+
+func (x *AvailableRoom) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailableRoom.ProtoReflect.Descriptor instead.
+func (*AvailableRoom) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AvailableRoom) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *AvailableRoom) GetNights() []*NightlyPrice {
+	if x != nil {
+		return x.Nights
+	}
+	return nil
+}
+
+func (x *AvailableRoom) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+// The price of a single night.
+type NightlyPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Price         int64                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NightlyPrice) Reset() {
+	*x = NightlyPrice{}
+	mi := &file_reservation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NightlyPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NightlyPrice) ProtoMessage() {}
+
+func (x *NightlyPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NightlyPrice.ProtoReflect.Descriptor instead.
+func (*NightlyPrice) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NightlyPrice) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *NightlyPrice) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type QuotePriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // Check-in date.
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // Check-out date (exclusive).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotePriceRequest) Reset() {
+	*x = QuotePriceRequest{}
+	mi := &file_reservation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotePriceRequest) ProtoMessage() {}
+
+func (x *QuotePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotePriceRequest.ProtoReflect.Descriptor instead.
+func (*QuotePriceRequest) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QuotePriceRequest) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *QuotePriceRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *QuotePriceRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type QuotePriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Nights        []*NightlyPrice        `protobuf:"bytes,2,rep,name=nights,proto3" json:"nights,omitempty"`                            // Price of each night including surcharges.
+	LineItems     []*PriceLineItem       `protobuf:"bytes,3,rep,name=line_items,json=lineItems,proto3" json:"line_items,omitempty"`     // Itemized breakdown (rates, surcharges, discounts).
+	Subtotal      int64                  `protobuf:"varint,4,opt,name=subtotal,proto3" json:"subtotal,omitempty"`                       // Sum of all nights before discounts.
+	Discount      int64                  `protobuf:"varint,5,opt,name=discount,proto3" json:"discount,omitempty"`                       // Total discount.
+	TotalPrice    int64                  `protobuf:"varint,6,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"` // Amount charged for the stay.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotePriceResponse) Reset() {
+	*x = QuotePriceResponse{}
+	mi := &file_reservation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotePriceResponse) ProtoMessage() {}
+
+func (x *QuotePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotePriceResponse.ProtoReflect.Descriptor instead.
+func (*QuotePriceResponse) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QuotePriceResponse) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *QuotePriceResponse) GetNights() []*NightlyPrice {
+	if x != nil {
+		return x.Nights
+	}
+	return nil
+}
+
+func (x *QuotePriceResponse) GetLineItems() []*PriceLineItem {
+	if x != nil {
+		return x.LineItems
+	}
+	return nil
+}
+
+func (x *QuotePriceResponse) GetSubtotal() int64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *QuotePriceResponse) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *QuotePriceResponse) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+// One entry of an itemized price breakdown.
+type PriceLineItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // NIGHT, WEEKEND_SURCHARGE, HOLIDAY_SURCHARGE or LENGTH_OF_STAY_DISCOUNT.
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`      // The night the item applies to (unset for whole-stay items).
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"` // Negative for discounts.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceLineItem) Reset() {
+	*x = PriceLineItem{}
+	mi := &file_reservation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLineItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLineItem) ProtoMessage() {}
+
+func (x *PriceLineItem) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLineItem.ProtoReflect.Descriptor instead.
+func (*PriceLineItem) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PriceLineItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PriceLineItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PriceLineItem) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *PriceLineItem) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type CancelReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID of the caller; must own the reservation.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReservationRequest) Reset() {
+	*x = CancelReservationRequest{}
+	mi := &file_reservation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationRequest) ProtoMessage() {}
+
+func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationRequest.ProtoReflect.Descriptor instead.
+func (*CancelReservationRequest) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CancelReservationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CancelReservationResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId   string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Status          ReservationStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=reservation.ReservationStatus" json:"status,omitempty"`       // Always CANCELLED.
+	CancellationFee int64                  `protobuf:"varint,3,opt,name=cancellation_fee,json=cancellationFee,proto3" json:"cancellation_fee,omitempty"` // Amount retained under the cancellation policy.
+	RefundAmount    int64                  `protobuf:"varint,4,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`          // total_price minus cancellation_fee.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelReservationResponse) Reset() {
+	*x = CancelReservationResponse{}
+	mi := &file_reservation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationResponse) ProtoMessage() {}
+
+func (x *CancelReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationResponse.ProtoReflect.Descriptor instead.
+func (*CancelReservationResponse) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelReservationResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CancelReservationResponse) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_PENDING
+}
+
+func (x *CancelReservationResponse) GetCancellationFee() int64 {
+	if x != nil {
+		return x.CancellationFee
+	}
+	return 0
+}
+
+func (x *CancelReservationResponse) GetRefundAmount() int64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+// A message for a user about one of their reservations.
+type Notification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                            // UUID
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // UUID; empty if not about a reservation.
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                                        // RESERVATION_CONFIRMED, RESERVATION_CANCELLED or STAY_COMPLETED.
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"` // Unset while unread.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_reservation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *Notification) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Notification) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID
+	UnreadOnly    bool                   `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 20; at most 100.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_reservation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListNotificationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	UnreadCount   int64                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"` // All unread notifications of the user, not only the listed ones.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_reservation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type MarkNotificationReadRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID of the caller; must own the notification.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MarkNotificationReadRequest) Reset() {
+	*x = MarkNotificationReadRequest{}
+	mi := &file_reservation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationReadRequest) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MarkNotificationReadRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *MarkNotificationReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type MarkNotificationReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationReadResponse) Reset() {
+	*x = MarkNotificationReadResponse{}
+	mi := &file_reservation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationReadResponse) ProtoMessage() {}
+
+func (x *MarkNotificationReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reservation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkNotificationReadResponse) Descriptor() ([]byte, []int) {
+	return file_reservation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MarkNotificationReadResponse) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+var File_reservation_proto protoreflect.FileDescriptor
+
+const file_reservation_proto_rawDesc = "" +
+	"\n" +
+	"\x11reservation.proto\x12\vreservation\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\x04\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\x03R\x06roomId\x129\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1f\n" +
+	"\vtotal_price\x18\x06 \x01(\x03R\n" +
+	"totalPrice\x126\n" +
+	"\x06status\x18\a \x01(\x0e2\x1e.reservation.ReservationStatusR\x06status\x12C\n" +
+	"\x0fprice_breakdown\x18\b \x03(\v2\x1a.reservation.PriceLineItemR\x0epriceBreakdown\x12)\n" +
+	"\x10cancellation_fee\x18\t \x01(\x03R\x0fcancellationFee\x12=\n" +
+	"\fcancelled_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12:\n" +
+	"\vcheck_in_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcheckInAt\x12<\n" +
+	"\fcheck_out_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"checkOutAt\"\xbe\x01\n" +
+	"\x18CreateReservationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\x03R\x06roomId\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"z\n" +
+	"\x19CreateReservationResponse\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.reservation.ReservationStatusR\x06status\">\n" +
+	"\x15GetReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"T\n" +
+	"\x16GetReservationResponse\x12:\n" +
+	"\vreservation\x18\x01 \x01(\v2\x18.reservation.ReservationR\vreservation\"2\n" +
+	"\x17ListReservationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"X\n" +
+	"\x18ListReservationsResponse\x12<\n" +
+	"\freservations\x18\x01 \x03(\v2\x18.reservation.ReservationR\freservations\"\xc0\x01\n" +
+	"\x19SearchAvailabilityRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x16\n" +
+	"\x06guests\x18\x03 \x01(\x05R\x06guests\x12\x19\n" +
+	"\broom_ids\x18\x04 \x03(\x03R\aroomIds\"N\n" +
+	"\x1aSearchAvailabilityResponse\x120\n" +
+	"\x05rooms\x18\x01 \x03(\v2\x1a.reservation.AvailableRoomR\x05rooms\"|\n" +
+	"\rAvailableRoom\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x121\n" +
+	"\x06nights\x18\x02 \x03(\v2\x19.reservation.NightlyPriceR\x06nights\x12\x1f\n" +
+	"\vtotal_price\x18\x03 \x01(\x03R\n" +
+	"totalPrice\"T\n" +
+	"\fNightlyPrice\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x03R\x05price\"\x9e\x01\n" +
+	"\x11QuotePriceRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xf4\x01\n" +
+	"\x12QuotePriceResponse\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x03R\x06roomId\x121\n" +
+	"\x06nights\x18\x02 \x03(\v2\x19.reservation.NightlyPriceR\x06nights\x129\n" +
+	"\n" +
+	"line_items\x18\x03 \x03(\v2\x1a.reservation.PriceLineItemR\tlineItems\x12\x1a\n" +
+	"\bsubtotal\x18\x04 \x01(\x03R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\x05 \x01(\x03R\bdiscount\x12\x1f\n" +
+	"\vtotal_price\x18\x06 \x01(\x03R\n" +
+	"totalPrice\"\x8d\x01\n" +
+	"\rPriceLineItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\"Z\n" +
+	"\x18CancelReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xca\x01\n" +
+	"\x19CancelReservationResponse\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.reservation.ReservationStatusR\x06status\x12)\n" +
+	"\x10cancellation_fee\x18\x03 \x01(\x03R\x0fcancellationFee\x12#\n" +
+	"\rrefund_amount\x18\x04 \x01(\x03R\frefundAmount\"\xe3\x01\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\aread_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"j\n" +
+	"\x18ListNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x7f\n" +
+	"\x19ListNotificationsResponse\x12?\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x19.reservation.NotificationR\rnotifications\x12!\n" +
+	"\funread_count\x18\x02 \x01(\x03R\vunreadCount\"_\n" +
+	"\x1bMarkNotificationReadRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"]\n" +
+	"\x1cMarkNotificationReadResponse\x12=\n" +
+	"\fnotification\x18\x01 \x01(\v2\x19.reservation.NotificationR\fnotification*M\n" +
+	"\x11ReservationStatus\x12\v\n" +
+	"\aPENDING\x10\x00\x12\r\n" +
+	"\tCONFIRMED\x10\x01\x12\r\n" +
+	"\tCANCELLED\x10\x02\x12\r\n" +
+	"\tCOMPLETED\x10\x032\x9f\x06\n" +
+	"\x12ReservationService\x12b\n" +
+	"\x11CreateReservation\x12%.reservation.CreateReservationRequest\x1a&.reservation.CreateReservationResponse\x12Y\n" +
+	"\x0eGetReservation\x12\".reservation.GetReservationRequest\x1a#.reservation.GetReservationResponse\x12_\n" +
+	"\x10ListReservations\x12$.reservation.ListReservationsRequest\x1a%.reservation.ListReservationsResponse\x12e\n" +
+	"\x12SearchAvailability\x12&.reservation.SearchAvailabilityRequest\x1a'.reservation.SearchAvailabilityResponse\x12M\n" +
+	"\n" +
+	"QuotePrice\x12\x1e.reservation.QuotePriceRequest\x1a\x1f.reservation.QuotePriceResponse\x12b\n" +
+	"\x11CancelReservation\x12%.reservation.CancelReservationRequest\x1a&.reservation.CancelReservationResponse\x12b\n" +
+	"\x11ListNotifications\x12%.reservation.ListNotificationsRequest\x1a&.reservation.ListNotificationsResponse\x12k\n" +
+	"\x14MarkNotificationRead\x12(.reservation.MarkNotificationReadRequest\x1a).reservation.MarkNotificationReadResponseBBZ@github.com/karimiku/smart-stay-platform/pkg/genproto/reservationb\x06proto3"
 
 var (
 	file_reservation_proto_rawDescOnce sync.Once
@@ -522,38 +1491,81 @@ func file_reservation_proto_rawDescGZIP() []byte {
 }
 
 var file_reservation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_reservation_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_reservation_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_reservation_proto_goTypes = []any{
-	(ReservationStatus)(0),            // 0: reservation.ReservationStatus
-	(*Reservation)(nil),               // 1: reservation.Reservation
-	(*CreateReservationRequest)(nil),  // 2: reservation.CreateReservationRequest
-	(*CreateReservationResponse)(nil), // 3: reservation.CreateReservationResponse
-	(*GetReservationRequest)(nil),     // 4: reservation.GetReservationRequest
-	(*GetReservationResponse)(nil),    // 5: reservation.GetReservationResponse
-	(*ListReservationsRequest)(nil),   // 6: reservation.ListReservationsRequest
-	(*ListReservationsResponse)(nil),  // 7: reservation.ListReservationsResponse
-	(*timestamppb.Timestamp)(nil),     // 8: google.protobuf.Timestamp
+	(ReservationStatus)(0),               // 0: reservation.ReservationStatus
+	(*Reservation)(nil),                  // 1: reservation.Reservation
+	(*CreateReservationRequest)(nil),     // 2: reservation.CreateReservationRequest
+	(*CreateReservationResponse)(nil),    // 3: reservation.CreateReservationResponse
+	(*GetReservationRequest)(nil),        // 4: reservation.GetReservationRequest
+	(*GetReservationResponse)(nil),       // 5: reservation.GetReservationResponse
+	(*ListReservationsRequest)(nil),      // 6: reservation.ListReservationsRequest
+	(*ListReservationsResponse)(nil),     // 7: reservation.ListReservationsResponse
+	(*SearchAvailabilityRequest)(nil),    // 8: reservation.SearchAvailabilityRequest
+	(*SearchAvailabilityResponse)(nil),   // 9: reservation.SearchAvailabilityResponse
+	(*AvailableRoom)(nil),                // 10: reservation.AvailableRoom
+	(*NightlyPrice)(nil),                 // 11: reservation.NightlyPrice
+	(*QuotePriceRequest)(nil),            // 12: reservation.QuotePriceRequest
+	(*QuotePriceResponse)(nil),           // 13: reservation.QuotePriceResponse
+	(*PriceLineItem)(nil),                // 14: reservation.PriceLineItem
+	(*CancelReservationRequest)(nil),     // 15: reservation.CancelReservationRequest
+	(*CancelReservationResponse)(nil),    // 16: reservation.CancelReservationResponse
+	(*Notification)(nil),                 // 17: reservation.Notification
+	(*ListNotificationsRequest)(nil),     // 18: reservation.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),    // 19: reservation.ListNotificationsResponse
+	(*MarkNotificationReadRequest)(nil),  // 20: reservation.MarkNotificationReadRequest
+	(*MarkNotificationReadResponse)(nil), // 21: reservation.MarkNotificationReadResponse
+	(*timestamppb.Timestamp)(nil),        // 22: google.protobuf.Timestamp
 }
 var file_reservation_proto_depIdxs = []int32{
-	8,  // 0: reservation.Reservation.start_date:type_name -> google.protobuf.Timestamp
-	8,  // 1: reservation.Reservation.end_date:type_name -> google.protobuf.Timestamp
+	22, // 0: reservation.Reservation.start_date:type_name -> google.protobuf.Timestamp
+	22, // 1: reservation.Reservation.end_date:type_name -> google.protobuf.Timestamp
 	0,  // 2: reservation.Reservation.status:type_name -> reservation.ReservationStatus
-	8,  // 3: reservation.CreateReservationRequest.start_date:type_name -> google.protobuf.Timestamp
-	8,  // 4: reservation.CreateReservationRequest.end_date:type_name -> google.protobuf.Timestamp
-	0,  // 5: reservation.CreateReservationResponse.status:type_name -> reservation.ReservationStatus
-	1,  // 6: reservation.GetReservationResponse.reservation:type_name -> reservation.Reservation
-	1,  // 7: reservation.ListReservationsResponse.reservations:type_name -> reservation.Reservation
-	2,  // 8: reservation.ReservationService.CreateReservation:input_type -> reservation.CreateReservationRequest
-	4,  // 9: reservation.ReservationService.GetReservation:input_type -> reservation.GetReservationRequest
-	6,  // 10: reservation.ReservationService.ListReservations:input_type -> reservation.ListReservationsRequest
-	3,  // 11: reservation.ReservationService.CreateReservation:output_type -> reservation.CreateReservationResponse
-	5,  // 12: reservation.ReservationService.GetReservation:output_type -> reservation.GetReservationResponse
-	7,  // 13: reservation.ReservationService.ListReservations:output_type -> reservation.ListReservationsResponse
-	11, // [11:14] is the sub-list for method output_type
-	8,  // [8:11] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	14, // 3: reservation.Reservation.price_breakdown:type_name -> reservation.PriceLineItem
+	22, // 4: reservation.Reservation.cancelled_at:type_name -> google.protobuf.Timestamp
+	22, // 5: reservation.Reservation.check_in_at:type_name -> google.protobuf.Timestamp
+	22, // 6: reservation.Reservation.check_out_at:type_name -> google.protobuf.Timestamp
+	22, // 7: reservation.CreateReservationRequest.start_date:type_name -> google.protobuf.Timestamp
+	22, // 8: reservation.CreateReservationRequest.end_date:type_name -> google.protobuf.Timestamp
+	0,  // 9: reservation.CreateReservationResponse.status:type_name -> reservation.ReservationStatus
+	1,  // 10: reservation.GetReservationResponse.reservation:type_name -> reservation.Reservation
+	1,  // 11: reservation.ListReservationsResponse.reservations:type_name -> reservation.Reservation
+	22, // 12: reservation.SearchAvailabilityRequest.start_date:type_name -> google.protobuf.Timestamp
+	22, // 13: reservation.SearchAvailabilityRequest.end_date:type_name -> google.protobuf.Timestamp
+	10, // 14: reservation.SearchAvailabilityResponse.rooms:type_name -> reservation.AvailableRoom
+	11, // 15: reservation.AvailableRoom.nights:type_name -> reservation.NightlyPrice
+	22, // 16: reservation.NightlyPrice.date:type_name -> google.protobuf.Timestamp
+	22, // 17: reservation.QuotePriceRequest.start_date:type_name -> google.protobuf.Timestamp
+	22, // 18: reservation.QuotePriceRequest.end_date:type_name -> google.protobuf.Timestamp
+	11, // 19: reservation.QuotePriceResponse.nights:type_name -> reservation.NightlyPrice
+	14, // 20: reservation.QuotePriceResponse.line_items:type_name -> reservation.PriceLineItem
+	22, // 21: reservation.PriceLineItem.date:type_name -> google.protobuf.Timestamp
+	0,  // 22: reservation.CancelReservationResponse.status:type_name -> reservation.ReservationStatus
+	22, // 23: reservation.Notification.created_at:type_name -> google.protobuf.Timestamp
+	22, // 24: reservation.Notification.read_at:type_name -> google.protobuf.Timestamp
+	17, // 25: reservation.ListNotificationsResponse.notifications:type_name -> reservation.Notification
+	17, // 26: reservation.MarkNotificationReadResponse.notification:type_name -> reservation.Notification
+	2,  // 27: reservation.ReservationService.CreateReservation:input_type -> reservation.CreateReservationRequest
+	4,  // 28: reservation.ReservationService.GetReservation:input_type -> reservation.GetReservationRequest
+	6,  // 29: reservation.ReservationService.ListReservations:input_type -> reservation.ListReservationsRequest
+	8,  // 30: reservation.ReservationService.SearchAvailability:input_type -> reservation.SearchAvailabilityRequest
+	12, // 31: reservation.ReservationService.QuotePrice:input_type -> reservation.QuotePriceRequest
+	15, // 32: reservation.ReservationService.CancelReservation:input_type -> reservation.CancelReservationRequest
+	18, // 33: reservation.ReservationService.ListNotifications:input_type -> reservation.ListNotificationsRequest
+	20, // 34: reservation.ReservationService.MarkNotificationRead:input_type -> reservation.MarkNotificationReadRequest
+	3,  // 35: reservation.ReservationService.CreateReservation:output_type -> reservation.CreateReservationResponse
+	5,  // 36: reservation.ReservationService.GetReservation:output_type -> reservation.GetReservationResponse
+	7,  // 37: reservation.ReservationService.ListReservations:output_type -> reservation.ListReservationsResponse
+	9,  // 38: reservation.ReservationService.SearchAvailability:output_type -> reservation.SearchAvailabilityResponse
+	13, // 39: reservation.ReservationService.QuotePrice:output_type -> reservation.QuotePriceResponse
+	16, // 40: reservation.ReservationService.CancelReservation:output_type -> reservation.CancelReservationResponse
+	19, // 41: reservation.ReservationService.ListNotifications:output_type -> reservation.ListNotificationsResponse
+	21, // 42: reservation.ReservationService.MarkNotificationRead:output_type -> reservation.MarkNotificationReadResponse
+	35, // [35:43] is the sub-list for method output_type
+	27, // [27:35] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_reservation_proto_init() }
@@ -567,7 +1579,7 @@ func file_reservation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reservation_proto_rawDesc), len(file_reservation_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   7,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReservationService_CreateReservation_FullMethodName = "/reservation.ReservationService/CreateReservation"
-	ReservationService_GetReservation_FullMethodName    = "/reservation.ReservationService/GetReservation"
-	ReservationService_ListReservations_FullMethodName  = "/reservation.ReservationService/ListReservations"
+	ReservationService_CreateReservation_FullMethodName    = "/reservation.ReservationService/CreateReservation"
+	ReservationService_GetReservation_FullMethodName       = "/reservation.ReservationService/GetReservation"
+	ReservationService_ListReservations_FullMethodName     = "/reservation.ReservationService/ListReservations"
+	ReservationService_SearchAvailability_FullMethodName   = "/reservation.ReservationService/SearchAvailability"
+	ReservationService_QuotePrice_FullMethodName           = "/reservation.ReservationService/QuotePrice"
+	ReservationService_CancelReservation_FullMethodName    = "/reservation.ReservationService/CancelReservation"
+	ReservationService_ListNotifications_FullMethodName    = "/reservation.ReservationService/ListNotifications"
+	ReservationService_MarkNotificationRead_FullMethodName = "/reservation.ReservationService/MarkNotificationRead"
 )
 
 // ReservationServiceClient is the client API for ReservationService service.
@@ -41,6 +46,22 @@ type ReservationServiceClient interface {
 	GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*GetReservationResponse, error)
 	// Retrieves all reservations for a specific user.
 	ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+	// Searches for rooms that are free for the whole requested stay.
+	// Each available room is returned with a per-night price breakdown so that
+	// clients can show a calendar before calling CreateReservation.
+	SearchAvailability(ctx context.Context, in *SearchAvailabilityRequest, opts ...grpc.CallOption) (*SearchAvailabilityResponse, error)
+	// Computes the itemized price of a stay without booking it.
+	// The same pricing rules are applied by CreateReservation.
+	QuotePrice(ctx context.Context, in *QuotePriceRequest, opts ...grpc.CallOption) (*QuotePriceResponse, error)
+	// Cancels a PENDING or CONFIRMED reservation owned by the caller.
+	// A cancellation fee is charged according to the cancellation policy, and a
+	// ReservationCancelled event is published so that the Key Service revokes the key (compensation).
+	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error)
+	// Lists the notifications of a user (e.g., a reservation was confirmed), newest first,
+	// together with the number of unread ones.
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	// Marks a notification owned by the caller as read.
+	MarkNotificationRead(ctx context.Context, in *MarkNotificationReadRequest, opts ...grpc.CallOption) (*MarkNotificationReadResponse, error)
 }
 
 type reservationServiceClient struct {
@@ -81,6 +102,56 @@ func (c *reservationServiceClient) ListReservations(ctx context.Context, in *Lis
 	return out, nil
 }
 
+func (c *reservationServiceClient) SearchAvailability(ctx context.Context, in *SearchAvailabilityRequest, opts ...grpc.CallOption) (*SearchAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchAvailabilityResponse)
+	err := c.cc.Invoke(ctx, ReservationService_SearchAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) QuotePrice(ctx context.Context, in *QuotePriceRequest, opts ...grpc.CallOption) (*QuotePriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuotePriceResponse)
+	err := c.cc.Invoke(ctx, ReservationService_QuotePrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelReservationResponse)
+	err := c.cc.Invoke(ctx, ReservationService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, ReservationService_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) MarkNotificationRead(ctx context.Context, in *MarkNotificationReadRequest, opts ...grpc.CallOption) (*MarkNotificationReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkNotificationReadResponse)
+	err := c.cc.Invoke(ctx, ReservationService_MarkNotificationRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReservationServiceServer is the server API for ReservationService service.
 // All implementations must embed UnimplementedReservationServiceServer
 // for forward compatibility.
@@ -98,6 +169,22 @@ type ReservationServiceServer interface {
 	GetReservation(context.Context, *GetReservationRequest) (*GetReservationResponse, error)
 	// Retrieves all reservations for a specific user.
 	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	// Searches for rooms that are free for the whole requested stay.
+	// Each available room is returned with a per-night price breakdown so that
+	// clients can show a calendar before calling CreateReservation.
+	SearchAvailability(context.Context, *SearchAvailabilityRequest) (*SearchAvailabilityResponse, error)
+	// Computes the itemized price of a stay without booking it.
+	// The same pricing rules are applied by CreateReservation.
+	QuotePrice(context.Context, *QuotePriceRequest) (*QuotePriceResponse, error)
+	// Cancels a PENDING or CONFIRMED reservation owned by the caller.
+	// A cancellation fee is charged according to the cancellation policy, and a
+	// ReservationCancelled event is published so that the Key Service revokes the key (compensation).
+	CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error)
+	// Lists the notifications of a user (e.g., a reservation was confirmed), newest first,
+	// together with the number of unread ones.
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	// Marks a notification owned by the caller as read.
+	MarkNotificationRead(context.Context, *MarkNotificationReadRequest) (*MarkNotificationReadResponse, error)
 	mustEmbedUnimplementedReservationServiceServer()
 }
 
@@ -117,6 +204,21 @@ func (UnimplementedReservationServiceServer) GetReservation(context.Context, *Ge
 func (UnimplementedReservationServiceServer) ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
 }
+func (UnimplementedReservationServiceServer) SearchAvailability(context.Context, *SearchAvailabilityRequest) (*SearchAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchAvailability not implemented")
+}
+func (UnimplementedReservationServiceServer) QuotePrice(context.Context, *QuotePriceRequest) (*QuotePriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuotePrice not implemented")
+}
+func (UnimplementedReservationServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedReservationServiceServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedReservationServiceServer) MarkNotificationRead(context.Context, *MarkNotificationReadRequest) (*MarkNotificationReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNotificationRead not implemented")
+}
 func (UnimplementedReservationServiceServer) mustEmbedUnimplementedReservationServiceServer() {}
 func (UnimplementedReservationServiceServer) testEmbeddedByValue()                            {}
 
@@ -192,6 +294,96 @@ func _ReservationService_ListReservations_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReservationService_SearchAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).SearchAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_SearchAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).SearchAvailability(ctx, req.(*SearchAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_QuotePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuotePriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).QuotePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_QuotePrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).QuotePrice(ctx, req.(*QuotePriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).CancelReservation(ctx, req.(*CancelReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_MarkNotificationRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNotificationReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).MarkNotificationRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_MarkNotificationRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).MarkNotificationRead(ctx, req.(*MarkNotificationReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReservationService_ServiceDesc is the grpc.ServiceDesc for ReservationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -211,6 +403,26 @@ var ReservationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListReservations",
 			Handler:    _ReservationService_ListReservations_Handler,
 		},
+		{
+			MethodName: "SearchAvailability",
+			Handler:    _ReservationService_SearchAvailability_Handler,
+		},
+		{
+			MethodName: "QuotePrice",
+			Handler:    _ReservationService_QuotePrice_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _ReservationService_CancelReservation_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _ReservationService_ListNotifications_Handler,
+		},
+		{
+			MethodName: "MarkNotificationRead",
+			Handler:    _ReservationService_MarkNotificationRead_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "reservation.proto",